@@ -0,0 +1,85 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cache"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// shortHashLength is the number of characters of the build hash shown by --cache-info
+const shortHashLength = 12
+
+type buildCacheInterface interface {
+	List() ([]cache.BuildCacheEntry, error)
+	Prune(shouldRemove func(cache.BuildCacheEntry) bool) (int, error)
+}
+
+// runCacheInfo lists the entries of the local build cache or, if prune is set, removes them.
+// When services are given, only their entries are listed or pruned
+func runCacheInfo(buildCache buildCacheInterface, services []string, prune bool, w io.Writer) error {
+	matches := func(e cache.BuildCacheEntry) bool {
+		if len(services) == 0 {
+			return true
+		}
+		for _, svc := range services {
+			if e.Service == svc {
+				return true
+			}
+		}
+		return false
+	}
+
+	if prune {
+		removed, err := buildCache.Prune(matches)
+		if err != nil {
+			return err
+		}
+		oktetoLog.Success("Removed %d entries from the build cache", removed)
+		return nil
+	}
+
+	entries, err := buildCache.List()
+	if err != nil {
+		return err
+	}
+	filtered := []cache.BuildCacheEntry{}
+	for _, e := range entries {
+		if matches(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	if len(filtered) == 0 {
+		fmt.Fprintln(w, "The build cache is empty")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	cols := []string{"Service", "Manifest", "Context", "Namespace", "Hash", "Image", "Created"}
+	fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	for _, e := range filtered {
+		hash := e.Hash
+		if len(hash) > shortHashLength {
+			hash = hash[:shortHashLength]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Service, e.Manifest, e.Context, e.Namespace, hash, e.Image, e.CreatedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cache"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCacheInfo(t *testing.T) {
+	buildCache := cache.NewLocalBuildCache(afero.NewMemMapFs(), "/okteto/build-cache.json")
+
+	var out bytes.Buffer
+	require.NoError(t, runCacheInfo(buildCache, nil, false, &out))
+	assert.Equal(t, "The build cache is empty\n", out.String())
+
+	require.NoError(t, buildCache.Set(cache.BuildCacheKey{Context: "ctx", Namespace: "ns", Manifest: "movies", Service: "api", Hash: "0123456789abcdef"}, "okteto.dev/movies-api@sha256:1"))
+	require.NoError(t, buildCache.Set(cache.BuildCacheKey{Context: "ctx", Namespace: "ns", Manifest: "movies", Service: "frontend", Hash: "fedcba"}, "okteto.dev/movies-frontend@sha256:1"))
+
+	out.Reset()
+	require.NoError(t, runCacheInfo(buildCache, []string{"api"}, false, &out))
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), "Service")
+	assert.Contains(t, string(lines[1]), "0123456789ab ")
+	assert.Contains(t, string(lines[1]), "okteto.dev/movies-api@sha256:1")
+
+	require.NoError(t, runCacheInfo(buildCache, []string{"api"}, true, &out))
+	entries, err := buildCache.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "frontend", entries[0].Service)
+	assert.WithinDuration(t, time.Now(), entries[0].CreatedAt, time.Minute)
+
+	require.NoError(t, runCacheInfo(buildCache, nil, true, &out))
+	entries, err = buildCache.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
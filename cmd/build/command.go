@@ -16,6 +16,7 @@ package build
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"os"
@@ -28,7 +29,9 @@ import (
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cache"
 	"github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/discovery"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log/io"
@@ -36,6 +39,7 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
@@ -70,6 +74,8 @@ type registryInterface interface {
 	GetRegistryAndRepo(image string) (string, string)
 	GetRepoNameAndTag(repo string) (string, string)
 	CloneGlobalImageToDev(imageWithDigest, tag string) (string, error)
+	GetAttestationDigests(image string) ([]string, error)
+	SignImage(image string, signer crypto.Signer) error
 }
 
 // NewBuildCommand creates a struct to run all build methods
@@ -91,12 +97,36 @@ const (
 // Build build and optionally push a Docker image
 func Build(ctx context.Context, ioCtrl *io.IOController, at analyticsTrackerInterface) *cobra.Command {
 	options := &types.BuildOptions{}
+	var cacheInfo, cachePrune bool
+	var graph string
 	cmd := &cobra.Command{
 		Use:   "build [service...]",
 		Short: "Build and push the images defined in the 'build' section of your okteto manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cacheInfo || cachePrune {
+				buildCache := cache.NewLocalBuildCache(afero.NewOsFs(), config.GetBuildCachePath())
+				return runCacheInfo(buildCache, args, cachePrune, os.Stdout)
+			}
+
 			options.CommandArgs = args
 			bc := NewBuildCommand(ioCtrl, at)
+			if graph != "" {
+				if err := validateGraphFormat(graph); err != nil {
+					return err
+				}
+				manifest, err := bc.GetManifest(options.File)
+				if err != nil {
+					return err
+				}
+				if !isBuildV2(manifest) {
+					return oktetoErrors.UserError{
+						E:    fmt.Errorf("the build graph is only available for okteto manifests with a 'build' section"),
+						Hint: fmt.Sprintf("Visit %s for more information.", docsURL),
+					}
+				}
+				return runGraph(manifest.Build, args, graph, os.Stdout)
+			}
+
 			// The context must be loaded before reading manifest. Otherwise,
 			// secrets will not be resolved when GetManifest is called and
 			// the manifest will load empty values.
@@ -131,10 +161,21 @@ func Build(ctx context.Context, ioCtrl *io.IOController, at analyticsTrackerInte
 	cmd.Flags().StringArrayVar(&options.ExportCache, "export-cache", nil, "export cache images")
 	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", string(TTYFormat), "show plain/tty build output")
 	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set build-time variables")
-	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secret files exposed to the build. Format: id=mysecret,src=/local/secret")
+	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secrets exposed to the build. Format: id=mysecret,src=/local/secret, id=mysecret,env=MY_SECRET, id=mysecret,okteto=OKTETO_SECRET or id=mysecret,command='gh auth token'")
+	cmd.Flags().StringArrayVar(&options.Outputs, "output", nil, "export the build result instead of pushing the image. Format: type=local|tar|oci,dest=path")
 	cmd.Flags().StringVar(&options.Platform, "platform", "", "set platform if server is multi-platform capable")
+	cmd.Flags().BoolVar(&options.SBOM, "sbom", false, "attach a SBOM attestation to the image (requires BuildKit v0.11 or newer)")
+	cmd.Flags().StringVar(&options.Provenance, "provenance", "", "attach a provenance attestation to the image: 'mode=min' or 'mode=max' (requires BuildKit v0.11 or newer)")
+	cmd.Flags().BoolVar(&options.Sign, "sign", false, "sign the pushed images with a cosign compatible signature")
+	cmd.Flags().StringVar(&options.SignKey, "sign-key", "", "path to the private key used to sign the images (default is the value of OKTETO_SIGN_KEY)")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace against which the image will be consumed. Default is the one defined at okteto context or okteto manifest")
 	cmd.Flags().BoolVarP(&options.BuildToGlobal, "global", "", false, "push the image to the global registry")
+	cmd.Flags().BoolVar(&options.TimingReport, "timing", false, "show the timing of each built service and the critical path of the build")
+	cmd.Flags().IntVar(&options.Parallelism, "parallelism", 0, "maximum number of independent services built at the same time (default is the 'buildParallelism' value of the okteto manifest or 1)")
+	cmd.Flags().BoolVarP(&cacheInfo, "cache-info", "", false, "list the images stored in the local build cache")
+	cmd.Flags().BoolVarP(&cachePrune, "cache-prune", "", false, "remove the images stored in the local build cache")
+	cmd.Flags().StringVar(&graph, "graph", "", "print the graph of the services to build and their dependencies without building them: text, dot or json")
+	cmd.Flags().Lookup("graph").NoOptDefVal = graphTextFormat
 	return cmd
 }
 
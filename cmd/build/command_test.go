@@ -14,6 +14,7 @@
 package build
 
 import (
+	"crypto"
 	"os"
 	"path/filepath"
 	"testing"
@@ -101,6 +102,11 @@ func (fr fakeRegistry) GetRepoNameAndTag(repo string) (string, string)   { retur
 func (fr fakeRegistry) CloneGlobalImageToDev(imageWithDigest, tag string) (string, error) {
 	return "", nil
 }
+func (fr fakeRegistry) GetAttestationDigests(image string) ([]string, error) {
+	return nil, nil
+}
+
+func (fr fakeRegistry) SignImage(_ string, _ crypto.Signer) error { return nil }
 
 var fakeManifestV2 *model.Manifest = &model.Manifest{
 	Build: model.ManifestBuild{
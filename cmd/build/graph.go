@@ -0,0 +1,176 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	// graphTextFormat prints the build graph as a table
+	graphTextFormat = "text"
+
+	// graphDotFormat prints the build graph in the Graphviz DOT language
+	graphDotFormat = "dot"
+
+	// graphJSONFormat prints the build graph as JSON
+	graphJSONFormat = "json"
+)
+
+// graphNode is a service of the build graph
+type graphNode struct {
+	Name      string   `json:"name"`
+	DependsOn []string `json:"dependsOn"`
+	// Stage is the position of the service in the build order: services in the same stage don't depend on each other
+	Stage int `json:"stage"`
+}
+
+func validateGraphFormat(format string) error {
+	switch format {
+	case graphTextFormat, graphDotFormat, graphJSONFormat:
+		return nil
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid graph format '%s'", format),
+			Hint: "Use one of: 'text', 'dot' or 'json'",
+		}
+	}
+}
+
+// runGraph prints the graph of the services to build, and their dependencies, without building them
+func runGraph(buildManifest model.ManifestBuild, services []string, format string, w io.Writer) error {
+	nodes, err := getBuildGraph(buildManifest, services)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case graphJSONFormat:
+		bytes, err := json.MarshalIndent(nodes, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+	case graphDotFormat:
+		fmt.Fprintln(w, "digraph build {")
+		fmt.Fprintln(w, "  rankdir=LR;")
+		for _, node := range nodes {
+			fmt.Fprintf(w, "  %q;\n", node.Name)
+			for _, dependency := range node.DependsOn {
+				fmt.Fprintf(w, "  %q -> %q;\n", dependency, node.Name)
+			}
+		}
+		fmt.Fprintln(w, "}")
+	default:
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(tw, "Service\tDepends on\tStage")
+		for _, node := range nodes {
+			dependsOn := "-"
+			if len(node.DependsOn) > 0 {
+				dependsOn = strings.Join(node.DependsOn, ", ")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", node.Name, dependsOn, node.Stage)
+		}
+		return tw.Flush()
+	}
+	return nil
+}
+
+// getBuildGraph returns the services to build with their dependencies, sorted by build stage.
+// When no services are given, all the services of the build section are included
+func getBuildGraph(buildManifest model.ManifestBuild, services []string) ([]graphNode, error) {
+	if len(services) == 0 {
+		for svcName := range buildManifest {
+			services = append(services, svcName)
+		}
+	}
+
+	included := map[string]bool{}
+	var include func(svcName string) error
+	include = func(svcName string) error {
+		if included[svcName] {
+			return nil
+		}
+		buildInfo, ok := buildManifest[svcName]
+		if !ok {
+			return fmt.Errorf("invalid services names, not found at manifest: [%s]", svcName)
+		}
+		included[svcName] = true
+		for _, dependency := range buildInfo.DependsOn {
+			if err := include(dependency); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, svcName := range services {
+		if err := include(svcName); err != nil {
+			return nil, err
+		}
+	}
+
+	stages := map[string]int{}
+	var getStage func(svcName string, visiting map[string]bool) (int, error)
+	getStage = func(svcName string, visiting map[string]bool) (int, error) {
+		if stage, ok := stages[svcName]; ok {
+			return stage, nil
+		}
+		if visiting[svcName] {
+			return 0, fmt.Errorf("the build section has a dependency cycle on service '%s'", svcName)
+		}
+		visiting[svcName] = true
+		stage := 1
+		for _, dependency := range buildManifest[svcName].DependsOn {
+			dependencyStage, err := getStage(dependency, visiting)
+			if err != nil {
+				return 0, err
+			}
+			if dependencyStage+1 > stage {
+				stage = dependencyStage + 1
+			}
+		}
+		stages[svcName] = stage
+		return stage, nil
+	}
+
+	nodes := make([]graphNode, 0, len(included))
+	for svcName := range included {
+		stage, err := getStage(svcName, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		dependsOn := append([]string{}, buildManifest[svcName].DependsOn...)
+		sort.Strings(dependsOn)
+		nodes = append(nodes, graphNode{
+			Name:      svcName,
+			DependsOn: dependsOn,
+			Stage:     stage,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Stage != nodes[j].Stage {
+			return nodes[i].Stage < nodes[j].Stage
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
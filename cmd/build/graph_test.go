@@ -0,0 +1,150 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var graphManifest = model.ManifestBuild{
+	"base":     &model.BuildInfo{},
+	"api":      &model.BuildInfo{DependsOn: []string{"base"}},
+	"frontend": &model.BuildInfo{DependsOn: []string{"base"}},
+	"e2e":      &model.BuildInfo{DependsOn: []string{"frontend", "api"}},
+	"worker":   &model.BuildInfo{},
+}
+
+func TestValidateGraphFormat(t *testing.T) {
+	assert.NoError(t, validateGraphFormat("text"))
+	assert.NoError(t, validateGraphFormat("dot"))
+	assert.NoError(t, validateGraphFormat("json"))
+	assert.Error(t, validateGraphFormat("yaml"))
+}
+
+func TestGetBuildGraph(t *testing.T) {
+	var tests = []struct {
+		name          string
+		buildManifest model.ManifestBuild
+		services      []string
+		expected      []graphNode
+		expectedErr   bool
+	}{
+		{
+			name:          "all services",
+			buildManifest: graphManifest,
+			expected: []graphNode{
+				{Name: "base", DependsOn: []string{}, Stage: 1},
+				{Name: "worker", DependsOn: []string{}, Stage: 1},
+				{Name: "api", DependsOn: []string{"base"}, Stage: 2},
+				{Name: "frontend", DependsOn: []string{"base"}, Stage: 2},
+				{Name: "e2e", DependsOn: []string{"api", "frontend"}, Stage: 3},
+			},
+		},
+		{
+			name:          "services with dependencies",
+			buildManifest: graphManifest,
+			services:      []string{"api"},
+			expected: []graphNode{
+				{Name: "base", DependsOn: []string{}, Stage: 1},
+				{Name: "api", DependsOn: []string{"base"}, Stage: 2},
+			},
+		},
+		{
+			name:          "unknown service",
+			buildManifest: graphManifest,
+			services:      []string{"unknown"},
+			expectedErr:   true,
+		},
+		{
+			name: "dependency cycle",
+			buildManifest: model.ManifestBuild{
+				"a": &model.BuildInfo{DependsOn: []string{"b"}},
+				"b": &model.BuildInfo{DependsOn: []string{"a"}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := getBuildGraph(tt.buildManifest, tt.services)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, nodes)
+		})
+	}
+}
+
+func TestRunGraph(t *testing.T) {
+	var tests = []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "text",
+			format: graphTextFormat,
+			expected: `Service  Depends on  Stage
+base     -           1
+api      base        2
+`,
+		},
+		{
+			name:   "dot",
+			format: graphDotFormat,
+			expected: `digraph build {
+  rankdir=LR;
+  "base";
+  "api";
+  "base" -> "api";
+}
+`,
+		},
+		{
+			name:   "json",
+			format: graphJSONFormat,
+			expected: `[
+ {
+  "name": "base",
+  "dependsOn": [],
+  "stage": 1
+ },
+ {
+  "name": "api",
+  "dependsOn": [
+   "base"
+  ],
+  "stage": 2
+ }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			require.NoError(t, runGraph(graphManifest, []string{"api"}, tt.format, b))
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
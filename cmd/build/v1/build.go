@@ -15,8 +15,11 @@ package v1
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -25,6 +28,7 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
 	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/types"
@@ -39,8 +43,14 @@ type OktetoBuilderInterface interface {
 type oktetoRegistryInterface interface {
 	GetImageTagWithDigest(imageTag string) (string, error)
 	HasGlobalPushAccess() (bool, error)
+	SignImage(image string, signer crypto.Signer) error
 }
 
+var (
+	errSignWithoutTag  = errors.New("images can only be signed when they are pushed: specify the flag '-t'")
+	errSignWithOutputs = errors.New("images exported to build outputs are not pushed and can't be signed")
+)
+
 // OktetoBuilder builds the images
 type OktetoBuilder struct {
 	Builder  OktetoBuilderInterface
@@ -105,15 +115,42 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		return err
 	}
 
+	if err := model.ValidateBuildOutputs(options.Outputs); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value for --output: %w", err),
+			Hint: "Export the build result to a single output with the syntax 'type=local|tar|oci,dest=path'",
+		}
+	}
+
+	var signer crypto.Signer
+	if options.Sign {
+		if options.Tag == "" {
+			return errSignWithoutTag
+		}
+		if len(options.Outputs) > 0 {
+			return errSignWithOutputs
+		}
+		signer, err = registry.GetSigner(afero.NewOsFs(), options.SignKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := bc.Builder.Run(ctx, options, bc.IoCtrl); err != nil {
 		analytics.TrackBuild(false)
 		return err
 	}
 
-	if options.Tag == "" {
+	switch {
+	case len(options.Outputs) > 0:
+		bc.IoCtrl.Out().Success("Build succeeded")
+		for _, output := range options.Outputs {
+			bc.IoCtrl.Out().Infof("Build result exported to '%s'", output)
+		}
+	case options.Tag == "":
 		bc.IoCtrl.Out().Success("Build succeeded")
 		bc.IoCtrl.Out().Infof("Your image won't be pushed. To push your image specify the flag '-t'.")
-	} else {
+	default:
 		displayTag := options.Tag
 		if options.DevTag != "" {
 			displayTag = options.DevTag
@@ -121,6 +158,16 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		bc.IoCtrl.Out().Success("Image '%s' successfully pushed", displayTag)
 	}
 
+	if signer != nil {
+		for _, tag := range strings.Split(options.Tag, ",") {
+			if err := bc.Registry.SignImage(tag, signer); err != nil {
+				analytics.TrackBuild(false)
+				return fmt.Errorf("error signing image '%s': %w", tag, err)
+			}
+			bc.IoCtrl.Out().Success("Image '%s' successfully signed", tag)
+		}
+	}
+
 	analytics.TrackBuild(true)
 	return nil
 }
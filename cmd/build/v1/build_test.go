@@ -15,6 +15,7 @@ package v1
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -61,6 +62,8 @@ func (fr fakeRegistry) AddImageByOpts(opts *types.BuildOptions) error {
 
 func (fr fakeRegistry) HasGlobalPushAccess() (bool, error) { return false, nil }
 
+func (fr fakeRegistry) SignImage(_ string, _ crypto.Signer) error { return nil }
+
 func TestBuildWithErrorFromDockerfile(t *testing.T) {
 	ctx := context.Background()
 	okteto.CurrentStore = &okteto.OktetoContextStore{
@@ -204,6 +207,64 @@ func TestBuildWithNoErrorFromDockerfileAndNoTag(t *testing.T) {
 	assert.Empty(t, image)
 }
 
+func TestBuildWithSignAndNoTag(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := &OktetoBuilder{
+		Builder:  builder,
+		Registry: registry,
+		IoCtrl:   io.NewIOController(),
+	}
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	options := &types.BuildOptions{
+		CommandArgs: []string{dir},
+		Sign:        true,
+	}
+	err = bc.Build(ctx, options)
+	assert.ErrorIs(t, err, errSignWithoutTag)
+}
+
+func TestBuildWithSeveralOutputs(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := &OktetoBuilder{
+		Builder:  builder,
+		Registry: registry,
+		IoCtrl:   io.NewIOController(),
+	}
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	options := &types.BuildOptions{
+		CommandArgs: []string{dir},
+		Outputs:     []string{"type=local,dest=bin", "type=tar,dest=out.tar"},
+	}
+	err = bc.Build(ctx, options)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
 func createDockerfile(t *testing.T) (string, error) {
 	dir := t.TempDir()
 	dockerfilePath := filepath.Join(dir, "Dockerfile")
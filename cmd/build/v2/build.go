@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"os"
@@ -25,7 +26,9 @@ import (
 
 	buildv1 "github.com/okteto/okteto/cmd/build/v1"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cache"
 	"github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/env"
@@ -37,6 +40,7 @@ import (
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/repository"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 )
 
 // OktetoBuilderInterface runs the build of an image
@@ -54,6 +58,8 @@ type oktetoRegistryInterface interface {
 	GetRegistryAndRepo(image string) (string, string)
 	GetRepoNameAndTag(repo string) (string, string)
 	CloneGlobalImageToDev(imageWithDigest, tag string) (string, error)
+	GetAttestationDigests(image string) ([]string, error)
+	SignImage(image string, signer crypto.Signer) error
 }
 
 // oktetoBuilderConfigInterface returns the configuration that the builder has for the registry and project
@@ -66,6 +72,13 @@ type oktetoBuilderConfigInterface interface {
 	IsSmartBuildsEnabled() bool
 }
 
+// buildCacheStore stores the image built for a smart build hash so it can be reused without querying the registry
+type buildCacheStore interface {
+	Get(key cache.BuildCacheKey) (string, bool, error)
+	Set(key cache.BuildCacheKey, image string) error
+	Delete(key cache.BuildCacheKey) error
+}
+
 type analyticsTrackerInterface interface {
 	TrackImageBuild(meta ...*analytics.ImageBuildMetadata)
 }
@@ -80,12 +93,22 @@ type OktetoBuilder struct {
 
 	hasher *serviceHasher
 
+	// buildCache is consulted before the registry to check if a smart build hash is already built
+	buildCache buildCacheStore
+
 	// buildEnvironments are the environment variables created by the build steps
 	buildEnvironments map[string]string
 
+	// timings are the build timings of each service, displayed in the timing report
+	timings map[string]*serviceTiming
+
 	ioCtrl *io.IOController
 	// lock is a mutex to provide builEnvironments map safe concurrency
 	lock sync.RWMutex
+	// timingsLock is a mutex to provide timings map safe concurrency
+	timingsLock sync.Mutex
+	// outputLock is a mutex to write whole lines of the output of the services built at the same time
+	outputLock sync.Mutex
 }
 
 // NewBuilder creates a new okteto builder
@@ -108,20 +131,21 @@ func NewBuilderFromScratch(analyticsTracker analyticsTrackerInterface, ioCtrl *i
 		ioCtrl.Logger().Infof("could not get working dir: %s", err)
 	}
 	gitRepo := repository.NewRepository(wd)
-	config := getConfig(registry, gitRepo, ioCtrl.Logger())
+	cfg := getConfig(registry, gitRepo, ioCtrl.Logger())
 
 	buildEnvs := map[string]string{}
-	buildEnvs[OktetoEnableSmartBuildEnvVar] = strconv.FormatBool(config.isSmartBuildsEnable)
+	buildEnvs[OktetoEnableSmartBuildEnvVar] = strconv.FormatBool(cfg.isSmartBuildsEnable)
 
 	return &OktetoBuilder{
 		Builder:           builder,
 		Registry:          registry,
 		V1Builder:         buildv1.NewBuilder(builder, registry, ioCtrl),
 		buildEnvironments: buildEnvs,
-		Config:            config,
+		Config:            cfg,
 		analyticsTracker:  analyticsTracker,
 		ioCtrl:            ioCtrl,
 		hasher:            newServiceHasher(gitRepo),
+		buildCache:        cache.NewLocalBuildCache(afero.NewOsFs(), config.GetBuildCachePath()),
 	}
 }
 
@@ -167,104 +191,141 @@ func (bc *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		return err
 	}
 
-	buildManifest := options.Manifest.Build
+	signer, err := getSigner(afero.NewOsFs(), options)
+	if err != nil {
+		return err
+	}
 
-	// builtImagesControl represents the controller for the built services
-	// when a service is built we track it here
-	builtImagesControl := make(map[string]bool)
+	buildManifest := options.Manifest.Build
 
 	// send analytics for all builds after Build
 	buildsAnalytics := make([]*analytics.ImageBuildMetadata, 0)
+	var analyticsLock sync.Mutex
 
 	// send all events appended on each build
 	defer func([]*analytics.ImageBuildMetadata) {
 		bc.analyticsTracker.TrackImageBuild(buildsAnalytics...)
 	}(buildsAnalytics)
 
+	bc.timingsLock.Lock()
+	bc.timings = map[string]*serviceTiming{}
+	bc.timingsLock.Unlock()
+
 	bc.ioCtrl.Logger().Infof("Images to build: [%s]", strings.Join(toBuildSvcs, ", "))
-	for len(builtImagesControl) != len(toBuildSvcs) {
-		for _, svcToBuild := range toBuildSvcs {
-			if skipServiceBuild(svcToBuild, builtImagesControl) {
-				bc.ioCtrl.Logger().Infof("skipping image '%s' due to being already built", svcToBuild)
-				continue
-			}
-			if !areAllServicesBuilt(buildManifest[svcToBuild].DependsOn, builtImagesControl) {
-				bc.ioCtrl.Logger().Infof("image '%s' can't be deployed because at least one of its dependent images(%s) are not built", svcToBuild, strings.Join(buildManifest[svcToBuild].DependsOn, ", "))
-				continue
-			}
-			if options.EnableStages {
-				bc.ioCtrl.SetStage(fmt.Sprintf("Building service %s", svcToBuild))
-			}
+	buildService := func(ctx context.Context, svcToBuild string, svcOptions *types.BuildOptions) error {
+		// create the meta pointer and append it to the analytics slice
+		meta := analytics.NewImageBuildMetadata()
+		analyticsLock.Lock()
+		buildsAnalytics = append(buildsAnalytics, meta)
+		analyticsLock.Unlock()
+
+		return bc.buildService(ctx, svcToBuild, svcOptions, signer, meta)
+	}
+	if err := bc.buildServices(ctx, toBuildSvcs, options, getBuildParallelism(options), buildService); err != nil {
+		return err
+	}
+	if options.EnableStages {
+		bc.ioCtrl.SetStage("")
+	}
+	if options.TimingReport {
+		bc.displayTimingReport(buildManifest)
+	}
+	return options.Manifest.ExpandEnvVars()
+}
 
-			buildSvcInfo := buildManifest[svcToBuild]
-
-			// create the meta pointer and append it to the analytics slice
-			meta := analytics.NewImageBuildMetadata()
-			buildsAnalytics = append(buildsAnalytics, meta)
-
-			meta.Name = svcToBuild
-			meta.RepoURL = bc.Config.GetAnonymizedRepo()
-
-			repoHashDurationStart := time.Now()
-
-			meta.RepoHash = bc.hasher.hashProjectCommit(buildSvcInfo)
-			meta.RepoHashDuration = time.Since(repoHashDurationStart)
-
-			buildContextHashDurationStart := time.Now()
-			meta.BuildContextHash = bc.hasher.hashBuildContext(buildSvcInfo)
-			meta.BuildContextHashDuration = time.Since(buildContextHashDurationStart)
-
-			// We only check that the image is built in the global registry if the noCache option is not set
-			if !options.NoCache && bc.Config.IsCleanProject() && bc.Config.IsSmartBuildsEnabled() {
-
-				imageChecker := getImageChecker(buildSvcInfo, bc.Config, bc.Registry, bc.ioCtrl.Logger())
-				cacheHitDurationStart := time.Now()
-				buildHash := bc.hasher.hashService(buildSvcInfo)
-				imageWithDigest, isBuilt := imageChecker.checkIfBuildHashIsBuilt(options.Manifest.Name, svcToBuild, buildHash)
-
-				meta.CacheHit = isBuilt
-				meta.CacheHitDuration = time.Since(cacheHitDurationStart)
-
-				if isBuilt {
-					bc.ioCtrl.Out().Infof("Skipping build of '%s' image because it's already built for commit %s", svcToBuild, bc.hasher.GetCommitHash(buildSvcInfo))
-					// if the built image belongs to global registry we clone it to the dev registry
-					// so that in can be used in dev containers (i.e. okteto up)
-					if bc.Registry.IsGlobalRegistry(imageWithDigest) {
-						bc.ioCtrl.Logger().Debugf("Copying image '%s' from global to personal registry", svcToBuild)
-						tag := buildHash
-						devImage, err := bc.Registry.CloneGlobalImageToDev(imageWithDigest, tag)
-						if err != nil {
-							return err
-						}
-						imageWithDigest = devImage
-					}
-
-					bc.SetServiceEnvVars(svcToBuild, imageWithDigest)
-					builtImagesControl[svcToBuild] = true
-					meta.Success = true
-					continue
+// buildService builds the image of a service, unless it is already built for its smart build hash,
+// and sets the environment variables of the built image for the services that depend on it
+func (bc *OktetoBuilder) buildService(ctx context.Context, svcToBuild string, options *types.BuildOptions, signer crypto.Signer, meta *analytics.ImageBuildMetadata) error {
+	buildSvcInfo := options.Manifest.Build[svcToBuild]
+
+	meta.Name = svcToBuild
+	meta.RepoURL = bc.Config.GetAnonymizedRepo()
+
+	repoHashDurationStart := time.Now()
+
+	meta.RepoHash = bc.hasher.hashProjectCommit(buildSvcInfo)
+	meta.RepoHashDuration = time.Since(repoHashDurationStart)
+
+	buildContextHashDurationStart := time.Now()
+	meta.BuildContextHash = bc.hasher.hashBuildContext(buildSvcInfo)
+	meta.BuildContextHashDuration = time.Since(buildContextHashDurationStart)
+
+	// We only check that the image is built in the global registry if the noCache option is not set
+	buildHash := ""
+	buildCacheKey := cache.BuildCacheKey{}
+	// services with outputs are always built since their results are exported locally
+	if !options.NoCache && !hasOutputs(buildSvcInfo, options) && bc.Config.IsCleanProject() && bc.Config.IsSmartBuildsEnabled() {
+		cacheHitDurationStart := time.Now()
+		buildHash = bc.hasher.hashService(withAttestationFlags(buildSvcInfo, options))
+		buildCacheKey = getBuildCacheKey(okteto.Context().Name, okteto.Context().Namespace, options.Manifest.Name, svcToBuild, buildHash)
+
+		// the local build cache is checked first, so only the cached image is looked up in the registry
+		imageWithDigest, isBuilt := bc.getImageFromBuildCache(buildCacheKey)
+		if !isBuilt {
+			imageChecker := getImageChecker(buildSvcInfo, bc.Config, bc.Registry, bc.ioCtrl.Logger())
+			imageWithDigest, isBuilt = imageChecker.checkIfBuildHashIsBuilt(options.Manifest.Name, svcToBuild, buildHash)
+		}
+
+		meta.CacheHit = isBuilt
+		meta.CacheHitDuration = time.Since(cacheHitDurationStart)
+
+		if isBuilt {
+			bc.ioCtrl.Out().Infof("Skipping build of '%s' image because it's already built for commit %s", svcToBuild, bc.hasher.GetCommitHash(buildSvcInfo))
+			// if the built image belongs to global registry we clone it to the dev registry
+			// so that in can be used in dev containers (i.e. okteto up)
+			if bc.Registry.IsGlobalRegistry(imageWithDigest) {
+				bc.ioCtrl.Logger().Debugf("Copying image '%s' from global to personal registry", svcToBuild)
+				tag := buildHash
+				devImage, err := bc.Registry.CloneGlobalImageToDev(imageWithDigest, tag)
+				if err != nil {
+					return err
 				}
+				imageWithDigest = devImage
 			}
 
-			if !okteto.Context().IsOkteto && buildSvcInfo.Image == "" {
-				return fmt.Errorf("'build.%s.image' is required if your context doesn't have Okteto installed", svcToBuild)
+			bc.storeImageInBuildCache(buildCacheKey, imageWithDigest)
+			bc.SetServiceEnvVars(svcToBuild, imageWithDigest)
+			if hasAttestations(buildSvcInfo, options) {
+				bc.SetServiceAttestationEnvVars(svcToBuild, imageWithDigest)
 			}
-			buildDurationStart := time.Now()
-			imageTag, err := bc.buildServiceImages(ctx, options.Manifest, svcToBuild, options)
-			if err != nil {
-				return fmt.Errorf("error building service '%s': %w", svcToBuild, err)
+			if err := bc.signServiceImage(svcToBuild, imageWithDigest, signer); err != nil {
+				return err
 			}
-			meta.BuildDuration = time.Since(buildDurationStart)
 			meta.Success = true
-
-			bc.SetServiceEnvVars(svcToBuild, imageTag)
-			builtImagesControl[svcToBuild] = true
+			bc.setServiceTimingResult(svcToBuild, timingStatusSkipped, meta.CacheHitDuration)
+			return nil
 		}
 	}
-	if options.EnableStages {
-		bc.ioCtrl.SetStage("")
+
+	if !okteto.Context().IsOkteto && buildSvcInfo.Image == "" {
+		return fmt.Errorf("'build.%s.image' is required if your context doesn't have Okteto installed", svcToBuild)
 	}
-	return options.Manifest.ExpandEnvVars()
+	buildDurationStart := time.Now()
+	imageTag, err := bc.buildServiceImages(ctx, options.Manifest, svcToBuild, options)
+	if err != nil {
+		return fmt.Errorf("error building service '%s': %w", svcToBuild, err)
+	}
+	meta.BuildDuration = time.Since(buildDurationStart)
+	meta.Success = true
+
+	// the image of a service exported to outputs is not pushed
+	if imageTag == "" && hasOutputs(buildSvcInfo, options) {
+		bc.setServiceTimingResult(svcToBuild, timingStatusExported, meta.BuildDuration)
+		return nil
+	}
+	bc.setServiceTimingResult(svcToBuild, timingStatusBuilt, meta.BuildDuration)
+
+	if buildHash != "" {
+		bc.storeImageInBuildCache(buildCacheKey, imageTag)
+	}
+	bc.SetServiceEnvVars(svcToBuild, imageTag)
+	if hasAttestations(buildSvcInfo, options) {
+		bc.SetServiceAttestationEnvVars(svcToBuild, imageTag)
+	}
+	if err := bc.signServiceImage(svcToBuild, imageTag, signer); err != nil {
+		return err
+	}
+	return nil
 }
 
 // areServicesBuilt compares the list of services with the built control
@@ -296,6 +357,11 @@ func (bc *OktetoBuilder) buildServiceImages(ctx context.Context, manifest *model
 			E:    fmt.Errorf("Build with volume mounts is not supported on vanilla contexts"),
 			Hint: "Please connect to a okteto context and try again",
 		}
+	case serviceHasVolumesToInclude(buildSvcInfo) && hasOutputs(buildSvcInfo, options):
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("Build with volume mounts is not supported with build outputs"),
+			Hint: fmt.Sprintf("Remove the outputs of service '%s' or its volume mounts and try again", svcName),
+		}
 	case serviceHasDockerfile(buildSvcInfo) && serviceHasVolumesToInclude(buildSvcInfo):
 		image, err := bc.buildSvcFromDockerfile(ctx, manifest, svcName, options)
 		if err != nil {
@@ -320,18 +386,27 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	bc.ioCtrl.Logger().Info(fmt.Sprintf("Building service '%s' from Dockerfile", svcName))
 	isStackManifest := manifest.Type == model.StackType
 	buildSvcInfo := bc.getBuildInfoWithoutVolumeMounts(manifest.Build[svcName], isStackManifest)
-	buildHash := bc.hasher.hashService(buildSvcInfo)
+	buildHash := bc.hasher.hashService(withAttestationFlags(buildSvcInfo, options))
 	tagToBuild := newImageTagger(bc.Config).getServiceImageReference(manifest.Name, svcName, buildSvcInfo, buildHash)
 	buildSvcInfo.Image = tagToBuild
-	if err := buildSvcInfo.AddBuildArgs(bc.buildEnvironments); err != nil {
+	// services built at the same time might be setting their environment variables
+	bc.lock.RLock()
+	err := buildSvcInfo.AddBuildArgs(bc.buildEnvironments)
+	bc.lock.RUnlock()
+	if err != nil {
 		return "", fmt.Errorf("error expanding build args from service '%s': %w", svcName, err)
 	}
 
 	buildOptions := build.OptsFromBuildInfo(manifest.Name, svcName, buildSvcInfo, options, bc.Registry)
+	buildOptions.Timing = &types.BuildTiming{}
 
 	if err := bc.V1Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
+	bc.addBuildTiming(svcName, buildOptions.Timing)
+	if len(buildOptions.Outputs) > 0 {
+		return "", nil
+	}
 	// check if the image is pushed to the dev registry if DevTag is set
 	reference := buildOptions.Tag
 	if buildOptions.DevTag != "" {
@@ -354,7 +429,7 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 
 	buildInfoCopy := manifest.Build[svcName].Copy()
 	buildInfoCopy.Image = ""
-	buildHash := bc.hasher.hashService(buildInfoCopy)
+	buildHash := bc.hasher.hashService(withAttestationFlags(buildInfoCopy, options))
 
 	tagToBuild := newImageWithVolumesTagger(bc.Config).getServiceImageReference(manifest.Name, svcName, buildInfoCopy, buildHash)
 	buildSvcInfo := getBuildInfoWithVolumeMounts(manifest.Build[svcName], isStackManifest)
@@ -364,10 +439,12 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 	}
 	buildOptions := build.OptsFromBuildInfo(manifest.Name, svcName, svcBuild, options, bc.Registry)
 	buildOptions.Tag = tagToBuild
+	buildOptions.Timing = &types.BuildTiming{}
 
 	if err := bc.V1Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
+	bc.addBuildTiming(svcName, buildOptions.Timing)
 	imageTagWithDigest, err := bc.Registry.GetImageTagWithDigest(buildOptions.Tag)
 	if err != nil {
 		return "", fmt.Errorf("error accessing image at registry %s: %v", options.Tag, err)
@@ -375,6 +452,38 @@ func (bc *OktetoBuilder) addVolumeMounts(ctx context.Context, manifest *model.Ma
 	return imageTagWithDigest, nil
 }
 
+// withAttestationFlags returns the build info with the attestations requested by the --sbom and --provenance flags
+// added to the ones declared in the manifest, so the smart build hash changes when the flags request attestations
+func withAttestationFlags(buildInfo *model.BuildInfo, options *types.BuildOptions) *model.BuildInfo {
+	if !options.SBOM && options.Provenance == "" {
+		return buildInfo
+	}
+	result := buildInfo.Copy()
+	attestations := model.BuildAttestations{}
+	if result.Attestations != nil {
+		attestations = *result.Attestations
+	}
+	attestations.SBOM = attestations.SBOM || options.SBOM
+	if options.Provenance != "" {
+		attestations.Provenance = options.Provenance
+	}
+	result.Attestations = &attestations
+	return result
+}
+
+// hasAttestations returns true when the manifest or the flags request attestations for the service
+func hasAttestations(buildInfo *model.BuildInfo, options *types.BuildOptions) bool {
+	if options.SBOM || options.Provenance != "" {
+		return true
+	}
+	return buildInfo.Attestations != nil && (buildInfo.Attestations.SBOM || buildInfo.Attestations.Provenance != "")
+}
+
+// hasOutputs returns true when the flags or the manifest export the build result of the service instead of pushing it
+func hasOutputs(buildInfo *model.BuildInfo, options *types.BuildOptions) bool {
+	return len(options.Outputs) > 0 || len(buildInfo.Outputs) > 0
+}
+
 // serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty
 func serviceHasDockerfile(buildInfo *model.BuildInfo) bool {
 	return buildInfo.Dockerfile != ""
@@ -436,10 +545,43 @@ func validateOptions(manifest *model.Manifest, svcsToBuild []string, options *ty
 		return err
 	}
 
+	if options.Parallelism < 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value %d for --parallelism", options.Parallelism),
+			Hint: "The number of services built at the same time must be greater than 0",
+		}
+	}
+
 	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil) {
 		return oktetoErrors.ErrNoFlagAllowedOnSingleImageBuild
 	}
 
+	if err := model.ValidateBuildOutputs(options.Outputs); err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value for --output: %w", err),
+			Hint: "Export the build result to a single output with the syntax 'type=local|tar|oci,dest=path'",
+		}
+	}
+
+	// every service would be exported to the same destination
+	if len(svcsToBuild) != 1 && len(options.Outputs) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the flag '--output' can only be used to build a single image, but %d images are built: %s", len(svcsToBuild), strings.Join(svcsToBuild, ", ")),
+			Hint: "Build a single image without dependencies or define the outputs of each image in the 'build' section of your okteto manifest",
+		}
+	}
+
+	if options.Sign {
+		for _, svc := range svcsToBuild {
+			if hasOutputs(manifest.Build[svc], options) {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the image of service '%s' is exported to build outputs and can't be signed", svc),
+					Hint: "Images exported to build outputs are not pushed. Remove the flag '--sign' or the outputs of the service",
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
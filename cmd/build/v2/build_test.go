@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"crypto"
 	"crypto/sha256"
 	"encoding/hex"
 	"os"
@@ -76,8 +77,11 @@ var fakeManifest *model.Manifest = &model.Manifest{
 
 type fakeRegistry struct {
 	registry          map[string]fakeImage
+	signed            map[string]bool
+	attestations      []string
 	errAddImageByName error
 	errAddImageByOpts error
+	errGetImage       error
 }
 
 // fakeImage represents the data from an image
@@ -92,12 +96,21 @@ type fakeImage struct {
 func newFakeRegistry() fakeRegistry {
 	return fakeRegistry{
 		registry: map[string]fakeImage{},
+		signed:   map[string]bool{},
 	}
 }
 
 func (fr fakeRegistry) HasGlobalPushAccess() (bool, error) { return false, nil }
 
+func (fr fakeRegistry) SignImage(image string, _ crypto.Signer) error {
+	fr.signed[image] = true
+	return nil
+}
+
 func (fr fakeRegistry) GetImageTagWithDigest(imageTag string) (string, error) {
+	if fr.errGetImage != nil {
+		return "", fr.errGetImage
+	}
 	if _, ok := fr.registry[imageTag]; !ok {
 		return "", oktetoErrors.ErrNotFound
 	}
@@ -149,6 +162,9 @@ func (fr fakeRegistry) GetRepoNameAndTag(repo string) (string, string)   { retur
 func (fr fakeRegistry) CloneGlobalImageToDev(imageWithDigest, tag string) (string, error) {
 	return "", nil
 }
+func (fr fakeRegistry) GetAttestationDigests(image string) ([]string, error) {
+	return fr.attestations, nil
+}
 
 type fakeAnalyticsTracker struct {
 	metaPayload []*analytics.ImageBuildMetadata
@@ -221,6 +237,74 @@ func TestValidateOptions(t *testing.T) {
 			options:     types.BuildOptions{},
 			expectedErr: false,
 		},
+		{
+			name: "invalid parallelism",
+			buildSection: model.ManifestBuild{
+				"test": &model.BuildInfo{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Parallelism: -1,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "several outputs",
+			buildSection: model.ManifestBuild{
+				"test": &model.BuildInfo{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Outputs: []string{"type=local,dest=out", "type=tar,dest=out.tar"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "output flag with several services",
+			buildSection: model.ManifestBuild{
+				"a": &model.BuildInfo{},
+				"b": &model.BuildInfo{},
+			},
+			svcsToBuild: []string{"a", "b"},
+			options: types.BuildOptions{
+				Outputs: []string{"type=local,dest=out"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "output flag with one service",
+			buildSection: model.ManifestBuild{
+				"a": &model.BuildInfo{},
+				"b": &model.BuildInfo{},
+			},
+			svcsToBuild: []string{"a"},
+			options: types.BuildOptions{
+				Outputs: []string{"type=local,dest=out"},
+			},
+		},
+		{
+			name: "sign a service exported to outputs",
+			buildSection: model.ManifestBuild{
+				"test": &model.BuildInfo{Outputs: model.BuildOutputs{{Type: "local", Dest: "out"}}},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Sign: true,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "sign with output flags",
+			buildSection: model.ManifestBuild{
+				"test": &model.BuildInfo{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Sign:    true,
+				Outputs: []string{"type=local,dest=out"},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "only one service with flags",
 			buildSection: model.ManifestBuild{
@@ -725,7 +809,7 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 					},
 					Target: "target",
 					Secrets: model.BuildSecrets{
-						"secret": "secret",
+						"secret": {File: "secret"},
 					},
 					Context:    "context",
 					Dockerfile: "dockerfile",
@@ -755,7 +839,7 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 					},
 					Target: "target",
 					Secrets: model.BuildSecrets{
-						"secret": "secret",
+						"secret": {File: "secret"},
 					},
 					Context:    "context",
 					Dockerfile: "dockerfile",
@@ -776,7 +860,7 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 					Args:   model.BuildArgs{},
 					Target: "target",
 					Secrets: model.BuildSecrets{
-						"secret": "secret",
+						"secret": {File: "secret"},
 					},
 					Context:    "context",
 					Dockerfile: "dockerfile",
@@ -802,7 +886,7 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 					},
 					Target: "target",
 					Secrets: model.BuildSecrets{
-						"secret": "secret",
+						"secret": {File: "secret"},
 					},
 					Context:    "context",
 					Dockerfile: "dockerfile",
@@ -825,3 +909,128 @@ func Test_getBuildHashFromCommit(t *testing.T) {
 	}
 
 }
+
+func Test_hasAttestations(t *testing.T) {
+	tests := []struct {
+		buildInfo *model.BuildInfo
+		options   *types.BuildOptions
+		name      string
+		expected  bool
+	}{
+		{
+			name:      "none",
+			buildInfo: &model.BuildInfo{},
+			options:   &types.BuildOptions{},
+		},
+		{
+			name:      "sbom flag",
+			buildInfo: &model.BuildInfo{},
+			options:   &types.BuildOptions{SBOM: true},
+			expected:  true,
+		},
+		{
+			name:      "provenance in manifest",
+			buildInfo: &model.BuildInfo{Attestations: &model.BuildAttestations{Provenance: "mode=max"}},
+			options:   &types.BuildOptions{},
+			expected:  true,
+		},
+		{
+			name:      "empty attestations in manifest",
+			buildInfo: &model.BuildInfo{Attestations: &model.BuildAttestations{}},
+			options:   &types.BuildOptions{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hasAttestations(tt.buildInfo, tt.options))
+		})
+	}
+}
+
+func Test_withAttestationFlags(t *testing.T) {
+	manifestInfo := &model.BuildInfo{Image: "okteto/a", Attestations: &model.BuildAttestations{Provenance: "mode=min"}}
+
+	// without flags the build info is not modified
+	assert.Same(t, manifestInfo, withAttestationFlags(manifestInfo, &types.BuildOptions{}))
+
+	result := withAttestationFlags(manifestInfo, &types.BuildOptions{SBOM: true, Provenance: "mode=max"})
+	assert.Equal(t, &model.BuildAttestations{SBOM: true, Provenance: "mode=max"}, result.Attestations)
+	assert.Equal(t, "okteto/a", result.Image)
+	assert.Equal(t, &model.BuildAttestations{Provenance: "mode=min"}, manifestInfo.Attestations)
+
+	// the attestation flags change the smart build hash
+	sh := newServiceHasher(fakeConfigRepo{sha: "sha"})
+	assert.NotEqual(t, sh.hashService(&model.BuildInfo{}), sh.hashService(withAttestationFlags(&model.BuildInfo{}, &types.BuildOptions{SBOM: true})))
+}
+
+func Test_hasOutputs(t *testing.T) {
+	tests := []struct {
+		buildInfo *model.BuildInfo
+		options   *types.BuildOptions
+		name      string
+		expected  bool
+	}{
+		{
+			name:      "none",
+			buildInfo: &model.BuildInfo{},
+			options:   &types.BuildOptions{},
+		},
+		{
+			name:      "output flag",
+			buildInfo: &model.BuildInfo{},
+			options:   &types.BuildOptions{Outputs: []string{"type=local,dest=bin"}},
+			expected:  true,
+		},
+		{
+			name:      "outputs in manifest",
+			buildInfo: &model.BuildInfo{Outputs: model.BuildOutputs{{Type: model.BuildOutputTar, Dest: "out.tar"}}},
+			options:   &types.BuildOptions{},
+			expected:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hasOutputs(tt.buildInfo, tt.options))
+		})
+	}
+}
+
+func TestBuildWithOutputs(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+				Registry:  "my-registry",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	dir, err := createDockerfile(t)
+	assert.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"artifacts": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/artifacts:test",
+				Outputs:    model.BuildOutputs{{Type: model.BuildOutputLocal, Dest: filepath.Join(dir, "bin")}},
+			},
+		},
+	}
+	err = bc.Build(ctx, &types.BuildOptions{
+		Manifest: manifest,
+	})
+	assert.NoError(t, err)
+
+	// the result is exported, so there is no image to refer to in the deploy commands
+	_, ok := bc.buildEnvironments["OKTETO_BUILD_ARTIFACTS_IMAGE"]
+	assert.False(t, ok)
+}
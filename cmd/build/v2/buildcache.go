@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"errors"
+
+	"github.com/okteto/okteto/pkg/cache"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+// getBuildCacheKey returns the key of a service build hash in the build cache.
+// Images are namespaced by context and namespace since the same hash might be pushed to different registries
+func getBuildCacheKey(contextName, namespace, manifestName, svcName, buildHash string) cache.BuildCacheKey {
+	return cache.BuildCacheKey{
+		Context:   contextName,
+		Namespace: namespace,
+		Manifest:  manifestName,
+		Service:   svcName,
+		Hash:      buildHash,
+	}
+}
+
+// getImageFromBuildCache returns the image with digest stored in the build cache for the key.
+// The image is checked in the registry, since it might have been pruned: entries whose image doesn't exist are evicted.
+// The cached image is trusted when the registry can't be reached, so the cache works offline
+func (bc *OktetoBuilder) getImageFromBuildCache(key cache.BuildCacheKey) (string, bool) {
+	if bc.buildCache == nil || key.Hash == "" {
+		return "", false
+	}
+	image, found, err := bc.buildCache.Get(key)
+	if err != nil {
+		bc.ioCtrl.Logger().Infof("could not read the build cache: %s", err)
+		return "", false
+	}
+	if !found {
+		return "", false
+	}
+
+	imageWithDigest, err := bc.Registry.GetImageTagWithDigest(image)
+	switch {
+	case errors.Is(err, oktetoErrors.ErrNotFound):
+		bc.ioCtrl.Logger().Infof("image %s of the build cache doesn't exist anymore", image)
+		bc.evictFromBuildCache(key)
+		return "", false
+	case err != nil:
+		bc.ioCtrl.Logger().Infof("could not check image %s of the build cache, using it: %s", image, err)
+	case imageWithDigest != image:
+		bc.ioCtrl.Logger().Infof("image %s of the build cache was replaced by %s", image, imageWithDigest)
+		bc.evictFromBuildCache(key)
+		return "", false
+	}
+	bc.ioCtrl.Logger().Infof("image %s found in the build cache", image)
+	return image, true
+}
+
+// evictFromBuildCache deletes the entry of the key from the build cache
+func (bc *OktetoBuilder) evictFromBuildCache(key cache.BuildCacheKey) {
+	if err := bc.buildCache.Delete(key); err != nil {
+		bc.ioCtrl.Logger().Infof("could not update the build cache: %s", err)
+	}
+}
+
+// storeImageInBuildCache stores the image with digest built for the key
+func (bc *OktetoBuilder) storeImageInBuildCache(key cache.BuildCacheKey, image string) {
+	if bc.buildCache == nil || key.Hash == "" || image == "" {
+		return
+	}
+	if err := bc.buildCache.Set(key, image); err != nil {
+		bc.ioCtrl.Logger().Infof("could not update the build cache: %s", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cache"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBuildCache struct {
+	entries map[cache.BuildCacheKey]string
+	err     error
+}
+
+func (fbc *fakeBuildCache) Get(key cache.BuildCacheKey) (string, bool, error) {
+	if fbc.err != nil {
+		return "", false, fbc.err
+	}
+	image, ok := fbc.entries[key]
+	return image, ok, nil
+}
+
+func (fbc *fakeBuildCache) Set(key cache.BuildCacheKey, image string) error {
+	if fbc.err != nil {
+		return fbc.err
+	}
+	fbc.entries[key] = image
+	return nil
+}
+
+func (fbc *fakeBuildCache) Delete(key cache.BuildCacheKey) error {
+	if fbc.err != nil {
+		return fbc.err
+	}
+	delete(fbc.entries, key)
+	return nil
+}
+
+func setBuildCacheContext() {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Name:      "test",
+				Namespace: "test",
+				IsOkteto:  true,
+			},
+		},
+		CurrentContext: "test",
+	}
+}
+
+const cachedImage = "okteto/a@sha256:4b2ef4d2b5a5f4c5e3b0d2e1d9e8a8c6f0e5a1f3c0d2b4a6e8f0a2c4e6b8d0f2"
+
+func TestBuildUsesBuildCache(t *testing.T) {
+	setBuildCacheContext()
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true, isClean: true, isSmartBuildsEnable: true}, &fakeAnalyticsTracker{})
+	buildInfo := &model.BuildInfo{
+		Context:    dir,
+		Dockerfile: filepath.Join(dir, "Dockerfile"),
+		Image:      "okteto/a:test",
+	}
+	buildHash := bc.hasher.hashService(buildInfo)
+	bc.buildCache = &fakeBuildCache{
+		entries: map[cache.BuildCacheKey]string{
+			getBuildCacheKey("test", "test", "test", "a", buildHash): cachedImage,
+		},
+	}
+	require.NoError(t, registry.AddImageByName(cachedImage))
+
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest: &model.Manifest{
+			Name:  "test",
+			Build: model.ManifestBuild{"a": buildInfo},
+		},
+	})
+	require.NoError(t, err)
+
+	// the image was not built
+	_, err = registry.GetImageTagWithDigest("okteto/a:test")
+	assert.Error(t, err)
+	assert.Equal(t, cachedImage, bc.buildEnvironments["OKTETO_BUILD_A_IMAGE"])
+}
+
+func TestBuildStoresImageInBuildCache(t *testing.T) {
+	setBuildCacheContext()
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true, isClean: true, isSmartBuildsEnable: true}, &fakeAnalyticsTracker{})
+	buildCache := &fakeBuildCache{entries: map[cache.BuildCacheKey]string{}}
+	bc.buildCache = buildCache
+	buildInfo := &model.BuildInfo{
+		Context:    dir,
+		Dockerfile: filepath.Join(dir, "Dockerfile"),
+		Image:      "okteto/a:test",
+	}
+	buildHash := bc.hasher.hashService(buildInfo)
+
+	err = bc.Build(context.Background(), &types.BuildOptions{
+		Manifest: &model.Manifest{
+			Name:  "test",
+			Build: model.ManifestBuild{"a": buildInfo},
+		},
+	})
+	require.NoError(t, err)
+
+	image, ok := buildCache.entries[getBuildCacheKey("test", "test", "test", "a", buildHash)]
+	require.True(t, ok)
+	assert.Equal(t, "okteto/a:test", image)
+}
+
+func TestGetImageFromBuildCache(t *testing.T) {
+	key := getBuildCacheKey("test", "test", "test", "a", "hash")
+	tests := []struct {
+		buildCache      *fakeBuildCache
+		name            string
+		key             cache.BuildCacheKey
+		registryErr     error
+		registryImages  []string
+		expectedImage   string
+		expectedFound   bool
+		expectedEvicted bool
+	}{
+		{
+			name: "no build cache",
+			key:  key,
+		},
+		{
+			name:       "no build hash",
+			buildCache: &fakeBuildCache{entries: map[cache.BuildCacheKey]string{{}: "image"}},
+		},
+		{
+			name:       "error reading the cache",
+			key:        key,
+			buildCache: &fakeBuildCache{err: errors.New("corrupted")},
+		},
+		{
+			name: "found",
+			key:  key,
+			buildCache: &fakeBuildCache{entries: map[cache.BuildCacheKey]string{
+				key: "image@sha256:1",
+			}},
+			registryImages: []string{"image@sha256:1"},
+			expectedImage:  "image@sha256:1",
+			expectedFound:  true,
+		},
+		{
+			name: "image deleted from the registry",
+			key:  key,
+			buildCache: &fakeBuildCache{entries: map[cache.BuildCacheKey]string{
+				key: "image@sha256:1",
+			}},
+			expectedEvicted: true,
+		},
+		{
+			name: "registry unreachable",
+			key:  key,
+			buildCache: &fakeBuildCache{entries: map[cache.BuildCacheKey]string{
+				key: "image@sha256:1",
+			}},
+			registryErr:   errors.New("dial tcp: lookup registry: no such host"),
+			expectedImage: "image@sha256:1",
+			expectedFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newFakeRegistry()
+			registry.errGetImage = tt.registryErr
+			require.NoError(t, registry.AddImageByName(tt.registryImages...))
+			bc := &OktetoBuilder{
+				Registry: registry,
+				ioCtrl:   io.NewIOController(),
+			}
+			if tt.buildCache != nil {
+				bc.buildCache = tt.buildCache
+			}
+			image, found := bc.getImageFromBuildCache(tt.key)
+			assert.Equal(t, tt.expectedImage, image)
+			assert.Equal(t, tt.expectedFound, found)
+			if tt.expectedEvicted {
+				assert.NotContains(t, tt.buildCache.entries, tt.key)
+			}
+			if tt.expectedFound {
+				assert.Contains(t, tt.buildCache.entries, tt.key)
+			}
+		})
+	}
+}
@@ -71,6 +71,25 @@ func (bc *OktetoBuilder) SetServiceEnvVars(service, reference string) {
 	bc.ioCtrl.Logger().Debug("manifest env vars set")
 }
 
+// SetServiceAttestationEnvVars sets the env var with the digests of the attestations attached to the service image
+func (bc *OktetoBuilder) SetServiceAttestationEnvVars(service, reference string) {
+	digests, err := bc.Registry.GetAttestationDigests(reference)
+	if err != nil {
+		bc.ioCtrl.Logger().Infof("could not get the attestations of image '%s': %s", reference, err)
+		return
+	}
+
+	sanitizedSvc := strings.ToUpper(strings.ReplaceAll(service, "-", "_"))
+	attestationsKey := fmt.Sprintf("OKTETO_BUILD_%s_ATTESTATIONS", sanitizedSvc)
+	attestations := strings.Join(digests, ",")
+	bc.lock.Lock()
+	bc.buildEnvironments[attestationsKey] = attestations
+	os.Setenv(attestationsKey, attestations)
+	bc.lock.Unlock()
+
+	bc.ioCtrl.Logger().Debugf("attestations of image '%s': %s", reference, attestations)
+}
+
 // GetBuildEnvVars gets okteto build env vars
 func (bc *OktetoBuilder) GetBuildEnvVars() map[string]string {
 	return bc.buildEnvironments
@@ -116,6 +116,18 @@ func Test_SetServiceEnvVars(t *testing.T) {
 	}
 }
 
+func Test_SetServiceAttestationEnvVars(t *testing.T) {
+	t.Setenv("OKTETO_BUILD_MY_FRONTEND_ATTESTATIONS", "")
+	registry := newFakeRegistry()
+	registry.attestations = []string{"sha256:1", "sha256:2"}
+	bc := NewFakeBuilder(nil, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+
+	bc.SetServiceAttestationEnvVars("my-frontend", "registry.url/namespace/frontend@sha256:0")
+
+	assert.Equal(t, "sha256:1,sha256:2", os.Getenv("OKTETO_BUILD_MY_FRONTEND_ATTESTATIONS"))
+	assert.Equal(t, "sha256:1,sha256:2", bc.GetBuildEnvVars()["OKTETO_BUILD_MY_FRONTEND_ATTESTATIONS"])
+}
+
 func TestExpandStackVariables(t *testing.T) {
 	ctx := context.Background()
 	okteto.CurrentStore = &okteto.OktetoContextStore{
@@ -18,7 +18,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/okteto/okteto/pkg/env"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -43,6 +45,9 @@ type serviceHasher struct {
 
 	buildContextCache map[string]string
 	projectCommit     string
+
+	// lock is a mutex to hash services built at the same time
+	lock sync.Mutex
 }
 
 func newServiceHasher(gitRepoCtrl repositoryCommitRetriever) *serviceHasher {
@@ -54,6 +59,7 @@ func newServiceHasher(gitRepoCtrl repositoryCommitRetriever) *serviceHasher {
 
 // hashProjectCommit returns the hash of the repository's commit
 func (sh *serviceHasher) hashProjectCommit(buildInfo *model.BuildInfo) string {
+	sh.lock.Lock()
 	if sh.projectCommit == "" {
 		var err error
 		sh.projectCommit, err = sh.gitRepoCtrl.GetSHA()
@@ -61,15 +67,18 @@ func (sh *serviceHasher) hashProjectCommit(buildInfo *model.BuildInfo) string {
 			oktetoLog.Infof("could not get repository sha: %w", err)
 		}
 	}
-	return sh.hash(buildInfo, projectCommitType, sh.projectCommit)
+	projectCommit := sh.projectCommit
+	sh.lock.Unlock()
+	return sh.hash(buildInfo, projectCommitType, projectCommit)
 }
 
 // hashBuildContext returns the hash of the service using its context tree hash
-func (sh serviceHasher) hashBuildContext(buildInfo *model.BuildInfo) string {
+func (sh *serviceHasher) hashBuildContext(buildInfo *model.BuildInfo) string {
 	buildContext := buildInfo.Context
 	if buildContext == "" {
 		buildContext = "."
 	}
+	sh.lock.Lock()
 	if _, ok := sh.buildContextCache[buildInfo.Context]; !ok {
 		var err error
 		sh.buildContextCache[buildContext], err = sh.gitRepoCtrl.GetTreeHash(buildContext)
@@ -77,19 +86,21 @@ func (sh serviceHasher) hashBuildContext(buildInfo *model.BuildInfo) string {
 			oktetoLog.Info("error trying to get tree hash for build context '%s': %w", buildContext, err)
 		}
 	}
+	treeHash := sh.buildContextCache[buildContext]
+	sh.lock.Unlock()
 
-	return sh.hash(buildInfo, buildContextCommitType, sh.buildContextCache[buildContext])
+	return sh.hash(buildInfo, buildContextCommitType, treeHash)
 }
 
 // hashService returns the hashed project commit by default. If smart-builds use the context it returns the hash of the service given its git tree hash
-func (sh serviceHasher) hashService(buildInfo *model.BuildInfo) string {
+func (sh *serviceHasher) hashService(buildInfo *model.BuildInfo) string {
 	if env.LoadBoolean(OktetoSmartBuildUsingContextEnvVar) {
 		return sh.hashBuildContext(buildInfo)
 	}
 	return sh.hashProjectCommit(buildInfo)
 }
 
-func (sh serviceHasher) hash(buildInfo *model.BuildInfo, commitType, commitHash string) string {
+func (sh *serviceHasher) hash(buildInfo *model.BuildInfo, commitType, commitHash string) string {
 	args := []string{}
 	for _, arg := range buildInfo.Args {
 		args = append(args, arg.String())
@@ -98,7 +109,7 @@ func (sh serviceHasher) hash(buildInfo *model.BuildInfo, commitType, commitHash
 
 	secrets := []string{}
 	for key, value := range buildInfo.Secrets {
-		secrets = append(secrets, fmt.Sprintf("%s=%s", key, value))
+		secrets = append(secrets, fmt.Sprintf("%s=%s", key, value.String()))
 	}
 	secretsText := strings.Join(secrets, ";")
 
@@ -115,12 +126,22 @@ func (sh serviceHasher) hash(buildInfo *model.BuildInfo, commitType, commitHash
 		fmt.Fprintf(&b, "dockerfile_content:%s;", getDockerfileContent(buildInfo.Dockerfile))
 	}
 	fmt.Fprintf(&b, "image:%s;", buildInfo.Image)
+	if len(buildInfo.Platforms) > 0 {
+		platforms := append([]string{}, buildInfo.Platforms...)
+		sort.Strings(platforms)
+		fmt.Fprintf(&b, "platforms:%s;", strings.Join(platforms, ","))
+	}
+	if buildInfo.Attestations != nil {
+		fmt.Fprintf(&b, "attestations:sbom=%t,provenance=%s;", buildInfo.Attestations.SBOM, buildInfo.Attestations.Provenance)
+	}
 
 	oktetoBuildHash := sha256.Sum256([]byte(b.String()))
 	return hex.EncodeToString(oktetoBuildHash[:])
 }
 
-func (sh serviceHasher) GetCommitHash(buildInfo *model.BuildInfo) string {
+func (sh *serviceHasher) GetCommitHash(buildInfo *model.BuildInfo) string {
+	sh.lock.Lock()
+	defer sh.lock.Unlock()
 	if env.LoadBoolean(OktetoSmartBuildUsingContextEnvVar) {
 		buildContext := buildInfo.Context
 		if buildContext == "" {
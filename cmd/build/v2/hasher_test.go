@@ -135,6 +135,28 @@ func TestServiceHasher_HashService(t *testing.T) {
 	}
 }
 
+func TestServiceHasher_HashPlatforms(t *testing.T) {
+	sh := newServiceHasher(fakeRepositoryCommitRetriever{sha: "testsha"})
+
+	singleArch := sh.hash(&model.BuildInfo{Platforms: model.BuildPlatforms{"linux/amd64"}}, projectCommitType, "testsha")
+	multiArch := sh.hash(&model.BuildInfo{Platforms: model.BuildPlatforms{"linux/amd64", "linux/arm64"}}, projectCommitType, "testsha")
+	multiArchUnsorted := sh.hash(&model.BuildInfo{Platforms: model.BuildPlatforms{"linux/arm64", "linux/amd64"}}, projectCommitType, "testsha")
+	noPlatforms := sh.hash(&model.BuildInfo{}, projectCommitType, "testsha")
+
+	assert.NotEqual(t, singleArch, multiArch)
+	assert.NotEqual(t, noPlatforms, multiArch)
+	assert.Equal(t, multiArch, multiArchUnsorted)
+}
+
+func TestServiceHasher_HashAttestations(t *testing.T) {
+	sh := newServiceHasher(fakeRepositoryCommitRetriever{sha: "testsha"})
+
+	noAttestations := sh.hash(&model.BuildInfo{}, projectCommitType, "testsha")
+	withSBOM := sh.hash(&model.BuildInfo{Attestations: &model.BuildAttestations{SBOM: true}}, projectCommitType, "testsha")
+
+	assert.NotEqual(t, noAttestations, withSBOM)
+}
+
 func TestGetCommitHash(t *testing.T) {
 
 	sh := serviceHasher{
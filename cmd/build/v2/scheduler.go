@@ -0,0 +1,180 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// buildServiceFunc builds a service with its own copy of the build options
+type buildServiceFunc func(ctx context.Context, svcName string, options *types.BuildOptions) error
+
+// buildResult is the result of the build of a service
+type buildResult struct {
+	err     error
+	svcName string
+}
+
+// getBuildParallelism returns the maximum number of services built at the same time.
+// The value of the flag takes precedence over the one defined in the manifest
+func getBuildParallelism(options *types.BuildOptions) int {
+	if options.Parallelism > 0 {
+		return options.Parallelism
+	}
+	if options.Manifest != nil && options.Manifest.BuildParallelism > 0 {
+		return options.Manifest.BuildParallelism
+	}
+	return 1
+}
+
+// buildServices builds the services following the depends_on graph: a service is built once all its dependencies are built,
+// and up to parallelism services are built at the same time. When a build fails, no more builds are started,
+// the ones running are cancelled and the first error is returned
+func (bc *OktetoBuilder) buildServices(ctx context.Context, svcsToBuild []string, options *types.BuildOptions, parallelism int, build buildServiceFunc) error {
+	buildManifest := options.Manifest.Build
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// builtImagesControl represents the controller for the built services
+	// when a service is built we track it here
+	builtImagesControl := make(map[string]bool)
+	running := make(map[string]bool)
+	results := make(chan buildResult)
+
+	if parallelism > 1 && options.EnableStages {
+		bc.ioCtrl.SetStage("Building services")
+	}
+
+	var firstErr error
+	for len(builtImagesControl) != len(svcsToBuild) {
+		if firstErr == nil {
+			for _, svcToBuild := range svcsToBuild {
+				if len(running) >= parallelism {
+					break
+				}
+				if skipServiceBuild(svcToBuild, builtImagesControl) || running[svcToBuild] {
+					continue
+				}
+				if !areAllServicesBuilt(buildManifest[svcToBuild].DependsOn, builtImagesControl) {
+					bc.ioCtrl.Logger().Infof("image '%s' can't be deployed because at least one of its dependent images(%s) are not built", svcToBuild, strings.Join(buildManifest[svcToBuild].DependsOn, ", "))
+					continue
+				}
+				running[svcToBuild] = true
+				go func(svcName string) {
+					results <- buildResult{
+						svcName: svcName,
+						err:     bc.runServiceBuild(ctx, svcName, options, parallelism, build),
+					}
+				}(svcToBuild)
+			}
+		}
+
+		if len(running) == 0 {
+			if firstErr != nil {
+				return firstErr
+			}
+			return fmt.Errorf("could not build the services: their dependencies can't be built")
+		}
+
+		result := <-results
+		delete(running, result.svcName)
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
+				cancel()
+			}
+			continue
+		}
+		builtImagesControl[result.svcName] = true
+	}
+	return nil
+}
+
+// runServiceBuild builds a service with a copy of the build options.
+// When services are built at the same time, the build progress of each service is displayed
+// as it is received, with every line prefixed by the name of the service
+func (bc *OktetoBuilder) runServiceBuild(ctx context.Context, svcName string, options *types.BuildOptions, parallelism int, build buildServiceFunc) error {
+	svcOptions := *options
+	if parallelism == 1 {
+		if options.EnableStages {
+			bc.ioCtrl.SetStage(fmt.Sprintf("Building service %s", svcName))
+		}
+		return build(ctx, svcName, &svcOptions)
+	}
+
+	progress := newPrefixWriter(bc.ioCtrl.Out(), &bc.outputLock, fmt.Sprintf("[%s] ", svcName))
+	svcOptions.ProgressWriter = progress
+	err := build(ctx, svcName, &svcOptions)
+	if flushErr := progress.Flush(); flushErr != nil {
+		bc.ioCtrl.Logger().Infof("failed to write the build output of service '%s': %s", svcName, flushErr)
+	}
+	return err
+}
+
+// prefixWriter writes every line of the build progress of a service prefixed with the name of the service.
+// The lines are written whole, so the output of the services built at the same time isn't mixed within a line
+type prefixWriter struct {
+	out    io.Writer
+	lock   *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, lock *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{
+		out:    out,
+		lock:   lock,
+		prefix: prefix,
+	}
+}
+
+// Write writes the complete lines of p and keeps the last one until it is completed
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line, even if it isn't completed
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s\n", w.prefix, bytes.TrimSuffix(line, []byte("\r")))
+	return err
+}
@@ -0,0 +1,278 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceBuilds records the services built by buildServices
+type fakeServiceBuilds struct {
+	errs           map[string]error
+	progressWriter map[string]io.Writer
+	built          []string
+	duration       time.Duration
+	running        int
+	maxRunning     int
+	mu             sync.Mutex
+}
+
+func (f *fakeServiceBuilds) build(ctx context.Context, svcName string, options *types.BuildOptions) error {
+	f.mu.Lock()
+	f.running++
+	if f.running > f.maxRunning {
+		f.maxRunning = f.running
+	}
+	f.progressWriter[svcName] = options.ProgressWriter
+	f.mu.Unlock()
+
+	if options.ProgressWriter != nil {
+		_, _ = options.ProgressWriter.Write([]byte("building " + svcName + "\n"))
+	}
+	select {
+	case <-time.After(f.duration):
+	case <-ctx.Done():
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running--
+	if err := f.errs[svcName]; err != nil {
+		return err
+	}
+	f.built = append(f.built, svcName)
+	return nil
+}
+
+func newFakeServiceBuilds(duration time.Duration) *fakeServiceBuilds {
+	return &fakeServiceBuilds{
+		errs:           map[string]error{},
+		progressWriter: map[string]io.Writer{},
+		duration:       duration,
+	}
+}
+
+func TestGetBuildParallelism(t *testing.T) {
+	var tests = []struct {
+		options  *types.BuildOptions
+		name     string
+		expected int
+	}{
+		{
+			name:     "default",
+			options:  &types.BuildOptions{Manifest: &model.Manifest{}},
+			expected: 1,
+		},
+		{
+			name:     "manifest",
+			options:  &types.BuildOptions{Manifest: &model.Manifest{BuildParallelism: 4}},
+			expected: 4,
+		},
+		{
+			name:     "flag takes precedence over the manifest",
+			options:  &types.BuildOptions{Parallelism: 2, Manifest: &model.Manifest{BuildParallelism: 4}},
+			expected: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getBuildParallelism(tt.options))
+		})
+	}
+}
+
+func TestBuildServicesRespectsDependencies(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+	options := &types.BuildOptions{
+		Manifest: &model.Manifest{
+			Build: model.ManifestBuild{
+				"a": &model.BuildInfo{},
+				"b": &model.BuildInfo{DependsOn: []string{"a"}},
+				"c": &model.BuildInfo{DependsOn: []string{"b"}},
+			},
+		},
+	}
+	builds := newFakeServiceBuilds(10 * time.Millisecond)
+
+	err := bc.buildServices(context.Background(), []string{"c", "b", "a"}, options, 3, builds.build)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, builds.built)
+	assert.Equal(t, 1, builds.maxRunning)
+}
+
+func TestBuildServicesInParallel(t *testing.T) {
+	buildManifest := model.ManifestBuild{
+		"a": &model.BuildInfo{},
+		"b": &model.BuildInfo{},
+		"c": &model.BuildInfo{},
+		"d": &model.BuildInfo{},
+		"e": &model.BuildInfo{DependsOn: []string{"a", "b", "c", "d"}},
+	}
+	var tests = []struct {
+		name               string
+		parallelism        int
+		expectedMaxRunning int
+	}{
+		{
+			name:               "sequential",
+			parallelism:        1,
+			expectedMaxRunning: 1,
+		},
+		{
+			name:               "limited by the parallelism",
+			parallelism:        2,
+			expectedMaxRunning: 2,
+		},
+		{
+			name:               "limited by the graph",
+			parallelism:        10,
+			expectedMaxRunning: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+			options := &types.BuildOptions{Manifest: &model.Manifest{Build: buildManifest}}
+			builds := newFakeServiceBuilds(20 * time.Millisecond)
+
+			err := bc.buildServices(context.Background(), []string{"a", "b", "c", "d", "e"}, options, tt.parallelism, builds.build)
+			require.NoError(t, err)
+			assert.Len(t, builds.built, 5)
+			assert.Equal(t, "e", builds.built[4])
+			assert.Equal(t, tt.expectedMaxRunning, builds.maxRunning)
+			for svcName, w := range builds.progressWriter {
+				if tt.parallelism == 1 {
+					assert.Nil(t, w, svcName)
+				} else {
+					assert.NotNil(t, w, svcName)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildServicesStopsOnError(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+	options := &types.BuildOptions{
+		Manifest: &model.Manifest{
+			Build: model.ManifestBuild{
+				"a": &model.BuildInfo{},
+				"b": &model.BuildInfo{},
+				"c": &model.BuildInfo{DependsOn: []string{"a", "b"}},
+			},
+		},
+	}
+	buildErr := errors.New("build failed")
+	builds := newFakeServiceBuilds(10 * time.Millisecond)
+	builds.errs["a"] = buildErr
+
+	err := bc.buildServices(context.Background(), []string{"a", "b", "c"}, options, 2, builds.build)
+	assert.ErrorIs(t, err, buildErr)
+	assert.NotContains(t, builds.built, "c")
+}
+
+func TestBuildServicesWithDependenciesNotToBuild(t *testing.T) {
+	bc := NewFakeBuilder(nil, newFakeRegistry(), fakeConfig{}, &fakeAnalyticsTracker{})
+	options := &types.BuildOptions{
+		Manifest: &model.Manifest{
+			Build: model.ManifestBuild{
+				"a": &model.BuildInfo{},
+				"b": &model.BuildInfo{DependsOn: []string{"a"}},
+			},
+		},
+	}
+	builds := newFakeServiceBuilds(0)
+
+	err := bc.buildServices(context.Background(), []string{"b"}, options, 2, builds.build)
+	assert.Error(t, err)
+	assert.Empty(t, builds.built)
+}
+
+func TestBuildWithParallelismPropagatesEnvVars(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+				Registry:  "my-registry",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	manifest := &model.Manifest{
+		Name:             "test",
+		BuildParallelism: 2,
+		Build: model.ManifestBuild{
+			"a": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+			"b": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/b:test",
+				DependsOn:  []string{"a"},
+			},
+		},
+	}
+	err = bc.Build(ctx, &types.BuildOptions{
+		Manifest: manifest,
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, registry.getFakeImage("okteto/b:test").Args, "OKTETO_BUILD_A_IMAGE=okteto/a:test")
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var lock sync.Mutex
+	w := newPrefixWriter(&out, &lock, "[api] ")
+
+	n, err := w.Write([]byte("#1 [internal] load build definition\n#2 RUN "))
+	require.NoError(t, err)
+	assert.Equal(t, 43, n)
+	assert.Equal(t, "[api] #1 [internal] load build definition\n", out.String())
+
+	_, err = w.Write([]byte("make\r\n#2 DONE"))
+	require.NoError(t, err)
+	assert.Equal(t, "[api] #1 [internal] load build definition\n[api] #2 RUN make\n", out.String())
+
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[api] #1 [internal] load build definition\n[api] #2 RUN make\n[api] #2 DONE\n", out.String())
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[api] #1 [internal] load build definition\n[api] #2 RUN make\n[api] #2 DONE\n", out.String())
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/registry"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+// getSigner returns the signer used to sign the built images or nil if the signature was not requested
+func getSigner(fs afero.Fs, options *types.BuildOptions) (crypto.Signer, error) {
+	if !options.Sign {
+		return nil, nil
+	}
+	signer, err := registry.GetSigner(fs, options.SignKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the signing key: %w", err)
+	}
+	return signer, nil
+}
+
+// signServiceImage signs the image with digest of a service. Images already signed with the same key are skipped
+func (bc *OktetoBuilder) signServiceImage(svcName, image string, signer crypto.Signer) error {
+	if signer == nil {
+		return nil
+	}
+	if err := bc.Registry.SignImage(image, signer); err != nil {
+		return fmt.Errorf("error signing the image of service '%s': %w", svcName, err)
+	}
+	bc.ioCtrl.Out().Success("Image for service '%s' successfully signed", svcName)
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setSignKeyEnvVar(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	t.Setenv(constants.OktetoSignKeyEnvVar, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	return key
+}
+
+func TestGetSigner(t *testing.T) {
+	t.Setenv(constants.OktetoSignKeyEnvVar, "")
+	fs := afero.NewMemMapFs()
+
+	signer, err := getSigner(fs, &types.BuildOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, signer)
+
+	_, err = getSigner(fs, &types.BuildOptions{Sign: true})
+	assert.Error(t, err)
+
+	key := setSignKeyEnvVar(t)
+	signer, err = getSigner(fs, &types.BuildOptions{Sign: true})
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+}
+
+func TestBuildSignsImages(t *testing.T) {
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+				Registry:  "my-registry",
+			},
+		},
+		CurrentContext: "test",
+	}
+	setSignKeyEnvVar(t)
+
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"a": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+		},
+	}
+
+	err = bc.Build(context.Background(), &types.BuildOptions{Manifest: manifest})
+	require.NoError(t, err)
+	assert.Empty(t, registry.signed)
+
+	err = bc.Build(context.Background(), &types.BuildOptions{Manifest: manifest, Sign: true})
+	require.NoError(t, err)
+	assert.True(t, registry.signed["okteto/a:test"])
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// timingStatusBuilt is the status of a service whose image has been built and pushed
+	timingStatusBuilt = "built"
+
+	// timingStatusSkipped is the status of a service whose image was already built for its smart build hash
+	timingStatusSkipped = "skipped"
+
+	// timingStatusExported is the status of a service whose build result has been exported to its outputs
+	timingStatusExported = "exported"
+)
+
+// serviceTiming is the timing of the build of a service
+type serviceTiming struct {
+	types.BuildTiming
+	status string
+	total  time.Duration
+}
+
+// addBuildTiming accumulates the timing of one of the builds of a service.
+// A service with volume mounts runs two builds: the one of its Dockerfile and the one adding the volumes
+func (bc *OktetoBuilder) addBuildTiming(svcName string, timing *types.BuildTiming) {
+	if timing == nil {
+		return
+	}
+	st := bc.getServiceTiming(svcName)
+	bc.timingsLock.Lock()
+	defer bc.timingsLock.Unlock()
+	st.Queue += timing.Queue
+	st.Solve += timing.Solve
+	st.Push += timing.Push
+	st.Steps += timing.Steps
+	st.CachedSteps += timing.CachedSteps
+}
+
+// setServiceTimingResult sets the status and the total duration of the build of a service
+func (bc *OktetoBuilder) setServiceTimingResult(svcName, status string, total time.Duration) {
+	st := bc.getServiceTiming(svcName)
+	bc.timingsLock.Lock()
+	defer bc.timingsLock.Unlock()
+	st.status = status
+	st.total = total
+}
+
+func (bc *OktetoBuilder) getServiceTiming(svcName string) *serviceTiming {
+	bc.timingsLock.Lock()
+	defer bc.timingsLock.Unlock()
+	if bc.timings == nil {
+		bc.timings = map[string]*serviceTiming{}
+	}
+	st, ok := bc.timings[svcName]
+	if !ok {
+		st = &serviceTiming{}
+		bc.timings[svcName] = st
+	}
+	return st
+}
+
+// displayTimingReport prints the timing of each built service and the critical path of the build
+func (bc *OktetoBuilder) displayTimingReport(buildManifest model.ManifestBuild) {
+	bc.timingsLock.Lock()
+	defer bc.timingsLock.Unlock()
+	if len(bc.timings) == 0 {
+		return
+	}
+	report := &strings.Builder{}
+	writeTimingReport(report, buildManifest, bc.timings)
+	bc.ioCtrl.Out().Println(strings.TrimSuffix(report.String(), "\n"))
+}
+
+func writeTimingReport(w io.Writer, buildManifest model.ManifestBuild, timings map[string]*serviceTiming) {
+	services := make([]string, 0, len(timings))
+	for svcName := range timings {
+		services = append(services, svcName)
+	}
+	sort.Strings(services)
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Service\tStatus\tQueue\tSolve\tPush\tCache hits\tTotal")
+	for _, svcName := range services {
+		st := timings[svcName]
+		if st.status == timingStatusSkipped {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t%s\n", svcName, st.status, formatDuration(st.total))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d/%d\t%s\n", svcName, st.status, formatDuration(st.Queue), formatDuration(st.Solve), formatDuration(st.Push), st.CachedSteps, st.Steps, formatDuration(st.total))
+	}
+	tw.Flush()
+
+	path, duration := getCriticalPath(buildManifest, timings)
+	if len(path) > 0 {
+		fmt.Fprintf(w, "Critical path: %s (%s)\n", strings.Join(path, " -> "), formatDuration(duration))
+	}
+}
+
+// getCriticalPath returns the chain of dependent services with the longest accumulated build time,
+// starting from the service without dependencies. These are the services that determine the duration of the build
+func getCriticalPath(buildManifest model.ManifestBuild, timings map[string]*serviceTiming) ([]string, time.Duration) {
+	services := make([]string, 0, len(timings))
+	for svcName := range timings {
+		services = append(services, svcName)
+	}
+	sort.Strings(services)
+
+	longest := map[string]time.Duration{}
+	next := map[string]string{}
+	var visit func(svcName string) time.Duration
+	visit = func(svcName string) time.Duration {
+		if d, ok := longest[svcName]; ok {
+			return d
+		}
+		var maxDependency time.Duration
+		if buildInfo, ok := buildManifest[svcName]; ok {
+			dependencies := append([]string{}, buildInfo.DependsOn...)
+			sort.Strings(dependencies)
+			for _, dependency := range dependencies {
+				if _, ok := timings[dependency]; !ok {
+					continue
+				}
+				if d := visit(dependency); d > maxDependency || next[svcName] == "" {
+					maxDependency = d
+					next[svcName] = dependency
+				}
+			}
+		}
+		longest[svcName] = timings[svcName].total + maxDependency
+		return longest[svcName]
+	}
+
+	last := ""
+	for _, svcName := range services {
+		if d := visit(svcName); last == "" || d > longest[last] {
+			last = svcName
+		}
+	}
+	if last == "" {
+		return nil, 0
+	}
+
+	path := []string{}
+	for svcName := last; svcName != ""; svcName = next[svcName] {
+		path = append([]string{svcName}, path...)
+	}
+	return path, longest[last]
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
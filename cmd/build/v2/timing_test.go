@@ -0,0 +1,170 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCriticalPath(t *testing.T) {
+	buildManifest := model.ManifestBuild{
+		"base":     &model.BuildInfo{},
+		"api":      &model.BuildInfo{DependsOn: []string{"base"}},
+		"frontend": &model.BuildInfo{DependsOn: []string{"base"}},
+		"worker":   &model.BuildInfo{},
+		"e2e":      &model.BuildInfo{DependsOn: []string{"api", "frontend"}},
+	}
+	var tests = []struct {
+		timings          map[string]*serviceTiming
+		name             string
+		expectedPath     []string
+		expectedDuration time.Duration
+	}{
+		{
+			name:    "no timings",
+			timings: map[string]*serviceTiming{},
+		},
+		{
+			name: "longest chain",
+			timings: map[string]*serviceTiming{
+				"base":     {total: 10 * time.Second},
+				"api":      {total: 20 * time.Second},
+				"frontend": {total: 5 * time.Second},
+				"worker":   {total: 25 * time.Second},
+				"e2e":      {total: 1 * time.Second},
+			},
+			expectedPath:     []string{"base", "api", "e2e"},
+			expectedDuration: 31 * time.Second,
+		},
+		{
+			name: "independent service is the slowest",
+			timings: map[string]*serviceTiming{
+				"base":   {total: 10 * time.Second},
+				"api":    {total: 20 * time.Second},
+				"worker": {total: 40 * time.Second},
+			},
+			expectedPath:     []string{"worker"},
+			expectedDuration: 40 * time.Second,
+		},
+		{
+			name: "ties are broken by name",
+			timings: map[string]*serviceTiming{
+				"base":     {total: 10 * time.Second},
+				"api":      {total: 5 * time.Second},
+				"frontend": {total: 5 * time.Second},
+			},
+			expectedPath:     []string{"base", "api"},
+			expectedDuration: 15 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, duration := getCriticalPath(buildManifest, tt.timings)
+			assert.Equal(t, tt.expectedPath, path)
+			assert.Equal(t, tt.expectedDuration, duration)
+		})
+	}
+}
+
+func TestWriteTimingReport(t *testing.T) {
+	buildManifest := model.ManifestBuild{
+		"base": &model.BuildInfo{},
+		"api":  &model.BuildInfo{DependsOn: []string{"base"}},
+	}
+	timings := map[string]*serviceTiming{
+		"base": {
+			status: timingStatusSkipped,
+			total:  300 * time.Millisecond,
+		},
+		"api": {
+			BuildTiming: types.BuildTiming{
+				Queue:       1200 * time.Millisecond,
+				Solve:       30 * time.Second,
+				Push:        4 * time.Second,
+				Steps:       8,
+				CachedSteps: 5,
+			},
+			status: timingStatusBuilt,
+			total:  36 * time.Second,
+		},
+	}
+
+	b := &bytes.Buffer{}
+	writeTimingReport(b, buildManifest, timings)
+
+	expected := `Service  Status   Queue  Solve  Push  Cache hits  Total
+api      built    1.2s   30s    4s    5/8         36s
+base     skipped  -      -      -     -           300ms
+Critical path: base -> api (36.3s)
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestBuildRecordsTimings(t *testing.T) {
+	ctx := context.Background()
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+				Registry:  "my-registry",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	dir, err := createDockerfile(t)
+	require.NoError(t, err)
+
+	registry := newFakeRegistry()
+	builder := test.NewFakeOktetoBuilder(registry)
+	bc := NewFakeBuilder(builder, registry, fakeConfig{isOkteto: true}, &fakeAnalyticsTracker{})
+	manifest := &model.Manifest{
+		Name: "test",
+		Build: model.ManifestBuild{
+			"a": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/a:test",
+			},
+			"b": &model.BuildInfo{
+				Context:    dir,
+				Dockerfile: filepath.Join(dir, "Dockerfile"),
+				Image:      "okteto/b:test",
+				DependsOn:  []string{"a"},
+			},
+		},
+	}
+	err = bc.Build(ctx, &types.BuildOptions{
+		Manifest:     manifest,
+		TimingReport: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, bc.timings, 2)
+	assert.Equal(t, timingStatusBuilt, bc.timings["a"].status)
+	assert.Equal(t, timingStatusBuilt, bc.timings["b"].status)
+}
@@ -31,6 +31,10 @@ type configMapHandler interface {
 	updateConfigMap(context.Context, *apiv1.ConfigMap, *pipeline.CfgData, error) error
 	updateEnvsFromCommands(context.Context, string, string, []string) error
 	getConfigmapVariablesEncoded(ctx context.Context, name, namespace string) (string, error)
+	getInventory(ctx context.Context, name, namespace string) ([]pipeline.InventoryItem, error)
+	updateInventory(ctx context.Context, name, namespace string, inventory []pipeline.InventoryItem) error
+	getHistory(ctx context.Context, name, namespace string) ([]pipeline.Revision, error)
+	addRevision(ctx context.Context, name, namespace string, revision pipeline.Revision) error
 }
 
 // deployInsideDeployConfigMapHandler is the runner used when the okteto is executed
@@ -108,6 +112,43 @@ func (h *defaultConfigMapHandler) updateEnvsFromCommands(ctx context.Context, na
 	return nil
 }
 
+// getInventory returns the objects applied by the last successful deploy
+func (h *defaultConfigMapHandler) getInventory(ctx context.Context, name, namespace string) ([]pipeline.InventoryItem, error) {
+	c, _, err := h.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.GetInventory(ctx, name, namespace, c)
+}
+
+// updateInventory stores in the config map the objects applied by the deploy
+func (h *defaultConfigMapHandler) updateInventory(ctx context.Context, name, namespace string, inventory []pipeline.InventoryItem) error {
+	c, _, err := h.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateInventory(ctx, name, namespace, inventory, c)
+}
+
+// getHistory returns the revisions recorded by the previous deploys
+func (h *defaultConfigMapHandler) getHistory(ctx context.Context, name, namespace string) ([]pipeline.Revision, error) {
+	c, _, err := h.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.GetHistory(ctx, name, namespace, c)
+}
+
+// addRevision stores in the config map the input of the deploy
+func (h *defaultConfigMapHandler) addRevision(ctx context.Context, name, namespace string, revision pipeline.Revision) error {
+	c, _, err := h.k8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	_, err = pipeline.AddRevision(ctx, name, namespace, revision, c)
+	return err
+}
+
 // translateConfigMapAndDeploy with the receiver deployInsideDeployConfigMapHandler doesn't do anything
 // because we have to  control the cfmap in the main execution. If both handled the configmap we will be
 // overwritten the cfmap and leave it in a inconsistent status
@@ -136,3 +177,29 @@ func (*deployInsideDeployConfigMapHandler) updateConfigMap(_ context.Context, _
 func (*deployInsideDeployConfigMapHandler) updateEnvsFromCommands(_ context.Context, _ string, _ string, _ []string) error {
 	return nil
 }
+
+// getInventory with the receiver deployInsideDeployConfigMapHandler doesn't return anything
+// because the inventory is managed by the main execution
+func (*deployInsideDeployConfigMapHandler) getInventory(_ context.Context, _, _ string) ([]pipeline.InventoryItem, error) {
+	return nil, nil
+}
+
+// updateInventory with the receiver deployInsideDeployConfigMapHandler doesn't do anything
+// because we have to  control the cfmap in the main execution. If both handled the configmap we will be
+// overwritten the cfmap and leave it in a inconsistent status
+func (*deployInsideDeployConfigMapHandler) updateInventory(_ context.Context, _, _ string, _ []pipeline.InventoryItem) error {
+	return nil
+}
+
+// getHistory with the receiver deployInsideDeployConfigMapHandler doesn't return anything
+// because the history is managed by the main execution
+func (*deployInsideDeployConfigMapHandler) getHistory(_ context.Context, _, _ string) ([]pipeline.Revision, error) {
+	return nil, nil
+}
+
+// addRevision with the receiver deployInsideDeployConfigMapHandler doesn't do anything
+// because we have to  control the cfmap in the main execution. If both handled the configmap we will be
+// overwritten the cfmap and leave it in a inconsistent status
+func (*deployInsideDeployConfigMapHandler) addRevision(_ context.Context, _, _ string, _ pipeline.Revision) error {
+	return nil
+}
@@ -19,6 +19,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
@@ -40,6 +43,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
+	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -64,12 +68,16 @@ type Options struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath     string
-	Name             string
-	Namespace        string
-	K8sContext       string
-	Repository       string
-	Branch           string
+	ManifestPath string
+	Name         string
+	Namespace    string
+	K8sContext   string
+	Repository   string
+	Branch       string
+	// Rollback is the revision of the deploy history to roll back to
+	Rollback string
+	// VerifyKey is the path of the public key used to verify the signatures of the images
+	VerifyKey        string
 	Variables        []string
 	servicesToDeploy []string
 	Timeout          time.Duration
@@ -79,6 +87,10 @@ type Options struct {
 	RunInRemote      bool
 	Wait             bool
 	ShowCTA          bool
+	DryRun           bool
+	Prune            bool
+	// VerifyImages checks the signatures of the built images before running the deploy commands
+	VerifyImages bool
 }
 
 type builderInterface interface {
@@ -114,6 +126,7 @@ type DeployCommand struct {
 	DivertDriver       divert.Driver
 	PipelineCMD        pipelineCMD.PipelineDeployerInterface
 	AnalyticsTracker   analyticsTrackerInterface
+	ImageVerifier      imageVerifier
 	ioCtrl             *io.IOController
 
 	PipelineType       model.Archetype
@@ -196,6 +209,13 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 
 			options.ShowCTA = oktetoLog.IsInteractive()
 			options.servicesToDeploy = args
+			if options.Rollback == rollbackPreviousRevision && len(args) == 1 {
+				// "okteto deploy --rollback 3" is parsed as a service named "3"
+				if _, err := strconv.Atoi(args[0]); err == nil {
+					options.Rollback = args[0]
+					options.servicesToDeploy = nil
+				}
+			}
 
 			k8sClientProvider := okteto.NewK8sClientProvider()
 			pc, err := pipelineCMD.NewCommand()
@@ -217,6 +237,7 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 				PipelineCMD:        pc,
 				runningInInstaller: config.RunningInInstaller(),
 				AnalyticsTracker:   at,
+				ImageVerifier:      registry.NewOktetoRegistry(okteto.Config{}),
 				ioCtrl:             ioCtrl,
 			}
 			startTime := time.Now()
@@ -251,6 +272,8 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 		},
 	}
 
+	cmd.AddCommand(History(ctx))
+
 	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
 	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrites the namespace where the development environment is deployed")
@@ -260,6 +283,12 @@ func Deploy(ctx context.Context, at analyticsTrackerInterface, ioCtrl *io.IOCont
 	cmd.Flags().BoolVarP(&options.Dependencies, "dependencies", "", false, "deploy the dependencies from manifest")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run deploy commands in remote")
+	cmd.Flags().BoolVarP(&options.Prune, "prune", "", false, "delete the objects applied by the previous deploy that are not applied anymore")
+	cmd.Flags().StringVar(&options.Rollback, "rollback", "", "deploy again the manifest, variables and images of a previous revision. Defaults to the last successful revision")
+	cmd.Flags().Lookup("rollback").NoOptDefVal = rollbackPreviousRevision
+	cmd.Flags().BoolVarP(&options.VerifyImages, "verify-images", "", false, "verify the signatures of the built images before running the deploy commands")
+	cmd.Flags().StringVar(&options.VerifyKey, "verify-key", "", "path to the public key used to verify the signatures of the images (default is the value of OKTETO_VERIFY_KEY)")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "run the deploy commands without persisting any change in the cluster and show the objects that would be applied")
 
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the development environment is deployed (defaults to false)")
 	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "the length of time to wait for completion, zero means never. Any other values should contain a corresponding time unit e.g. 1s, 2m, 3h ")
@@ -303,6 +332,22 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		return err
 	}
 
+	if deployOptions.Prune {
+		if err := validatePrune(deployOptions); err != nil {
+			return err
+		}
+	}
+
+	if deployOptions.Rollback != "" {
+		if err := validateRollback(deployOptions); err != nil {
+			return err
+		}
+	}
+
+	if deployOptions.DryRun {
+		return dc.runDryRun(ctx, deployOptions)
+	}
+
 	if dc.isRemote || dc.runningInInstaller {
 		currentVars, err := dc.CfgMapHandler.getConfigmapVariablesEncoded(ctx, deployOptions.Name, deployOptions.Manifest.Namespace)
 		if err != nil {
@@ -316,6 +361,14 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		}
 	}
 
+	var rollbackRevision *pipeline.Revision
+	if deployOptions.Rollback != "" {
+		rollbackRevision, err = dc.loadRollbackRevision(ctx, deployOptions, cwd, c)
+		if err != nil {
+			return err
+		}
+	}
+
 	data := &pipeline.CfgData{
 		Name:       deployOptions.Name,
 		Namespace:  deployOptions.Manifest.Namespace,
@@ -350,11 +403,23 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		return nil
 	}
 
-	if err := buildImages(ctx, dc.Builder, deployOptions); err != nil {
-		if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
-			return errStatus
+	// the images of the revision were set by loadRollbackRevision
+	if rollbackRevision == nil {
+		if err := buildImages(ctx, dc.Builder, deployOptions); err != nil {
+			if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
+				return errStatus
+			}
+			return err
+		}
+	}
+
+	if deployOptions.VerifyImages {
+		if err := dc.verifyImages(deployOptions); err != nil {
+			if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
+				return errStatus
+			}
+			return err
 		}
-		return err
 	}
 
 	if err := dc.recreateFailedPods(ctx, deployOptions.Name); err != nil {
@@ -405,6 +470,8 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 		data.Status = pipeline.DeployedStatus
 	}
 
+	dc.addRevision(ctx, deployOptions, data, rollbackRevision)
+
 	if errStatus := dc.CfgMapHandler.updateConfigMap(ctx, cfg, data, err); errStatus != nil {
 		return errStatus
 	}
@@ -412,7 +479,74 @@ func (dc *DeployCommand) RunDeploy(ctx context.Context, deployOptions *Options)
 	return err
 }
 
-func buildImages(ctx context.Context, builder builderInterface, deployOptions *Options) error {
+// runDryRun runs the deploy commands forwarding every request to the cluster with dryRun=All.
+// The images, the pipeline configmap, the dependencies and the post deploy steps are skipped, as they would modify the cluster
+func (dc *DeployCommand) runDryRun(ctx context.Context, deployOptions *Options) error {
+	if shouldRunInRemote(deployOptions) {
+		return errDryRunNotSupportedInRemote
+	}
+
+	if deployOptions.Manifest.HasDependencies() {
+		oktetoLog.Warning("Dependencies are not deployed in dry-run mode")
+	}
+
+	if deployOptions.Manifest.Deploy == nil {
+		return nil
+	}
+
+	if err := checkImagesToBuild(ctx, dc.Builder, deployOptions); err != nil {
+		return err
+	}
+
+	deployer, err := dc.GetDeployer(ctx, deployOptions, dc.Builder, dc.CfgMapHandler, dc.K8sClientProvider, NewKubeConfig(), model.GetAvailablePort, dc.ioCtrl)
+	if err != nil {
+		return err
+	}
+
+	if err := deployer.deploy(ctx, deployOptions); err != nil {
+		if err == oktetoErrors.ErrIntSig {
+			return nil
+		}
+		return oktetoErrors.UserError{E: err}
+	}
+	return nil
+}
+
+// validatePrune checks that the objects applied by the deploy can be compared with the previous inventory
+func validatePrune(deployOptions *Options) error {
+	if deployOptions.DryRun {
+		return errPruneWithDryRun
+	}
+	if shouldRunInRemote(deployOptions) {
+		return errPruneNotSupportedInRemote
+	}
+	if len(deployOptions.servicesToDeploy) > 0 {
+		return errPruneWithServices
+	}
+	return nil
+}
+
+// checkImagesToBuild warns about the images that a deploy would build. They are not built in dry-run mode,
+// as building an image pushes it to the registry
+func checkImagesToBuild(ctx context.Context, builder builderInterface, deployOptions *Options) error {
+	servicesToBuild := setToSlice(getServicesToBuildSet(deployOptions))
+	if !deployOptions.Build {
+		var err error
+		servicesToBuild, err = builder.GetServicesToBuild(ctx, deployOptions.Manifest, servicesToBuild)
+		if err != nil {
+			return err
+		}
+	}
+	if len(servicesToBuild) == 0 {
+		return nil
+	}
+	sort.Strings(servicesToBuild)
+	oktetoLog.Warning("Dry run: the images of %s are not built. The deploy commands that use them might fail", strings.Join(servicesToBuild, ", "))
+	return nil
+}
+
+// getServicesToBuildSet returns the services built by a deploy
+func getServicesToBuildSet(deployOptions *Options) map[string]bool {
 	var stackServicesWithBuild map[string]bool
 
 	if stack := deployOptions.Manifest.GetStack(); stack != nil {
@@ -426,7 +560,11 @@ func buildImages(ctx context.Context, builder builderInterface, deployOptions *O
 	// - All the services that have a build section defined in the *okteto* manifest
 	// - Services from *deployOptions.servicesToDeploy* that have a build section
 
-	servicesToBuildSet := setUnion(oktetoManifestServicesWithBuild, servicesToDeployWithBuild)
+	return setUnion(oktetoManifestServicesWithBuild, servicesToDeployWithBuild)
+}
+
+func buildImages(ctx context.Context, builder builderInterface, deployOptions *Options) error {
+	servicesToBuildSet := getServicesToBuildSet(deployOptions)
 
 	if deployOptions.Build {
 		buildOptions := &types.BuildOptions{
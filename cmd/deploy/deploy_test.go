@@ -15,11 +15,13 @@ package deploy
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -124,6 +126,11 @@ func (fr fakeRegistry) GetRepoNameAndTag(repo string) (string, string)   { retur
 func (fr fakeRegistry) CloneGlobalImageToDev(imageWithDigest, tag string) (string, error) {
 	return "", nil
 }
+func (fr fakeRegistry) GetAttestationDigests(image string) ([]string, error) {
+	return nil, nil
+}
+
+func (fr fakeRegistry) SignImage(_ string, _ crypto.Signer) error { return nil }
 
 var fakeManifest *model.Manifest = &model.Manifest{
 	Deploy: &model.DeployInfo{
@@ -174,6 +181,7 @@ type fakeProxy struct {
 type fakeExecutor struct {
 	err      error
 	executed []model.DeployCommand
+	mu       sync.Mutex
 }
 
 type fakeKubeConfig struct {
@@ -184,6 +192,9 @@ type fakeKubeConfig struct {
 
 type fakeCmapHandler struct {
 	errUpdatingWithEnvs error
+	inventory           []pipeline.InventoryItem
+	history             []pipeline.Revision
+	inventoryUpdated    bool
 }
 
 func (*fakeCmapHandler) translateConfigMapAndDeploy(context.Context, *pipeline.CfgData) (*apiv1.ConfigMap, error) {
@@ -202,6 +213,26 @@ func (f *fakeCmapHandler) updateEnvsFromCommands(context.Context, string, string
 	return f.errUpdatingWithEnvs
 }
 
+func (f *fakeCmapHandler) getInventory(context.Context, string, string) ([]pipeline.InventoryItem, error) {
+	return f.inventory, nil
+}
+
+func (f *fakeCmapHandler) updateInventory(_ context.Context, _, _ string, inventory []pipeline.InventoryItem) error {
+	f.inventory = inventory
+	f.inventoryUpdated = true
+	return nil
+}
+
+func (f *fakeCmapHandler) getHistory(context.Context, string, string) ([]pipeline.Revision, error) {
+	return f.history, nil
+}
+
+func (f *fakeCmapHandler) addRevision(_ context.Context, _, _ string, revision pipeline.Revision) error {
+	revision.Number = len(f.history) + 1
+	f.history = append(f.history, revision)
+	return nil
+}
+
 func (f *fakeKubeConfig) Read() (*rest.Config, error) {
 	if f.errRead != nil {
 		return nil, f.errRead
@@ -221,6 +252,10 @@ func (*fakeProxy) SetName(_ string) {}
 
 func (*fakeProxy) SetDivert(_ divert.Driver) {}
 
+func (*fakeProxy) SetDryRun(_ *dryRunRecorder) {}
+
+func (*fakeProxy) SetInventory(_ *inventoryRecorder) {}
+
 func (fk *fakeProxy) Shutdown(_ context.Context) error {
 	if fk.errOnShutdown != nil {
 		return fk.errOnShutdown
@@ -239,6 +274,8 @@ func (fk *fakeProxy) GetToken() string {
 }
 
 func (fe *fakeExecutor) Execute(command model.DeployCommand, _ []string) error {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
 	fe.executed = append(fe.executed, command)
 	if fe.err != nil {
 		return fe.err
@@ -753,6 +790,99 @@ func TestDeployWithoutErrors(t *testing.T) {
 	assert.Equal(t, pipeline.DeployedStatus, cfg.Data["status"])
 }
 
+func TestDeployDryRun(t *testing.T) {
+	fakeK8sClientProvider := test.NewFakeK8sProvider()
+	fakeDeployer := &fakeDeployer{
+		proxy:             &fakeProxy{},
+		executor:          &fakeExecutor{},
+		kubeconfig:        &fakeKubeConfig{},
+		fs:                afero.NewMemMapFs(),
+		k8sClientProvider: fakeK8sClientProvider,
+	}
+
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	c := &DeployCommand{
+		GetManifest:       getFakeManifest,
+		K8sClientProvider: fakeK8sClientProvider,
+		CfgMapHandler:     newDefaultConfigMapHandler(fakeK8sClientProvider),
+		GetDeployer:       fakeDeployer.Get,
+		Builder:           &fakeV2Builder{},
+	}
+	ctx := context.Background()
+	opts := &Options{
+		Name:      "movies",
+		Variables: []string{},
+		DryRun:    true,
+	}
+
+	err := c.RunDeploy(ctx, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fakeManifest.Deploy.Commands, fakeDeployer.executor.executed)
+
+	// the pipeline configmap is not created in dry-run mode
+	fakeClient, _, err := c.K8sClientProvider.Provide(clientcmdapi.NewConfig())
+	require.NoError(t, err)
+	_, err = configmaps.Get(ctx, pipeline.TranslatePipelineName(opts.Name), okteto.Context().Namespace, fakeClient)
+	assert.Error(t, err)
+}
+
+func TestDeployDryRunInRemote(t *testing.T) {
+	c := &DeployCommand{}
+	opts := &Options{
+		Manifest: &model.Manifest{
+			Deploy: &model.DeployInfo{
+				Remote: true,
+			},
+		},
+		DryRun: true,
+	}
+
+	err := c.runDryRun(context.Background(), opts)
+	assert.ErrorIs(t, err, errDryRunNotSupportedInRemote)
+}
+
+func TestValidatePrune(t *testing.T) {
+	tests := []struct {
+		expectedErr error
+		opts        *Options
+		name        string
+	}{
+		{
+			name: "valid",
+			opts: &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}},
+		},
+		{
+			name:        "with dry-run",
+			opts:        &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}, DryRun: true},
+			expectedErr: errPruneWithDryRun,
+		},
+		{
+			name:        "in remote",
+			opts:        &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{Remote: true}}},
+			expectedErr: errPruneNotSupportedInRemote,
+		},
+		{
+			name:        "subset of services",
+			opts:        &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}, servicesToDeploy: []string{"api"}},
+			expectedErr: errPruneWithServices,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validatePrune(tt.opts), tt.expectedErr)
+		})
+	}
+}
+
 func getManifestWithError(_ string) (*model.Manifest, error) {
 	return nil, assert.AnError
 }
@@ -1432,3 +1562,36 @@ func Test_GetDeployer(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckImagesToBuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		build bool
+	}{
+		{
+			name: "images to build",
+		},
+		{
+			name:  "forced build",
+			build: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &fakeV2Builder{servicesAlreadyBuilt: []string{"api"}}
+			deployOptions := &Options{
+				Build: tt.build,
+				Manifest: &model.Manifest{
+					Build: model.ManifestBuild{
+						"api":    &model.BuildInfo{},
+						"worker": &model.BuildInfo{},
+					},
+					Deploy: &model.DeployInfo{},
+				},
+			}
+			require.NoError(t, checkImagesToBuild(context.Background(), builder, deployOptions))
+			// the images are never built in dry-run mode
+			assert.Nil(t, builder.buildOptionsStorage)
+		})
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/pmezard/go-difflib/difflib"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	dryRunQueryParam = "dryRun"
+	dryRunAll        = "All"
+)
+
+var (
+	errDryRunNotSupportedInRemote = errors.New("'--dry-run' is not supported when the deploy commands run remotely")
+)
+
+// dryRunObject represents an object that would be created, updated or deleted by the deploy
+type dryRunObject struct {
+	object   *unstructured.Unstructured
+	resource schema.GroupVersionResource
+	// namespace and name are taken from the request for deletions, as the response body might be a status object
+	namespace string
+	name      string
+	deleted   bool
+}
+
+func (o dryRunObject) key() string {
+	return fmt.Sprintf("%s/%s/%s", o.resource.String(), o.namespace, o.name)
+}
+
+// dryRunRecorder keeps the objects returned by the kubernetes API for the requests forwarded with dryRun=All
+type dryRunRecorder struct {
+	objects map[string]dryRunObject
+	order   []string
+	mu      sync.Mutex
+}
+
+func newDryRunRecorder() *dryRunRecorder {
+	return &dryRunRecorder{
+		objects: map[string]dryRunObject{},
+	}
+}
+
+// isMutatingRequest returns true for the requests that modify objects in the cluster
+func isMutatingRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// supportsDryRun returns true for the requests that create, update, patch or delete regular resources.
+// Connect requests and most subresources (pods/exec, pods/portforward, pods/eviction...) don't support the dryRun param.
+// The status and scale subresources are updates of the resource, so they are sent with dryRun too
+func supportsDryRun(r *http.Request) bool {
+	if !isMutatingRequest(r) {
+		return false
+	}
+	_, _, _, subresource, ok := parseResourcePath(r.URL.Path)
+	if !ok {
+		return false
+	}
+	switch subresource {
+	case "", "status", "scale":
+		return true
+	}
+	return false
+}
+
+// connectSubresources open a stream to run commands in a pod or to forward traffic to it, even when requested with GET
+var connectSubresources = map[string]bool{
+	"exec":        true,
+	"attach":      true,
+	"portforward": true,
+	"proxy":       true,
+}
+
+// checkDryRunRequest returns an error for the requests that would modify the cluster in dry-run mode,
+// as they can't be sent with the dryRun param
+func checkDryRunRequest(r *http.Request) error {
+	_, _, _, subresource, ok := parseResourcePath(r.URL.Path)
+	if ok && connectSubresources[strings.Split(subresource, "/")[0]] {
+		return fmt.Errorf("'%s' requests are not supported in dry-run mode: %s %s", subresource, r.Method, r.URL.Path)
+	}
+	if isMutatingRequest(r) && !supportsDryRun(r) {
+		return fmt.Errorf("the request can't be simulated in dry-run mode: %s %s", r.Method, r.URL.Path)
+	}
+	return nil
+}
+
+// rejectDryRunRequest answers a request with a kubernetes status error, so the client shows why it failed
+func rejectDryRunRequest(rw http.ResponseWriter, err error) {
+	oktetoLog.Infof("dry-run: rejecting request: %s", err)
+	status := k8sErrors.NewBadRequest(err.Error()).ErrStatus
+	status.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(int(status.Code))
+	if err := json.NewEncoder(rw).Encode(status); err != nil {
+		oktetoLog.Infof("dry-run: could not write the response: %s", err)
+	}
+}
+
+// setDryRun adds the dryRun=All query param so the kubernetes API server doesn't persist the request
+func setDryRun(r *http.Request) {
+	query := r.URL.Query()
+	query.Set(dryRunQueryParam, dryRunAll)
+	r.URL.RawQuery = query.Encode()
+}
+
+// record stores the object returned by the kubernetes API for a dry-run request
+func (r *dryRunRecorder) record(resp *http.Response) error {
+	req := resp.Request
+	if req == nil || !isMutatingRequest(req) || req.URL.Query().Get(dryRunQueryParam) != dryRunAll {
+		return nil
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil
+	}
+
+	resource, namespace, name, subresource, ok := parseResourcePath(req.URL.Path)
+	if !ok || subresource != "" {
+		return nil
+	}
+
+	obj := dryRunObject{
+		resource:  resource,
+		namespace: namespace,
+		name:      name,
+		deleted:   req.Method == http.MethodDelete,
+	}
+
+	if !obj.deleted {
+		if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+			oktetoLog.Infof("dry-run: ignoring non json response for %s %s", req.Method, req.URL.Path)
+			return nil
+		}
+		body, err := readResponseBody(resp)
+		if err != nil {
+			return err
+		}
+		u := &unstructured.Unstructured{}
+		if err := u.UnmarshalJSON(body); err != nil {
+			oktetoLog.Infof("dry-run: could not decode response for %s %s: %s", req.Method, req.URL.Path, err)
+			return nil
+		}
+		obj.object = u
+		obj.namespace = u.GetNamespace()
+		obj.name = u.GetName()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := obj.key()
+	if _, ok := r.objects[key]; !ok {
+		r.order = append(r.order, key)
+	}
+	r.objects[key] = obj
+	return nil
+}
+
+// getObjects returns the recorded objects in the order they were first applied
+func (r *dryRunRecorder) getObjects() []dryRunObject {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]dryRunObject, 0, len(r.order))
+	for _, key := range r.order {
+		result = append(result, r.objects[key])
+	}
+	return result
+}
+
+// readResponseBody reads the body of the response leaving it available for the client
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the response body: %w", err)
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(raw))
+
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return raw, nil
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the compressed response body: %w", err)
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
+
+// parseResourcePath extracts the resource, namespace, name and subresource from a kubernetes API path:
+// /api/v1/namespaces/{namespace}/{resource}/{name}/{subresource} or /apis/{group}/{version}/...
+func parseResourcePath(path string) (schema.GroupVersionResource, string, string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	gvr := schema.GroupVersionResource{}
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		gvr.Version = parts[1]
+		parts = parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		gvr.Group = parts[1]
+		gvr.Version = parts[2]
+		parts = parts[3:]
+	default:
+		return gvr, "", "", "", false
+	}
+
+	namespace := ""
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace = parts[1]
+		parts = parts[2:]
+	}
+
+	gvr.Resource = parts[0]
+	name, subresource := "", ""
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+	if len(parts) > 2 {
+		subresource = strings.Join(parts[2:], "/")
+	}
+	return gvr, namespace, name, subresource, true
+}
+
+// showDryRunReport prints every object that the deploy would apply together with its diff against the live object
+func showDryRunReport(ctx context.Context, recorder *dryRunRecorder, c dynamic.Interface, w io.Writer) error {
+	objects := recorder.getObjects()
+	oktetoLog.Information("Dry run: the deploy commands would modify %d Kubernetes objects", len(objects))
+	for _, obj := range objects {
+		live, err := getLiveObject(ctx, c, obj)
+		if err != nil {
+			return err
+		}
+
+		action := "configured"
+		switch {
+		case obj.deleted:
+			action = "deleted"
+		case live == nil:
+			action = "created"
+		}
+		id := fmt.Sprintf("%s/%s", obj.resource.GroupResource().String(), obj.name)
+		if obj.namespace != "" {
+			id = fmt.Sprintf("%s in namespace '%s'", id, obj.namespace)
+		}
+		fmt.Fprintf(w, "\n# %s %s\n", id, action)
+
+		diff, err := getObjectDiff(live, obj)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			fmt.Fprintln(w, "# no changes")
+			continue
+		}
+		fmt.Fprint(w, diff)
+	}
+	return nil
+}
+
+func getLiveObject(ctx context.Context, c dynamic.Interface, obj dryRunObject) (*unstructured.Unstructured, error) {
+	if obj.name == "" {
+		return nil, nil
+	}
+	var client dynamic.ResourceInterface = c.Resource(obj.resource)
+	if obj.namespace != "" {
+		client = c.Resource(obj.resource).Namespace(obj.namespace)
+	}
+	live, err := client.Get(ctx, obj.name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not get live object '%s': %w", obj.name, err)
+	}
+	return live, nil
+}
+
+// getObjectDiff returns the unified diff between the live object and the dry-run result
+func getObjectDiff(live *unstructured.Unstructured, obj dryRunObject) (string, error) {
+	liveYAML, err := toComparableYAML(live)
+	if err != nil {
+		return "", err
+	}
+	newYAML := ""
+	if !obj.deleted {
+		newYAML, err = toComparableYAML(obj.object)
+		if err != nil {
+			return "", err
+		}
+	}
+	if liveYAML == newYAML {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(newYAML),
+		FromFile: "live",
+		ToFile:   "dry-run",
+		Context:  3,
+	})
+}
+
+// toComparableYAML returns the YAML representation of an object without the fields managed by the API server
+func toComparableYAML(u *unstructured.Unstructured) (string, error) {
+	if u == nil {
+		return "", nil
+	}
+	obj := u.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	b, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("could not encode object '%s': %w", obj.GetName(), err)
+	}
+	y, err := yaml.JSONToYAML(b)
+	if err != nil {
+		return "", fmt.Errorf("could not encode object '%s': %w", obj.GetName(), err)
+	}
+	return string(y), nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func Test_parseResourcePath(t *testing.T) {
+	tests := []struct {
+		name                string
+		path                string
+		expectedGVR         schema.GroupVersionResource
+		expectedNamespace   string
+		expectedName        string
+		expectedSubresource string
+		expectedOK          bool
+	}{
+		{
+			name:              "core namespaced collection",
+			path:              "/api/v1/namespaces/test/services",
+			expectedGVR:       schema.GroupVersionResource{Version: "v1", Resource: "services"},
+			expectedNamespace: "test",
+			expectedOK:        true,
+		},
+		{
+			name:              "group namespaced object",
+			path:              "/apis/apps/v1/namespaces/test/deployments/api",
+			expectedGVR:       schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			expectedNamespace: "test",
+			expectedName:      "api",
+			expectedOK:        true,
+		},
+		{
+			name:                "subresource",
+			path:                "/apis/apps/v1/namespaces/test/deployments/api/status",
+			expectedGVR:         schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			expectedNamespace:   "test",
+			expectedName:        "api",
+			expectedSubresource: "status",
+			expectedOK:          true,
+		},
+		{
+			name:         "cluster scoped object",
+			path:         "/api/v1/namespaces/test",
+			expectedGVR:  schema.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+			expectedName: "test",
+			expectedOK:   true,
+		},
+		{
+			name: "not a resource path",
+			path: "/version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvr, namespace, name, subresource, ok := parseResourcePath(tt.path)
+			require.Equal(t, tt.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.expectedGVR, gvr)
+			assert.Equal(t, tt.expectedNamespace, namespace)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedSubresource, subresource)
+		})
+	}
+}
+
+func newDryRunResponse(t *testing.T, method, path, body string) *http.Response {
+	u, err := url.Parse(path)
+	require.NoError(t, err)
+	req := &http.Request{Method: method, URL: u}
+	setDryRun(req)
+	return &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func Test_supportsDryRun(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/configmaps", expected: true},
+		{method: http.MethodPut, path: "/apis/apps/v1/namespaces/test/deployments/api", expected: true},
+		{method: http.MethodPatch, path: "/apis/apps/v1/namespaces/test/deployments/api/scale", expected: true},
+		{method: http.MethodDelete, path: "/api/v1/namespaces/test/services/api", expected: true},
+		{method: http.MethodGet, path: "/api/v1/namespaces/test/configmaps", expected: false},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/exec", expected: false},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/portforward", expected: false},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/eviction", expected: false},
+		{method: http.MethodPost, path: "/version", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			u, err := url.Parse(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, supportsDryRun(&http.Request{Method: tt.method, URL: u}))
+		})
+	}
+}
+
+func Test_checkDryRunRequest(t *testing.T) {
+	tests := []struct {
+		method      string
+		path        string
+		expectedErr bool
+	}{
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/configmaps"},
+		{method: http.MethodPatch, path: "/apis/apps/v1/namespaces/test/deployments/api/scale"},
+		{method: http.MethodGet, path: "/api/v1/namespaces/test/pods/api/log"},
+		{method: http.MethodGet, path: "/api/v1/namespaces/test/configmaps"},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/exec", expectedErr: true},
+		{method: http.MethodGet, path: "/api/v1/namespaces/test/pods/api/exec", expectedErr: true},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/attach", expectedErr: true},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/portforward", expectedErr: true},
+		{method: http.MethodGet, path: "/api/v1/namespaces/test/services/api/proxy/healthz", expectedErr: true},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/eviction", expectedErr: true},
+		{method: http.MethodPost, path: "/api/v1/namespaces/test/pods/api/binding", expectedErr: true},
+		{method: http.MethodPost, path: "/version", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			u, err := url.Parse(tt.path)
+			require.NoError(t, err)
+			err = checkDryRunRequest(&http.Request{Method: tt.method, URL: u})
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_rejectDryRunRequest(t *testing.T) {
+	rw := httptest.NewRecorder()
+	rejectDryRunRequest(rw, errors.New("not supported"))
+
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	status := &metav1.Status{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), status))
+	assert.Equal(t, "Status", status.Kind)
+	assert.Equal(t, "not supported", status.Message)
+}
+
+func Test_dryRunRecorder(t *testing.T) {
+	recorder := newDryRunRecorder()
+
+	cmBody := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cfg","namespace":"test"},"data":{"key":"value"}}`
+	resp := newDryRunResponse(t, http.MethodPost, "/api/v1/namespaces/test/configmaps", cmBody)
+	require.NoError(t, recorder.record(resp))
+
+	// the body is still available for the client
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, cmBody, string(b))
+
+	// requests that are not dry-run are ignored
+	u, err := url.Parse("/api/v1/namespaces/test/secrets")
+	require.NoError(t, err)
+	require.NoError(t, recorder.record(&http.Response{
+		StatusCode: http.StatusCreated,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    &http.Request{Method: http.MethodPost, URL: u},
+	}))
+
+	require.NoError(t, recorder.record(newDryRunResponse(t, http.MethodDelete, "/api/v1/namespaces/test/services/old", `{"kind":"Status"}`)))
+
+	objects := recorder.getObjects()
+	require.Len(t, objects, 2)
+	assert.Equal(t, "cfg", objects[0].name)
+	assert.False(t, objects[0].deleted)
+	assert.Equal(t, "old", objects[1].name)
+	assert.True(t, objects[1].deleted)
+}
+
+func Test_showDryRunReport(t *testing.T) {
+	live := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "cfg",
+				"namespace":       "test",
+				"resourceVersion": "1",
+			},
+			"data": map[string]interface{}{
+				"key": "old",
+			},
+		},
+	}
+	c := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(), live)
+
+	recorder := newDryRunRecorder()
+	require.NoError(t, recorder.record(newDryRunResponse(t, http.MethodPut, "/api/v1/namespaces/test/configmaps/cfg",
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cfg","namespace":"test","resourceVersion":"2"},"data":{"key":"new"}}`)))
+	require.NoError(t, recorder.record(newDryRunResponse(t, http.MethodPost, "/api/v1/namespaces/test/configmaps",
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other","namespace":"test"}}`)))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, showDryRunReport(context.Background(), recorder, c, out))
+
+	result := out.String()
+	assert.Contains(t, result, "# configmaps/cfg in namespace 'test' configured")
+	assert.Contains(t, result, "-  key: old")
+	assert.Contains(t, result, "+  key: new")
+	assert.NotContains(t, result, "resourceVersion")
+	assert.Contains(t, result, "# configmaps/other in namespace 'test' created")
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// HistoryOptions defines the options to list the deploy history
+type HistoryOptions struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Output       string
+}
+
+// History lists the revisions recorded by the deploys of a development environment
+func History(ctx context.Context) *cobra.Command {
+	options := &HistoryOptions{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the revisions of a development environment that can be rolled back",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#deploy"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateHistoryOutput(options.Output); err != nil {
+				return err
+			}
+
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+
+			ctxResource, err := utils.LoadManifestContext(options.ManifestPath)
+			if err != nil {
+				if !oktetoErrors.IsNotExist(err) {
+					return err
+				}
+				ctxResource = &model.ContextResource{}
+			}
+			if err := ctxResource.UpdateNamespace(options.Namespace); err != nil {
+				return err
+			}
+			if err := ctxResource.UpdateContext(options.K8sContext); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      options.Output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+
+			if options.Name == "" {
+				manifest, err := model.GetManifestV2(options.ManifestPath)
+				if err != nil {
+					return err
+				}
+				options.Name = manifest.Name
+				if options.Name == "" {
+					cwd, err := os.Getwd()
+					if err != nil {
+						return fmt.Errorf("failed to get the current working directory: %w", err)
+					}
+					options.Name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, okteto.Context().Namespace, options.ManifestPath)
+				}
+			}
+
+			history, err := pipeline.GetHistory(ctx, options.Name, okteto.Context().Namespace, c)
+			if err != nil {
+				return err
+			}
+			return showHistory(history, options.Output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func validateHistoryOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+	}
+}
+
+// historyItem is the information of a revision shown by okteto deploy history
+type historyItem struct {
+	Date       string   `json:"date"`
+	Status     string   `json:"status"`
+	GitCommit  string   `json:"gitCommit,omitempty"`
+	Branch     string   `json:"branch,omitempty"`
+	Images     []string `json:"images,omitempty"`
+	Revision   int      `json:"revision"`
+	RollbackOf int      `json:"rollbackOf,omitempty"`
+}
+
+// showHistory prints the revisions, the newest first
+func showHistory(history []pipeline.Revision, output string, w io.Writer) error {
+	items := make([]historyItem, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		revision := history[i]
+		item := historyItem{
+			Revision:   revision.Number,
+			Date:       revision.Date.Format(time.RFC3339),
+			Status:     revision.Status,
+			GitCommit:  revision.GitCommit,
+			Branch:     revision.Branch,
+			RollbackOf: revision.RollbackOf,
+		}
+		for name, value := range revision.Images {
+			if strings.HasSuffix(name, "_IMAGE") {
+				item.Images = append(item.Images, value)
+			}
+		}
+		sort.Strings(item.Images)
+		items = append(items, item)
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(items, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	cols := []string{"Revision", "Date", "Status", "Commit", "Branch", "Description"}
+	fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	for _, item := range items {
+		commit := item.GitCommit
+		if commit == "" {
+			commit = "-"
+		}
+		branch := item.Branch
+		if branch == "" {
+			branch = "-"
+		}
+		description := "-"
+		if item.RollbackOf != 0 {
+			description = fmt.Sprintf("rollback to %d", item.RollbackOf)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", item.Revision, item.Date, item.Status, commit, branch, description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowHistory(t *testing.T) {
+	date := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	history := []pipeline.Revision{
+		{
+			Number:    1,
+			Date:      date,
+			Status:    pipeline.DeployedStatus,
+			GitCommit: "1234567",
+			Branch:    "main",
+			Images: map[string]string{
+				"OKTETO_BUILD_API_IMAGE": "okteto.dev/api@sha256:123",
+				"OKTETO_BUILD_API_TAG":   "sha256:123",
+			},
+		},
+		{
+			Number:     2,
+			Date:       date.Add(time.Hour),
+			Status:     pipeline.ErrorStatus,
+			RollbackOf: 1,
+		},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, showHistory(history, "", &b))
+	expected := `Revision  Date                  Status    Commit   Branch  Description
+2         2023-06-01T11:00:00Z  error     -        -       rollback to 1
+1         2023-06-01T10:00:00Z  deployed  1234567  main    -
+`
+	assert.Equal(t, expected, b.String())
+
+	b.Reset()
+	require.NoError(t, showHistory(history[:1], "json", &b))
+	expected = `[
+ {
+  "date": "2023-06-01T10:00:00Z",
+  "status": "deployed",
+  "gitCommit": "1234567",
+  "branch": "main",
+  "images": [
+   "okteto.dev/api@sha256:123"
+  ],
+  "revision": 1
+ }
+]
+`
+	assert.Equal(t, expected, b.String())
+
+	b.Reset()
+	require.NoError(t, showHistory(nil, "json", &b))
+	assert.Equal(t, "[]\n", b.String())
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// resourcePolicyAnnotation allows to keep an object when it is pruned or destroyed
+	resourcePolicyAnnotation = "dev.okteto.com/policy"
+	keepPolicy               = "keep"
+
+	// helmReleaseSecretType and helmOwnerLabel identify the objects where helm stores the history of a release
+	helmReleaseSecretType = "helm.sh/release.v1"
+	helmOwnerLabel        = "owner"
+	helmOwner             = "helm"
+)
+
+var (
+	errPruneNotSupportedInRemote = errors.New("'--prune' is not supported when the deploy commands run remotely")
+	errPruneWithDryRun           = errors.New("'--prune' and '--dry-run' can't be used at the same time")
+	errPruneWithServices         = errors.New("'--prune' can't be used when deploying a subset of services")
+)
+
+// inventoryRecorder keeps the objects applied through the proxy during a deploy
+type inventoryRecorder struct {
+	items map[string]pipeline.InventoryItem
+	// name is the value of the deployed-by label of the objects of the development environment
+	name string
+	mu   sync.Mutex
+}
+
+func newInventoryRecorder(name string) *inventoryRecorder {
+	return &inventoryRecorder{
+		items: map[string]pipeline.InventoryItem{},
+		name:  name,
+	}
+}
+
+func inventoryKey(item pipeline.InventoryItem) string {
+	return fmt.Sprintf("%s/%s/%s/%s", item.Group, item.Resource, item.Namespace, item.Name)
+}
+
+// record stores the object created or updated by a request. Deleted objects are removed from the inventory.
+// kubectl apply and helm read the objects before updating them, and skip the update when the object doesn't change:
+// the objects of the development environment read by the deploy are recorded too, so they are not pruned
+func (r *inventoryRecorder) record(resp *http.Response) error {
+	req := resp.Request
+	if req == nil || req.URL.Query().Get(dryRunQueryParam) != "" {
+		return nil
+	}
+	isRead := req.Method == http.MethodGet
+	if !isRead && !isMutatingRequest(req) {
+		return nil
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil
+	}
+
+	resource, namespace, name, subresource, ok := parseResourcePath(req.URL.Path)
+	if !ok || subresource != "" || (isRead && name == "") {
+		return nil
+	}
+
+	if req.Method == http.MethodDelete {
+		if name == "" {
+			return nil
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.items, inventoryKey(pipeline.InventoryItem{Group: resource.Group, Resource: resource.Resource, Namespace: namespace, Name: name}))
+		return nil
+	}
+
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(body); err != nil {
+		oktetoLog.Infof("inventory: could not decode response for %s %s: %s", req.Method, req.URL.Path, err)
+		return nil
+	}
+	if u.GetName() == "" || isHelmReleaseStorage(u) {
+		return nil
+	}
+	if isRead && (r.name == "" || u.GetLabels()[model.DeployedByLabel] != r.name) {
+		return nil
+	}
+
+	item := pipeline.InventoryItem{
+		Group:     resource.Group,
+		Version:   resource.Version,
+		Kind:      u.GetKind(),
+		Resource:  resource.Resource,
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[inventoryKey(item)] = item
+	return nil
+}
+
+// isHelmReleaseStorage returns true for the secrets and configmaps where helm stores the revisions of a release.
+// They are managed by helm, and pruning them would truncate the history of the release and break 'helm rollback'
+func isHelmReleaseStorage(u *unstructured.Unstructured) bool {
+	if u.GetKind() != "Secret" && u.GetKind() != "ConfigMap" {
+		return false
+	}
+	if u.GetLabels()[helmOwnerLabel] == helmOwner {
+		return true
+	}
+	secretType, _, _ := unstructured.NestedString(u.Object, "type")
+	return secretType == helmReleaseSecretType
+}
+
+// getInventory returns the recorded objects sorted by resource, namespace and name
+func (r *inventoryRecorder) getInventory() []pipeline.InventoryItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]pipeline.InventoryItem, 0, len(r.items))
+	for _, item := range r.items {
+		result = append(result, item)
+	}
+	sortInventory(result)
+	return result
+}
+
+func sortInventory(inventory []pipeline.InventoryItem) {
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventoryKey(inventory[i]) < inventoryKey(inventory[j])
+	})
+}
+
+// mergeInventory returns the objects in any of the inventories
+func mergeInventory(previous, current []pipeline.InventoryItem) []pipeline.InventoryItem {
+	items := map[string]pipeline.InventoryItem{}
+	for _, item := range previous {
+		items[inventoryKey(item)] = item
+	}
+	for _, item := range current {
+		items[inventoryKey(item)] = item
+	}
+	result := make([]pipeline.InventoryItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, item)
+	}
+	sortInventory(result)
+	return result
+}
+
+// getStaleInventory returns the objects of the previous inventory that were not applied by the current deploy
+func getStaleInventory(previous, current []pipeline.InventoryItem) []pipeline.InventoryItem {
+	applied := map[string]bool{}
+	for _, item := range current {
+		applied[inventoryKey(item)] = true
+	}
+	var result []pipeline.InventoryItem
+	for _, item := range previous {
+		if !applied[inventoryKey(item)] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// pruneInventory deletes the stale objects that are still labeled as deployed by the development environment
+func pruneInventory(ctx context.Context, c dynamic.Interface, name string, stale []pipeline.InventoryItem) error {
+	for _, item := range stale {
+		gvr := schema.GroupVersionResource{Group: item.Group, Version: item.Version, Resource: item.Resource}
+		var client dynamic.ResourceInterface = c.Resource(gvr)
+		if item.Namespace != "" {
+			client = c.Resource(gvr).Namespace(item.Namespace)
+		}
+
+		obj, err := client.Get(ctx, item.Name, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("could not get %s '%s': %w", item.Kind, item.Name, err)
+		}
+		if isHelmReleaseStorage(obj) {
+			oktetoLog.Debugf("skipping prune of %s '%s' because it is managed by helm", item.Kind, item.Name)
+			continue
+		}
+		if obj.GetLabels()[model.DeployedByLabel] != name {
+			oktetoLog.Debugf("skipping prune of %s '%s' because it is not deployed by '%s'", item.Kind, item.Name, name)
+			continue
+		}
+		if obj.GetAnnotations()[resourcePolicyAnnotation] == keepPolicy {
+			oktetoLog.Debugf("skipping prune of %s '%s' because of policy annotation", item.Kind, item.Name)
+			continue
+		}
+
+		deletePropagation := metav1.DeletePropagationBackground
+		if err := client.Delete(ctx, item.Name, metav1.DeleteOptions{PropagationPolicy: &deletePropagation}); err != nil && !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("could not prune %s '%s': %w", item.Kind, item.Name, err)
+		}
+		oktetoLog.Information("Pruned %s '%s'", item.Kind, item.Name)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+func newInventoryResponse(t *testing.T, method, path string, status int, body string) *http.Response {
+	u, err := url.Parse(path)
+	require.NoError(t, err)
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: method, URL: u},
+	}
+}
+
+func Test_inventoryRecorder(t *testing.T) {
+	recorder := newInventoryRecorder("movies")
+
+	responses := []*http.Response{
+		newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/services", http.StatusCreated,
+			`{"apiVersion":"v1","kind":"Service","metadata":{"name":"api","namespace":"test"}}`),
+		newInventoryResponse(t, http.MethodPut, "/apis/apps/v1/namespaces/test/deployments/api", http.StatusOK,
+			`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"api","namespace":"test"}}`),
+		newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/configmaps", http.StatusCreated,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"old","namespace":"test"}}`),
+		// deleted objects are removed from the inventory
+		newInventoryResponse(t, http.MethodDelete, "/api/v1/namespaces/test/configmaps/old", http.StatusOK, `{"kind":"Status"}`),
+		// subresources, failed requests and reads are ignored
+		newInventoryResponse(t, http.MethodPut, "/apis/apps/v1/namespaces/test/deployments/api/scale", http.StatusOK,
+			`{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"api","namespace":"test"}}`),
+		newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/secrets", http.StatusConflict,
+			`{"kind":"Status"}`),
+		newInventoryResponse(t, http.MethodGet, "/api/v1/namespaces/test/secrets/token", http.StatusOK,
+			`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"token","namespace":"test"}}`),
+		newInventoryResponse(t, http.MethodGet, "/api/v1/namespaces/test/secrets/other", http.StatusOK,
+			`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"other","namespace":"test","labels":{"dev.okteto.com/deployed-by":"other"}}}`),
+		newInventoryResponse(t, http.MethodGet, "/api/v1/namespaces/test/secrets", http.StatusOK,
+			`{"apiVersion":"v1","kind":"SecretList","metadata":{},"items":[]}`),
+		// reads of objects deployed by the development environment are recorded
+		newInventoryResponse(t, http.MethodGet, "/api/v1/namespaces/test/secrets/unchanged", http.StatusOK,
+			`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"unchanged","namespace":"test","labels":{"dev.okteto.com/deployed-by":"movies"}}}`),
+		// helm release storage is managed by helm
+		newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/secrets", http.StatusCreated,
+			`{"apiVersion":"v1","kind":"Secret","type":"helm.sh/release.v1","metadata":{"name":"sh.helm.release.v1.movies.v1","namespace":"test"}}`),
+		newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/configmaps", http.StatusCreated,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"movies.v1","namespace":"test","labels":{"owner":"helm"}}}`),
+	}
+	for _, resp := range responses {
+		require.NoError(t, recorder.record(resp))
+	}
+
+	// dry-run requests are ignored
+	resp := newInventoryResponse(t, http.MethodPost, "/api/v1/namespaces/test/secrets", http.StatusCreated,
+		`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"dry","namespace":"test"}}`)
+	setDryRun(resp.Request)
+	require.NoError(t, recorder.record(resp))
+
+	expected := []pipeline.InventoryItem{
+		{Version: "v1", Kind: "Secret", Resource: "secrets", Namespace: "test", Name: "unchanged"},
+		{Version: "v1", Kind: "Service", Resource: "services", Namespace: "test", Name: "api"},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "test", Name: "api"},
+	}
+	assert.Equal(t, expected, recorder.getInventory())
+}
+
+func Test_getStaleInventory(t *testing.T) {
+	svc := pipeline.InventoryItem{Version: "v1", Kind: "Service", Resource: "services", Namespace: "test", Name: "api"}
+	cm := pipeline.InventoryItem{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: "cfg"}
+	deployment := pipeline.InventoryItem{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: "test", Name: "api"}
+
+	tests := []struct {
+		name     string
+		previous []pipeline.InventoryItem
+		current  []pipeline.InventoryItem
+		expected []pipeline.InventoryItem
+	}{
+		{
+			name:    "no previous inventory",
+			current: []pipeline.InventoryItem{svc},
+		},
+		{
+			name:     "same objects",
+			previous: []pipeline.InventoryItem{svc, deployment},
+			current:  []pipeline.InventoryItem{deployment, svc},
+		},
+		{
+			name:     "objects not applied anymore",
+			previous: []pipeline.InventoryItem{svc, cm, deployment},
+			current:  []pipeline.InventoryItem{deployment},
+			expected: []pipeline.InventoryItem{svc, cm},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getStaleInventory(tt.previous, tt.current))
+		})
+	}
+}
+
+func Test_mergeInventory(t *testing.T) {
+	svc := pipeline.InventoryItem{Version: "v1", Kind: "Service", Resource: "services", Namespace: "test", Name: "api"}
+	cm := pipeline.InventoryItem{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: "cfg"}
+
+	result := mergeInventory([]pipeline.InventoryItem{svc, cm}, []pipeline.InventoryItem{svc})
+	assert.Equal(t, []pipeline.InventoryItem{cm, svc}, result)
+}
+
+func newLabeledConfigMap(name string, labels map[string]interface{}, annotations map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"name":      name,
+		"namespace": "test",
+		"labels":    labels,
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata,
+		},
+	}
+}
+
+func Test_pruneInventory(t *testing.T) {
+	ctx := context.Background()
+	c := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newLabeledConfigMap("stale", map[string]interface{}{model.DeployedByLabel: "movies"}, nil),
+		newLabeledConfigMap("other", map[string]interface{}{model.DeployedByLabel: "other"}, nil),
+		newLabeledConfigMap("keep", map[string]interface{}{model.DeployedByLabel: "movies"}, map[string]interface{}{resourcePolicyAnnotation: keepPolicy}),
+		newLabeledConfigMap("helm-release", map[string]interface{}{model.DeployedByLabel: "movies", helmOwnerLabel: helmOwner}, nil),
+	)
+
+	stale := []pipeline.InventoryItem{}
+	for _, name := range []string{"stale", "other", "keep", "helm-release", "not-found"} {
+		stale = append(stale, pipeline.InventoryItem{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: name})
+	}
+	require.NoError(t, pruneInventory(ctx, c, "movies", stale))
+
+	client := c.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test")
+	_, err := client.Get(ctx, "stale", metav1.GetOptions{})
+	assert.True(t, k8sErrors.IsNotFound(err))
+	_, err = client.Get(ctx, "other", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = client.Get(ctx, "keep", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = client.Get(ctx, "helm-release", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func Test_pruneUnchangedRedeploy(t *testing.T) {
+	ctx := context.Background()
+	labels := map[string]interface{}{model.DeployedByLabel: "movies"}
+	c := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newLabeledConfigMap("cfg", labels, nil),
+		newLabeledConfigMap("settings", labels, nil),
+	)
+	previous := []pipeline.InventoryItem{
+		{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: "cfg"},
+		{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: "settings"},
+	}
+
+	// kubectl apply reads every object of the manifest and skips the patch because nothing changed
+	recorder := newInventoryRecorder("movies")
+	for _, name := range []string{"cfg", "settings"} {
+		resp := newInventoryResponse(t, http.MethodGet, "/api/v1/namespaces/test/configmaps/"+name, http.StatusOK,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"`+name+`","namespace":"test","labels":{"dev.okteto.com/deployed-by":"movies"}}}`)
+		require.NoError(t, recorder.record(resp))
+	}
+
+	current := recorder.getInventory()
+	assert.Equal(t, previous, current)
+	stale := getStaleInventory(previous, current)
+	assert.Empty(t, stale)
+	require.NoError(t, pruneInventory(ctx, c, "movies", stale))
+
+	client := c.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("test")
+	for _, name := range []string{"cfg", "settings"} {
+		_, err := client.Get(ctx, name, metav1.GetOptions{})
+		assert.NoError(t, err)
+	}
+}
+
+func Test_isHelmReleaseStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      map[string]interface{}
+		expected bool
+	}{
+		{
+			name: "helm release secret",
+			obj: map[string]interface{}{
+				"kind":     "Secret",
+				"type":     helmReleaseSecretType,
+				"metadata": map[string]interface{}{"name": "sh.helm.release.v1.movies.v1"},
+			},
+			expected: true,
+		},
+		{
+			name: "helm release configmap",
+			obj: map[string]interface{}{
+				"kind":     "ConfigMap",
+				"metadata": map[string]interface{}{"name": "movies.v1", "labels": map[string]interface{}{"owner": "helm"}},
+			},
+			expected: true,
+		},
+		{
+			name: "opaque secret",
+			obj: map[string]interface{}{
+				"kind":     "Secret",
+				"type":     "Opaque",
+				"metadata": map[string]interface{}{"name": "token"},
+			},
+			expected: false,
+		},
+		{
+			name: "deployment with the owner label",
+			obj: map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "api", "labels": map[string]interface{}{"owner": "helm"}},
+			},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isHelmReleaseStorage(&unstructured.Unstructured{Object: tt.obj}))
+		})
+	}
+}
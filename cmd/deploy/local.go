@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/compose-spec/godotenv"
 	stackCMD "github.com/okteto/okteto/cmd/stack"
@@ -35,6 +36,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/afero"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 )
 
@@ -48,6 +50,8 @@ type localDeployer struct {
 	Fs                 afero.Fs
 	DivertDriver       divert.Driver
 	GetExternalControl func(cfg *rest.Config) ExternalResourceInterface
+	dryRunRecorder     *dryRunRecorder
+	inventoryRecorder  *inventoryRecorder
 	TempKubeconfigFile string
 	isRemote           bool
 }
@@ -125,6 +129,14 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 		ld.DivertDriver = driver
 	}
 
+	if deployOptions.DryRun {
+		ld.dryRunRecorder = newDryRunRecorder()
+		ld.Proxy.SetDryRun(ld.dryRunRecorder)
+	} else {
+		ld.inventoryRecorder = newInventoryRecorder(format.ResourceK8sMetaString(deployOptions.Name))
+		ld.Proxy.SetInventory(ld.inventoryRecorder)
+	}
+
 	os.Setenv(constants.OktetoNameEnvVar, deployOptions.Name)
 
 	if err := setDeployOptionsValuesFromManifest(ctx, deployOptions, cwd, c); err != nil {
@@ -174,6 +186,9 @@ func (ld *localDeployer) deploy(ctx context.Context, deployOptions *Options) err
 	}
 	oktetoLog.EnableMasking()
 	err = ld.runDeploySection(ctx, deployOptions)
+	if err == nil && !deployOptions.DryRun {
+		err = ld.updateInventory(ctx, deployOptions)
+	}
 	oktetoLog.DisableMasking()
 	oktetoLog.SetStage("done")
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "EOF")
@@ -192,36 +207,14 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 		}
 	}()
 
-	var envMapFromOktetoEnvFile map[string]string
 	// deploy commands if any
-	for _, command := range opts.Manifest.Deploy.Commands {
-		oktetoLog.Information("Running '%s'", command.Name)
-		oktetoLog.SetStage(command.Name)
-		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Executing command '%s'...", command.Name)
-
-		if err := ld.Executor.Execute(command, opts.Variables); err != nil {
-			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error executing command '%s': %s", command.Name, err.Error())
-			return fmt.Errorf("error executing command '%s': %s", command.Name, err.Error())
-		}
-		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Command '%s' successfully executed", command.Name)
-
-		envMapFromOktetoEnvFile, err = godotenv.Read(oktetoEnvFile.Name())
-		if err != nil {
-			oktetoLog.Warning("no valid format used in the okteto env file: %s", err.Error())
-		}
-
-		envsFromOktetoEnvFile := make([]string, 0, len(envMapFromOktetoEnvFile))
-		for k, v := range envMapFromOktetoEnvFile {
-			envsFromOktetoEnvFile = append(envsFromOktetoEnvFile, fmt.Sprintf("%s=%s", k, v))
-		}
+	envMapFromOktetoEnvFile, err := ld.runDeployCommands(opts, oktetoEnvFile.Name())
+	if err != nil {
+		return err
+	}
 
-		// the variables in the $OKTETO_ENV file are added as environment variables
-		// to the executor. If there is already a previously set value for that
-		// variable, the executor will use in next command the last one added which
-		// corresponds to those coming from $OKTETO_ENV.
-		opts.Variables = append(opts.Variables, envsFromOktetoEnvFile...)
-		oktetoLog.SetStage("")
-		oktetoLog.SetLevel("")
+	if opts.DryRun {
+		return ld.showDryRunResult(ctx, opts)
 	}
 
 	err = ld.ConfigMapHandler.updateEnvsFromCommands(ctx, opts.Name, opts.Manifest.Namespace, opts.Variables)
@@ -276,6 +269,191 @@ func (ld *localDeployer) runDeploySection(ctx context.Context, opts *Options) er
 	return nil
 }
 
+// runDeployCommands runs the deploy commands following the dependencies between them.
+// Every command starts as soon as the commands it depends on have finished, so independent commands run at the same time
+func (ld *localDeployer) runDeployCommands(opts *Options, oktetoEnvFilePath string) (map[string]string, error) {
+	commands := opts.Manifest.Deploy.Commands
+	dependencies := opts.Manifest.Deploy.GetCommandDependencies()
+
+	var (
+		mu                      sync.Mutex
+		firstErr                error
+		envMapFromOktetoEnvFile map[string]string
+		running                 int
+	)
+	started := make([]bool, len(commands))
+	isRunning := make([]bool, len(commands))
+	finished := make([]bool, len(commands))
+	done := make(chan struct{}, len(commands))
+
+	isReady := func(i int) bool {
+		for _, dependency := range dependencies[i] {
+			if !finished[dependency] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// the stage is the name of the command only while it runs alone. When several commands run at the same time,
+	// the executor labels every output line with the name of its command instead
+	updateStage := func() {
+		stage := ""
+		if running == 1 {
+			for i, command := range commands {
+				if isRunning[i] {
+					stage = command.Name
+				}
+			}
+		}
+		oktetoLog.SetStage(stage)
+	}
+
+	run := func(i int, variables []string) {
+		command := commands[i]
+		err := ld.Executor.Execute(command, variables)
+
+		mu.Lock()
+		defer func() {
+			mu.Unlock()
+			done <- struct{}{}
+		}()
+		running--
+		isRunning[i] = false
+		updateStage()
+		if err != nil {
+			oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error executing command '%s': %s", command.Name, err.Error())
+			if firstErr == nil {
+				firstErr = fmt.Errorf("error executing command '%s': %s", command.Name, err.Error())
+			}
+			return
+		}
+		oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Command '%s' successfully executed", command.Name)
+
+		envMapFromOktetoEnvFile, err = godotenv.Read(oktetoEnvFilePath)
+		if err != nil {
+			oktetoLog.Warning("no valid format used in the okteto env file: %s", err.Error())
+		}
+
+		envsFromOktetoEnvFile := make([]string, 0, len(envMapFromOktetoEnvFile))
+		for k, v := range envMapFromOktetoEnvFile {
+			envsFromOktetoEnvFile = append(envsFromOktetoEnvFile, fmt.Sprintf("%s=%s", k, v))
+		}
+
+		// the variables in the $OKTETO_ENV file are added as environment variables
+		// to the executor. If there is already a previously set value for that
+		// variable, the executor will use in next command the last one added which
+		// corresponds to those coming from $OKTETO_ENV.
+		opts.Variables = append(opts.Variables, envsFromOktetoEnvFile...)
+		finished[i] = true
+		if running == 0 {
+			oktetoLog.SetLevel("")
+		}
+	}
+
+	for {
+		mu.Lock()
+		if firstErr == nil {
+			for i, command := range commands {
+				if started[i] || !isReady(i) {
+					continue
+				}
+				started[i] = true
+				isRunning[i] = true
+				running++
+				updateStage()
+				oktetoLog.Information("Running '%s'", command.Name)
+				oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Executing command '%s'...", command.Name)
+
+				variables := make([]string, len(opts.Variables))
+				copy(variables, opts.Variables)
+				go run(i, variables)
+			}
+		}
+		if running == 0 {
+			mu.Unlock()
+			break
+		}
+		mu.Unlock()
+		<-done
+	}
+
+	return envMapFromOktetoEnvFile, firstErr
+}
+
+// showDryRunResult prints the objects recorded by the proxy. The sections that are not applied by the deploy commands
+// are not supported in dry-run mode
+func (ld *localDeployer) showDryRunResult(ctx context.Context, opts *Options) error {
+	if opts.Manifest.Deploy.ComposeSection != nil {
+		oktetoLog.Warning("The 'compose' section is not deployed in dry-run mode")
+	}
+	if opts.Manifest.Deploy.Endpoints != nil {
+		oktetoLog.Warning("The 'endpoints' section is not deployed in dry-run mode")
+	}
+	if opts.Manifest.Deploy.Divert != nil {
+		oktetoLog.Warning("The 'divert' section is not deployed in dry-run mode")
+	}
+	if len(opts.Manifest.External) > 0 {
+		oktetoLog.Warning("The 'external' section is not deployed in dry-run mode")
+	}
+
+	oktetoLog.SetStage("Dry run")
+	defer oktetoLog.SetStage("")
+	if len(ld.dryRunRecorder.getObjects()) == 0 {
+		oktetoLog.Information("Dry run: the deploy commands don't modify any Kubernetes object")
+		return nil
+	}
+
+	_, cfg, err := ld.K8sClientProvider.Provide(okteto.Context().Cfg)
+	if err != nil {
+		return err
+	}
+	dc, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error getting kubernetes dynamic client: %w", err)
+	}
+	return showDryRunReport(ctx, ld.dryRunRecorder, dc, os.Stdout)
+}
+
+// updateInventory stores the objects applied by the deploy in the config map. When '--prune' is set,
+// the objects applied by the previous deploy that were not applied this time are deleted
+func (ld *localDeployer) updateInventory(ctx context.Context, opts *Options) error {
+	previous, err := ld.ConfigMapHandler.getInventory(ctx, opts.Name, opts.Manifest.Namespace)
+	if err != nil {
+		return fmt.Errorf("could not get the inventory of '%s': %w", opts.Name, err)
+	}
+
+	current := ld.inventoryRecorder.getInventory()
+	if len(opts.servicesToDeploy) > 0 {
+		// only a subset of the services was deployed, the objects of the rest of services are still deployed
+		current = mergeInventory(previous, current)
+	}
+
+	if opts.Prune {
+		if stale := getStaleInventory(previous, current); len(stale) > 0 {
+			oktetoLog.SetStage("Prune")
+			_, cfg, err := ld.K8sClientProvider.Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+			dc, err := dynamic.NewForConfig(cfg)
+			if err != nil {
+				return fmt.Errorf("error getting kubernetes dynamic client: %w", err)
+			}
+			if err := pruneInventory(ctx, dc, format.ResourceK8sMetaString(opts.Name), stale); err != nil {
+				oktetoLog.AddToBuffer(oktetoLog.ErrorLevel, "error pruning objects: %s", err.Error())
+				return err
+			}
+			oktetoLog.SetStage("")
+		}
+	}
+
+	if err := ld.ConfigMapHandler.updateInventory(ctx, opts.Name, opts.Manifest.Namespace, current); err != nil {
+		return fmt.Errorf("could not update config map with the inventory: %w", err)
+	}
+	return nil
+}
+
 func (ld *localDeployer) deployStack(ctx context.Context, opts *Options) error {
 	composeSectionInfo := opts.Manifest.Deploy.ComposeSection
 	composeSectionInfo.Stack.Namespace = okteto.Context().Namespace
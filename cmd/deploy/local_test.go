@@ -15,9 +15,13 @@ package deploy
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/spf13/afero"
@@ -134,3 +138,168 @@ func Test_newLocalDeployer(t *testing.T) {
 		})
 	}
 }
+
+// orderedFakeExecutor checks that the dependencies of a command have finished when it starts.
+// The lock is released while the command runs, so independent commands run at the same time
+type orderedFakeExecutor struct {
+	err           map[string]error
+	finished      map[string]bool
+	started       []string
+	running       int
+	maxConcurrent int
+	mu            sync.Mutex
+}
+
+func (fe *orderedFakeExecutor) Execute(command model.DeployCommand, _ []string) error {
+	fe.mu.Lock()
+	for _, dependency := range command.DependsOn {
+		if !fe.finished[dependency] {
+			fe.mu.Unlock()
+			return fmt.Errorf("'%s' started before '%s' finished", command.Name, dependency)
+		}
+	}
+	fe.started = append(fe.started, command.Name)
+	fe.running++
+	if fe.running > fe.maxConcurrent {
+		fe.maxConcurrent = fe.running
+	}
+	fe.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	fe.running--
+	fe.finished[command.Name] = true
+	return fe.err[command.Name]
+}
+
+func (*orderedFakeExecutor) CleanUp(_ error) {}
+
+func TestRunDeployCommands(t *testing.T) {
+	tests := []struct {
+		err                   map[string]error
+		name                  string
+		commands              []model.DeployCommand
+		expectedStarted       []string
+		expectedMaxConcurrent int
+		expectedErr           bool
+	}{
+		{
+			name: "sequential commands",
+			commands: []model.DeployCommand{
+				{Name: "a", Command: "a"},
+				{Name: "b", Command: "b"},
+				{Name: "c", Command: "c"},
+			},
+			expectedStarted:       []string{"a", "b", "c"},
+			expectedMaxConcurrent: 1,
+		},
+		{
+			name: "dependencies are respected",
+			commands: []model.DeployCommand{
+				{Name: "c", Command: "c", DependsOn: []string{"a", "b"}},
+				{Name: "a", Command: "a", Parallel: true},
+				{Name: "b", Command: "b", DependsOn: []string{"a"}},
+			},
+			expectedStarted:       []string{"a", "b", "c"},
+			expectedMaxConcurrent: 1,
+		},
+		{
+			name: "independent commands run at the same time",
+			commands: []model.DeployCommand{
+				{Name: "a", Command: "a", Parallel: true},
+				{Name: "b", Command: "b", Parallel: true},
+				{Name: "c", Command: "c", Parallel: true},
+				{Name: "d", Command: "d", DependsOn: []string{"a", "b", "c"}},
+			},
+			expectedStarted:       []string{"a", "b", "c", "d"},
+			expectedMaxConcurrent: 3,
+		},
+		{
+			name: "error stops scheduling new commands",
+			commands: []model.DeployCommand{
+				{Name: "a", Command: "a"},
+				{Name: "b", Command: "b"},
+			},
+			err: map[string]error{
+				"a": assert.AnError,
+			},
+			expectedStarted:       []string{"a"},
+			expectedMaxConcurrent: 1,
+			expectedErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			envFile, err := fs.Create("okteto.env")
+			require.NoError(t, err)
+
+			executor := &orderedFakeExecutor{
+				err:      tt.err,
+				finished: map[string]bool{},
+			}
+			ld := localDeployer{
+				Executor: executor,
+				Fs:       fs,
+			}
+			opts := &Options{
+				Manifest: &model.Manifest{
+					Deploy: &model.DeployInfo{
+						Commands: tt.commands,
+					},
+				},
+			}
+			_, err = ld.runDeployCommands(opts, envFile.Name())
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.ElementsMatch(t, tt.expectedStarted, executor.started)
+			require.Equal(t, tt.expectedMaxConcurrent, executor.maxConcurrent)
+		})
+	}
+}
+
+func TestUpdateInventory(t *testing.T) {
+	svc := pipeline.InventoryItem{Version: "v1", Kind: "Service", Resource: "services", Namespace: "test", Name: "api"}
+	cm := pipeline.InventoryItem{Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Namespace: "test", Name: "cfg"}
+
+	tests := []struct {
+		name             string
+		servicesToDeploy []string
+		expected         []pipeline.InventoryItem
+	}{
+		{
+			name:     "all services",
+			expected: []pipeline.InventoryItem{svc},
+		},
+		{
+			name:             "subset of services keeps the previous inventory",
+			servicesToDeploy: []string{"api"},
+			expected:         []pipeline.InventoryItem{cm, svc},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmapHandler := &fakeCmapHandler{inventory: []pipeline.InventoryItem{cm}}
+			ld := localDeployer{
+				ConfigMapHandler:  cmapHandler,
+				inventoryRecorder: newInventoryRecorder("movies"),
+			}
+			ld.inventoryRecorder.items[inventoryKey(svc)] = svc
+
+			opts := &Options{
+				Name:             "movies",
+				Manifest:         &model.Manifest{Namespace: "test"},
+				servicesToDeploy: tt.servicesToDeploy,
+			}
+			require.NoError(t, ld.updateInventory(context.Background(), opts))
+			assert.True(t, cmapHandler.inventoryUpdated)
+			assert.Equal(t, tt.expected, cmapHandler.inventory)
+		})
+	}
+}
@@ -43,6 +43,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 type proxyInterface interface {
@@ -52,6 +53,8 @@ type proxyInterface interface {
 	GetToken() string
 	SetName(name string)
 	SetDivert(driver divert.Driver)
+	SetDryRun(recorder *dryRunRecorder)
+	SetInventory(recorder *inventoryRecorder)
 }
 
 type proxyConfig struct {
@@ -68,6 +71,10 @@ type Proxy struct {
 
 type proxyHandler struct {
 	DivertDriver divert.Driver
+	// DryRunRecorder is set when the mutating requests must be sent with dryRun=All
+	DryRunRecorder *dryRunRecorder
+	// InventoryRecorder keeps the objects applied through the proxy
+	InventoryRecorder *inventoryRecorder
 	// Name is sanitized version of the pipeline name
 	Name string
 }
@@ -187,6 +194,16 @@ func (p *Proxy) SetDivert(driver divert.Driver) {
 	p.proxyHandler.SetDivert(driver)
 }
 
+// SetDryRun forwards every mutating request with dryRun=All and stores the resulting objects in the recorder
+func (p *Proxy) SetDryRun(recorder *dryRunRecorder) {
+	p.proxyHandler.SetDryRun(recorder)
+}
+
+// SetInventory stores in the recorder every object applied through the proxy
+func (p *Proxy) SetInventory(recorder *inventoryRecorder) {
+	p.proxyHandler.SetInventory(recorder)
+}
+
 func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config) (http.Handler, error) {
 	// By default we don't disable HTTP/2
 	trans, err := newProtocolTransport(clusterConfig, false)
@@ -203,6 +220,7 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 	}
 	proxy := httputil.NewSingleHostReverseProxy(destinationURL)
 	proxy.Transport = trans
+	proxy.ModifyResponse = ph.recordResponse
 
 	oktetoLog.Debugf("forwarding host: %s", clusterConfig.Host)
 
@@ -238,6 +256,15 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 		}
 
 		r.Host = destinationURL.Host
+		if ph.DryRunRecorder != nil {
+			if err := checkDryRunRequest(r); err != nil {
+				rejectDryRunRequest(rw, err)
+				return
+			}
+			if supportsDryRun(r) {
+				setDryRun(r)
+			}
+		}
 		// Modify all resources updated or created to include the label.
 		if r.Method == "PUT" || r.Method == "POST" {
 			b, err := io.ReadAll(r.Body)
@@ -280,6 +307,26 @@ func (ph *proxyHandler) SetDivert(driver divert.Driver) {
 	ph.DivertDriver = driver
 }
 
+func (ph *proxyHandler) SetDryRun(recorder *dryRunRecorder) {
+	ph.DryRunRecorder = recorder
+}
+
+func (ph *proxyHandler) SetInventory(recorder *inventoryRecorder) {
+	ph.InventoryRecorder = recorder
+}
+
+func (ph *proxyHandler) recordResponse(resp *http.Response) error {
+	if ph.DryRunRecorder != nil {
+		if err := ph.DryRunRecorder.record(resp); err != nil {
+			return err
+		}
+	}
+	if ph.InventoryRecorder != nil {
+		return ph.InventoryRecorder.record(resp)
+	}
+	return nil
+}
+
 func (ph *proxyHandler) translateBody(b []byte) ([]byte, error) {
 	var body map[string]json.RawMessage
 	if err := json.Unmarshal(b, &body); err != nil {
@@ -330,6 +377,10 @@ func (ph *proxyHandler) translateBody(b []byte) ([]byte, error) {
 		if err := ph.translateVirtualServiceSpec(body); err != nil {
 			return nil, err
 		}
+	case "HTTPRoute":
+		if err := ph.translateHTTPRouteSpec(body); err != nil {
+			return nil, err
+		}
 	}
 
 	return json.Marshal(body)
@@ -504,6 +555,25 @@ func (ph *proxyHandler) translateVirtualServiceSpec(body map[string]json.RawMess
 	return nil
 }
 
+func (ph *proxyHandler) translateHTTPRouteSpec(body map[string]json.RawMessage) error {
+	if ph.DivertDriver == nil {
+		return nil
+	}
+
+	var spec *gatewayV1beta1.HTTPRouteSpec
+	if err := json.Unmarshal(body["spec"], &spec); err != nil {
+		oktetoLog.Infof("error unmarshalling http route on proxy: %s", err.Error())
+		return nil
+	}
+	ph.DivertDriver.UpdateHTTPRoute(spec)
+	specAsByte, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("could not process http route's spec: %s", err)
+	}
+	body["spec"] = specAsByte
+	return nil
+}
+
 func newProtocolTransport(clusterConfig *rest.Config, disableHTTP2 bool) (http.RoundTripper, error) {
 	copiedConfig := &rest.Config{}
 	*copiedConfig = *clusterConfig
@@ -0,0 +1,194 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"k8s.io/client-go/kubernetes"
+)
+
+// rollbackPreviousRevision is the value of the --rollback flag when no revision is given
+const rollbackPreviousRevision = "previous"
+
+var (
+	errRollbackNotSupportedInRemote = errors.New("rollback is not supported when the deploy commands run in remote")
+	errRollbackWithDryRun           = errors.New("--rollback can't be used with --dry-run")
+	errRollbackWithBuild            = errors.New("--rollback can't be used with --build: the images of the revision are deployed")
+	errRollbackWithServices         = errors.New("--rollback can't be used when deploying a subset of services")
+	errNoRevisionToRollback         = errors.New("there isn't a previous successful deploy to roll back to")
+)
+
+// validateRollback checks that the deploy can be run with the input recorded in a revision
+func validateRollback(deployOptions *Options) error {
+	if deployOptions.DryRun {
+		return errRollbackWithDryRun
+	}
+	if deployOptions.Build {
+		return errRollbackWithBuild
+	}
+	if len(deployOptions.servicesToDeploy) > 0 {
+		return errRollbackWithServices
+	}
+	if _, err := parseRollbackRevision(deployOptions.Rollback); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseRollbackRevision returns the revision number of the --rollback flag, or 0 for the previous successful revision
+func parseRollbackRevision(rollback string) (int, error) {
+	if rollback == rollbackPreviousRevision {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(rollback)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid revision '%s': it must be a positive number", rollback)
+	}
+	return number, nil
+}
+
+// getRollbackRevision returns the revision of the history to roll back to.
+// When no revision number is given, it is the last successful revision before the current one
+func getRollbackRevision(history []pipeline.Revision, rollback string) (*pipeline.Revision, error) {
+	number, err := parseRollbackRevision(rollback)
+	if err != nil {
+		return nil, err
+	}
+
+	if number == 0 {
+		for i := len(history) - 2; i >= 0; i-- {
+			if history[i].Status == pipeline.DeployedStatus {
+				return &history[i], nil
+			}
+		}
+		return nil, errNoRevisionToRollback
+	}
+
+	for i := range history {
+		if history[i].Number != number {
+			continue
+		}
+		if history[i].Status != pipeline.DeployedStatus {
+			return nil, fmt.Errorf("revision %d can't be rolled back because it didn't finish successfully", number)
+		}
+		return &history[i], nil
+	}
+	return nil, fmt.Errorf("revision %d not found: run 'okteto deploy history' to list the available revisions", number)
+}
+
+// loadRollbackRevision replaces the manifest, the variables and the images of the deploy by the ones recorded in the revision to roll back to
+func (dc *DeployCommand) loadRollbackRevision(ctx context.Context, deployOptions *Options, cwd string, c kubernetes.Interface) (*pipeline.Revision, error) {
+	history, err := dc.CfgMapHandler.getHistory(ctx, deployOptions.Name, deployOptions.Manifest.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := getRollbackRevision(history, deployOptions.Rollback)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := dc.readRevisionManifest(revision, deployOptions.Manifest.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Namespace = deployOptions.Manifest.Namespace
+	deployOptions.Manifest = manifest
+	if err := setDeployOptionsValuesFromManifest(ctx, deployOptions, cwd, c); err != nil {
+		return nil, err
+	}
+	if shouldRunInRemote(deployOptions) {
+		return nil, errRollbackNotSupportedInRemote
+	}
+
+	deployOptions.Variables = revision.Variables
+	if err := validateAndSet(revision.Variables, os.Setenv); err != nil {
+		return nil, err
+	}
+	for name, value := range revision.Images {
+		os.Setenv(name, value)
+	}
+	if revision.GitCommit != "" {
+		if currentCommit := os.Getenv(constants.OktetoGitCommitEnvVar); currentCommit != revision.GitCommit {
+			oktetoLog.Warning("Revision %d was deployed from commit '%s' but the current commit is '%s'. The files used by the deploy commands are read from your working directory", revision.Number, revision.GitCommit, currentCommit)
+		}
+		os.Setenv(constants.OktetoGitCommitEnvVar, revision.GitCommit)
+	}
+
+	oktetoLog.Information("Rolling back '%s' to revision %d", deployOptions.Name, revision.Number)
+	return revision, nil
+}
+
+// readRevisionManifest loads the manifest stored in the revision from a temporary file. The path of the loaded manifest
+// is set to manifestPath, so the paths it references are resolved from the directory of the current manifest
+func (dc *DeployCommand) readRevisionManifest(revision *pipeline.Revision, manifestPath string) (*model.Manifest, error) {
+	if len(revision.Manifest) == 0 {
+		return nil, fmt.Errorf("revision %d can't be rolled back because its manifest wasn't recorded", revision.Number)
+	}
+
+	f, err := afero.TempFile(dc.Fs, os.TempDir(), "okteto-rollback-*.yml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the manifest of revision %d: %w", revision.Number, err)
+	}
+	defer func() {
+		if err := dc.Fs.Remove(f.Name()); err != nil {
+			oktetoLog.Infof("could not remove %s: %s", f.Name(), err)
+		}
+	}()
+	if _, err := f.Write(revision.Manifest); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write the manifest of revision %d: %w", revision.Number, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write the manifest of revision %d: %w", revision.Number, err)
+	}
+
+	manifest, err := dc.GetManifest(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest of revision %d: %w", revision.Number, err)
+	}
+	manifest.Manifest = revision.Manifest
+	manifest.ManifestPath = manifestPath
+	return manifest, nil
+}
+
+// addRevision records the input of the deploy in the deploy history
+func (dc *DeployCommand) addRevision(ctx context.Context, deployOptions *Options, data *pipeline.CfgData, rollbackRevision *pipeline.Revision) {
+	revision := pipeline.Revision{
+		Date:      time.Now().UTC(),
+		Status:    data.Status,
+		GitCommit: os.Getenv(constants.OktetoGitCommitEnvVar),
+		Branch:    data.Branch,
+		Manifest:  data.Manifest,
+		Variables: data.Variables,
+		Images:    dc.Builder.GetBuildEnvVars(),
+	}
+	if rollbackRevision != nil {
+		revision.Images = rollbackRevision.Images
+		revision.RollbackOf = rollbackRevision.Number
+	}
+	if err := dc.CfgMapHandler.addRevision(ctx, deployOptions.Name, deployOptions.Manifest.Namespace, revision); err != nil {
+		oktetoLog.Infof("could not record the deploy revision: %s", err)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRollbackRevision(t *testing.T) {
+	history := []pipeline.Revision{
+		{Number: 1, Status: pipeline.DeployedStatus},
+		{Number: 2, Status: pipeline.ErrorStatus},
+		{Number: 3, Status: pipeline.DeployedStatus},
+		{Number: 4, Status: pipeline.ErrorStatus},
+	}
+	tests := []struct {
+		expectedErr bool
+		name        string
+		rollback    string
+		history     []pipeline.Revision
+		expected    int
+	}{
+		{
+			name:     "previous after a failed deploy",
+			rollback: rollbackPreviousRevision,
+			history:  history,
+			expected: 3,
+		},
+		{
+			name:     "previous after a successful deploy",
+			rollback: rollbackPreviousRevision,
+			history:  history[:3],
+			expected: 1,
+		},
+		{
+			name:        "previous without successful revisions",
+			rollback:    rollbackPreviousRevision,
+			history:     history[:1],
+			expectedErr: true,
+		},
+		{
+			name:     "specific revision",
+			rollback: "1",
+			history:  history,
+			expected: 1,
+		},
+		{
+			name:        "failed revision",
+			rollback:    "2",
+			history:     history,
+			expectedErr: true,
+		},
+		{
+			name:        "revision not found",
+			rollback:    "7",
+			history:     history,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid revision",
+			rollback:    "last",
+			history:     history,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision, err := getRollbackRevision(tt.history, tt.rollback)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, revision.Number)
+		})
+	}
+}
+
+func TestValidateRollback(t *testing.T) {
+	tests := []struct {
+		expected error
+		opts     *Options
+		name     string
+	}{
+		{
+			name: "valid",
+			opts: &Options{Rollback: "2"},
+		},
+		{
+			name:     "dry-run",
+			opts:     &Options{Rollback: rollbackPreviousRevision, DryRun: true},
+			expected: errRollbackWithDryRun,
+		},
+		{
+			name:     "build",
+			opts:     &Options{Rollback: rollbackPreviousRevision, Build: true},
+			expected: errRollbackWithBuild,
+		},
+		{
+			name:     "services",
+			opts:     &Options{Rollback: rollbackPreviousRevision, servicesToDeploy: []string{"api"}},
+			expected: errRollbackWithServices,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateRollback(tt.opts), tt.expected)
+		})
+	}
+}
+
+func TestDeployRollback(t *testing.T) {
+	t.Setenv("OKTETO_BUILD_API_IMAGE", "")
+	t.Setenv("ROLLBACK_VAR", "")
+	t.Setenv(constants.OktetoGitCommitEnvVar, "current")
+
+	fakeK8sClientProvider := test.NewFakeK8sProvider()
+	fakeDeployer := &fakeDeployer{
+		proxy:             &fakeProxy{},
+		executor:          &fakeExecutor{},
+		kubeconfig:        &fakeKubeConfig{},
+		fs:                afero.NewMemMapFs(),
+		k8sClientProvider: fakeK8sClientProvider,
+	}
+
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	revisionCommands := []model.DeployCommand{{Name: "rollback", Command: "echo rollback"}}
+	cmapHandler := &fakeCmapHandler{
+		history: []pipeline.Revision{
+			{
+				Number:    1,
+				Status:    pipeline.DeployedStatus,
+				Manifest:  []byte("deploy:\n  - echo rollback"),
+				Variables: []string{"ROLLBACK_VAR=value"},
+				Images:    map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api@sha256:123"},
+				GitCommit: "previous",
+			},
+			{
+				Number: 2,
+				Status: pipeline.ErrorStatus,
+			},
+		},
+	}
+	fs := afero.NewMemMapFs()
+	readManifests := []string{}
+	builder := &fakeV2Builder{}
+	c := &DeployCommand{
+		GetManifest: func(path string) (*model.Manifest, error) {
+			readManifests = append(readManifests, path)
+			if path == "" {
+				return getFakeManifest(path)
+			}
+			b, err := afero.ReadFile(fs, path)
+			if err != nil {
+				return nil, err
+			}
+			assert.Equal(t, []byte("deploy:\n  - echo rollback"), b)
+			return &model.Manifest{Deploy: &model.DeployInfo{Commands: revisionCommands}}, nil
+		},
+		K8sClientProvider: fakeK8sClientProvider,
+		CfgMapHandler:     cmapHandler,
+		GetDeployer:       fakeDeployer.Get,
+		Builder:           builder,
+		Fs:                fs,
+		EndpointGetter:    getFakeEndpoint,
+	}
+	opts := &Options{
+		Name:      "movies",
+		Variables: []string{},
+		Rollback:  rollbackPreviousRevision,
+	}
+
+	err := c.RunDeploy(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Len(t, readManifests, 2)
+	assert.Equal(t, revisionCommands, fakeDeployer.executor.executed)
+	assert.Nil(t, builder.buildOptionsStorage)
+	assert.Equal(t, "value", os.Getenv("ROLLBACK_VAR"))
+	assert.Equal(t, "okteto.dev/api@sha256:123", os.Getenv("OKTETO_BUILD_API_IMAGE"))
+	assert.Equal(t, "previous", os.Getenv(constants.OktetoGitCommitEnvVar))
+
+	// the manifest is read from the temporary directory, and the file is removed
+	require.Len(t, readManifests, 2)
+	assert.Equal(t, os.TempDir(), filepath.Dir(readManifests[1]))
+	files, err := afero.ReadDir(fs, os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.Len(t, cmapHandler.history, 3)
+	revision := cmapHandler.history[2]
+	assert.Equal(t, pipeline.DeployedStatus, revision.Status)
+	assert.Equal(t, 1, revision.RollbackOf)
+	assert.Equal(t, []byte("deploy:\n  - echo rollback"), revision.Manifest)
+	assert.Equal(t, []string{"ROLLBACK_VAR=value"}, revision.Variables)
+	assert.Equal(t, map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api@sha256:123"}, revision.Images)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/registry"
+)
+
+var (
+	errNoImagesToVerify = oktetoErrors.UserError{
+		E:    errors.New("there are no built images to verify"),
+		Hint: "'--verify-images' verifies the images of the 'build' section of your okteto manifest. Remove the flag if your manifest doesn't build images",
+	}
+)
+
+// imageVerifier checks the signature of an image stored in a registry
+type imageVerifier interface {
+	VerifyImage(image string, publicKey crypto.PublicKey) error
+}
+
+// getBuiltImages returns the value of the OKTETO_BUILD_<SVC>_IMAGE variable of every service of the build section.
+// Variables of services that are not part of the manifest are ignored, since they might be set by a parent shell
+func getBuiltImages(build model.ManifestBuild, getenv func(string) string) []string {
+	images := []string{}
+	for svc := range build {
+		if image := getenv(fmt.Sprintf("OKTETO_BUILD_%s_IMAGE", strings.ToUpper(strings.ReplaceAll(svc, "-", "_")))); image != "" {
+			images = append(images, image)
+		} else {
+			oktetoLog.Infof("skipping verification of service '%s': it has no built image", svc)
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+// verifyImages checks the signatures of the built images before running the deploy commands
+func (dc *DeployCommand) verifyImages(deployOptions *Options) error {
+	publicKey, err := registry.GetVerifier(dc.Fs, deployOptions.VerifyKey)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("could not load the verification key: %w", err),
+			Hint: "Use the flag '--verify-key' or set the OKTETO_VERIFY_KEY environment variable with the public key used to sign the images",
+		}
+	}
+
+	images := getBuiltImages(deployOptions.Manifest.Build, os.Getenv)
+	if len(images) == 0 {
+		return errNoImagesToVerify
+	}
+	for _, image := range images {
+		oktetoLog.Infof("verifying signature of image '%s'", image)
+		if err := dc.ImageVerifier.VerifyImage(image, publicKey); err != nil {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the signature of image '%s' could not be verified: %w", image, err),
+				Hint: "Sign your images running 'okteto build --sign' with the private key of the verification key",
+			}
+		}
+	}
+	oktetoLog.Success("Verified the signatures of %d images", len(images))
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImageVerifier struct {
+	err      error
+	verified []string
+}
+
+func (fv *fakeImageVerifier) VerifyImage(image string, _ crypto.PublicKey) error {
+	if fv.err != nil {
+		return fv.err
+	}
+	fv.verified = append(fv.verified, image)
+	return nil
+}
+
+func newTestVerifyKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestGetBuiltImages(t *testing.T) {
+	environ := map[string]string{
+		"OKTETO_BUILD_FRONTEND_IMAGE": "okteto.dev/frontend@sha256:aaa",
+		"OKTETO_BUILD_FRONTEND_TAG":   "sha256:aaa",
+		"OKTETO_BUILD_MY_API_IMAGE":   "okteto.dev/api@sha256:bbb",
+		"OKTETO_BUILD_OTHER_IMAGE":    "okteto.dev/other@sha256:ccc",
+		"MY_IMAGE":                    "nginx",
+	}
+	getenv := func(name string) string {
+		return environ[name]
+	}
+	build := model.ManifestBuild{
+		"frontend": &model.BuildInfo{},
+		"my-api":   &model.BuildInfo{},
+		"worker":   &model.BuildInfo{},
+	}
+	assert.Equal(t, []string{"okteto.dev/api@sha256:bbb", "okteto.dev/frontend@sha256:aaa"}, getBuiltImages(build, getenv))
+	assert.Empty(t, getBuiltImages(nil, getenv))
+}
+
+func TestVerifyImages(t *testing.T) {
+	publicKey := newTestVerifyKey(t)
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "cosign.pub", publicKey, 0600))
+
+	t.Setenv(constants.OktetoVerifyKeyEnvVar, "")
+	t.Setenv("OKTETO_BUILD_API_IMAGE", "okteto.dev/api@sha256:bbb")
+	manifest := &model.Manifest{
+		Build: model.ManifestBuild{
+			"api": &model.BuildInfo{},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		verifier         *fakeImageVerifier
+		manifest         *model.Manifest
+		verifyKey        string
+		envKey           string
+		expectedVerified []string
+		expectedErr      bool
+	}{
+		{
+			name:             "verified with key file",
+			verifier:         &fakeImageVerifier{},
+			manifest:         manifest,
+			verifyKey:        "cosign.pub",
+			expectedVerified: []string{"okteto.dev/api@sha256:bbb"},
+		},
+		{
+			name:             "verified with env var",
+			verifier:         &fakeImageVerifier{},
+			manifest:         manifest,
+			envKey:           string(publicKey),
+			expectedVerified: []string{"okteto.dev/api@sha256:bbb"},
+		},
+		{
+			name:        "no key",
+			verifier:    &fakeImageVerifier{},
+			manifest:    manifest,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid signature",
+			verifier:    &fakeImageVerifier{err: errors.New("invalid signature")},
+			manifest:    manifest,
+			verifyKey:   "cosign.pub",
+			expectedErr: true,
+		},
+		{
+			name:        "no images to verify",
+			verifier:    &fakeImageVerifier{},
+			manifest:    &model.Manifest{Build: model.ManifestBuild{"worker": &model.BuildInfo{}}},
+			verifyKey:   "cosign.pub",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.OktetoVerifyKeyEnvVar, tt.envKey)
+			dc := &DeployCommand{
+				Fs:            fs,
+				ImageVerifier: tt.verifier,
+			}
+			err := dc.verifyImages(&Options{VerifyKey: tt.verifyKey, Manifest: tt.manifest})
+			if tt.expectedErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVerified, tt.verifier.verified)
+		})
+	}
+}
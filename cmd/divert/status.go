@@ -0,0 +1,175 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/divert/status"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+var errNoDivert = errors.New("the okteto manifest doesn't have a 'divert' section")
+
+// StatusOptions defines the options of the divert status command
+type StatusOptions struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Output       string
+}
+
+type statusGetter interface {
+	Status(ctx context.Context) ([]status.Route, error)
+}
+
+// Status shows the routing state of a diverted development environment
+func Status(ctx context.Context) *cobra.Command {
+	options := &StatusOptions{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the routing state of a diverted development environment",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#divert"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+
+			ctxResource, err := utils.LoadManifestContext(options.ManifestPath)
+			if err != nil {
+				if !oktetoErrors.IsNotExist(err) {
+					return err
+				}
+				ctxResource = &model.ContextResource{}
+			}
+			if err := ctxResource.UpdateNamespace(options.Namespace); err != nil {
+				return err
+			}
+			if err := ctxResource.UpdateContext(options.K8sContext); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      options.Output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+
+			manifest, err := model.GetManifestV2(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+			if manifest.Deploy == nil || manifest.Deploy.Divert == nil {
+				return errNoDivert
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+
+			manifest.Namespace = okteto.Context().Namespace
+			if options.Name != "" {
+				manifest.Name = options.Name
+			}
+			if manifest.Name == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get the current working directory: %w", err)
+				}
+				manifest.Name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, manifest.Namespace, options.ManifestPath)
+			}
+
+			driver, err := divert.New(manifest, c)
+			if err != nil {
+				return err
+			}
+			return showStatus(ctx, driver, options.Output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment is deployed")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+	}
+}
+
+// showStatus prints the divert state of every host and route of the development environment
+func showStatus(ctx context.Context, sg statusGetter, output string, w io.Writer) error {
+	routes, err := sg.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(routes, "", " ")
+		if err != nil {
+			return err
+		}
+		jsonOutput := string(bytes)
+		if jsonOutput == "null" {
+			jsonOutput = "[]"
+		}
+		fmt.Fprintln(w, jsonOutput)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	cols := []string{"Kind", "Name", "Host", "Original Namespace", "Diverted Namespace", "Header", "Status"}
+	fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	for _, r := range routes {
+		host := r.Host
+		if host == "" {
+			host = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Kind, r.Name, host, r.OriginalNamespace, r.DivertedNamespace, r.Header, r.Status)
+	}
+	return tw.Flush()
+}
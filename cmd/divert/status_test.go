@@ -0,0 +1,115 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package divert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatusGetter struct {
+	err    error
+	routes []status.Route
+}
+
+func (f fakeStatusGetter) Status(_ context.Context) ([]status.Route, error) {
+	return f.routes, f.err
+}
+
+func TestShowStatus(t *testing.T) {
+	routes := []status.Route{
+		{
+			Kind:              "HTTPRoute",
+			Name:              "frontend",
+			Host:              "movies.okteto.dev",
+			OriginalNamespace: "staging",
+			DivertedNamespace: "cindy",
+			Header:            "okteto-divert: cindy",
+			Status:            status.Diverted,
+		},
+		{
+			Kind:              "HTTPRoute",
+			Name:              "admin",
+			OriginalNamespace: "staging",
+			DivertedNamespace: "cindy",
+			Header:            "okteto-divert: cindy",
+			Status:            status.Missing,
+		},
+	}
+	tests := []struct {
+		expectedErr error
+		name        string
+		output      string
+		expected    string
+		routes      []status.Route
+	}{
+		{
+			name:   "table",
+			routes: routes,
+			expected: `Kind       Name      Host               Original Namespace  Diverted Namespace  Header                Status
+HTTPRoute  frontend  movies.okteto.dev  staging             cindy               okteto-divert: cindy  diverted
+HTTPRoute  admin     -                  staging             cindy               okteto-divert: cindy  missing
+`,
+		},
+		{
+			name:   "json",
+			output: "json",
+			routes: routes[1:],
+			expected: `[
+ {
+  "kind": "HTTPRoute",
+  "name": "admin",
+  "originalNamespace": "staging",
+  "divertedNamespace": "cindy",
+  "header": "okteto-divert: cindy",
+  "status": "missing"
+ }
+]
+`,
+		},
+		{
+			name:     "json-empty",
+			output:   "json",
+			expected: "[]\n",
+		},
+		{
+			name:        "error",
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := showStatus(context.Background(), fakeStatusGetter{routes: tt.routes, err: tt.expectedErr}, tt.output, &b)
+			if tt.expectedErr != nil {
+				require.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	assert.NoError(t, validateOutput(""))
+	assert.NoError(t, validateOutput("json"))
+	assert.Error(t, validateOutput("yaml"))
+}
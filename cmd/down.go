@@ -24,6 +24,7 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/down"
+	"github.com/okteto/okteto/pkg/cmd/session"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/apps"
@@ -176,6 +177,10 @@ func runDown(ctx context.Context, dev *model.Dev, rm bool) error {
 	exit := make(chan error, 1)
 
 	go func() {
+		if err := session.Stop(ctx, dev.Namespace, dev.Name); err != nil && !errors.Is(err, session.ErrNotFound) {
+			oktetoLog.Infof("failed to stop the detached session of '%s': %s", dev.Name, err)
+		}
+
 		c, _, err := okteto.GetK8sClient()
 		if err != nil {
 			exit <- err
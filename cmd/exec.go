@@ -41,6 +41,7 @@ type execFlags struct {
 	manifestPath     string
 	namespace        string
 	k8sContext       string
+	container        string
 	commandToExecute []string
 }
 
@@ -82,7 +83,7 @@ func Exec() *cobra.Command {
 
 			t := time.NewTicker(1 * time.Second)
 			iter := 0
-			err = executeExec(ctx, dev, execFlags.commandToExecute)
+			err = executeExec(ctx, dev, execFlags.container, execFlags.commandToExecute)
 			for oktetoErrors.IsTransient(err) {
 				if iter == 0 {
 					oktetoLog.Yellow("Connection lost to your development container, reconnecting...")
@@ -90,7 +91,7 @@ func Exec() *cobra.Command {
 				iter++
 				iter = iter % 10
 				<-t.C
-				err = executeExec(ctx, dev, execFlags.commandToExecute)
+				err = executeExec(ctx, dev, execFlags.container, execFlags.commandToExecute)
 			}
 
 			analytics.TrackExec(&analytics.TrackExecMetadata{
@@ -119,11 +120,12 @@ func Exec() *cobra.Command {
 	cmd.Flags().StringVarP(&execFlags.manifestPath, "file", "f", utils.DefaultManifest, "path to the manifest file")
 	cmd.Flags().StringVarP(&execFlags.namespace, "namespace", "n", "", "namespace where the exec command is executed")
 	cmd.Flags().StringVarP(&execFlags.k8sContext, "context", "c", "", "context where the exec command is executed")
+	cmd.Flags().StringVarP(&execFlags.container, "container", "", "", "container of the development pod where the command is executed (defaults to the main development container)")
 
 	return cmd
 }
 
-func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
+func executeExec(ctx context.Context, dev *model.Dev, container string, args []string) error {
 	oktetoLog.Spinner("Preparing your container")
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
@@ -186,6 +188,18 @@ func executeExec(ctx context.Context, dev *model.Dev, args []string) error {
 		dev.Container = pod.Spec.Containers[0].Name
 	}
 
+	if container != "" && container != dev.Container {
+		if !dev.HasContainer(container) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("container '%s' is not in dev mode", container),
+				Hint: fmt.Sprintf("Add it to the 'containers' field of '%s' in your okteto manifest and run 'okteto up' again", devName),
+			}
+		}
+		// the SSH server only runs in the main development container
+		oktetoLog.StopSpinner()
+		return exec.Exec(ctx, c, cfg, dev.Namespace, pod.Name, container, true, os.Stdin, os.Stdout, os.Stderr, wrapped)
+	}
+
 	if dev.RemoteModeEnabled() {
 		p, err := ssh.GetPort(devName)
 		if err != nil {
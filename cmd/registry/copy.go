@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+// CopyOptions defines the options of okteto registry cp
+type CopyOptions struct {
+	Namespace  string
+	K8sContext string
+}
+
+type copyRegistryInterface interface {
+	CopyImage(src, dst string) (string, error)
+}
+
+// Copy copies an image between repositories, for example from the namespace registry to the global registry
+func Copy(ctx context.Context) *cobra.Command {
+	options := &CopyOptions{}
+	cmd := &cobra.Command{
+		Use:     "cp <source> <destination>",
+		Aliases: []string{"copy"},
+		Short:   "Copy an image, and all its platforms, to another repository",
+		Long: `Copy an image, and all its platforms, to another repository.
+
+If the destination has no tag, the tag of the source image is used.`,
+		Args: utils.ExactArgsAccepted(2, "https://www.okteto.com/docs/reference/cli/#registry"),
+		Example: `  okteto registry cp okteto.dev/api:1.0 okteto.global/api
+  okteto registry cp okteto.global/api:1.0 okteto.dev/api:stable`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := initOktetoContext(ctx, options.K8sContext, options.Namespace, true); err != nil {
+				return err
+			}
+
+			reg := oktetoRegistry.NewOktetoRegistry(okteto.Config{})
+			return runCopy(reg, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace used to expand okteto.dev")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "okteto context of the registry")
+	return cmd
+}
+
+// runCopy copies an image and shows the destination image with digest
+func runCopy(reg copyRegistryInterface, src, dst string) error {
+	oktetoLog.Spinner(fmt.Sprintf("Copying '%s' to '%s'...", src, dst))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	image, err := reg.CopyImage(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to copy '%s' to '%s': %w", src, dst, err)
+	}
+	oktetoLog.StopSpinner()
+	oktetoLog.Success("Image '%s' copied to '%s'", src, image)
+	return nil
+}
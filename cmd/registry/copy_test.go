@@ -0,0 +1,44 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCopyRegistry struct {
+	err    error
+	copied map[string]string
+}
+
+func (fr *fakeCopyRegistry) CopyImage(src, dst string) (string, error) {
+	if fr.err != nil {
+		return "", fr.err
+	}
+	fr.copied[src] = dst
+	return dst + "@sha256:aaaa", nil
+}
+
+func TestRunCopy(t *testing.T) {
+	reg := &fakeCopyRegistry{copied: map[string]string{}}
+	assert.NoError(t, runCopy(reg, "okteto.dev/api:1.0", "okteto.global/api"))
+	assert.Equal(t, map[string]string{"okteto.dev/api:1.0": "okteto.global/api"}, reg.copied)
+
+	reg.err = errors.New("unauthorized")
+	err := runCopy(reg, "okteto.dev/api:1.0", "okteto.global/api")
+	assert.ErrorContains(t, err, "unauthorized")
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/okteto"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+// InspectOptions defines the options of okteto registry inspect
+type InspectOptions struct {
+	Namespace  string
+	K8sContext string
+	Output     string
+}
+
+type inspectRegistryInterface interface {
+	InspectImage(image string) (oktetoRegistry.ImageInfo, error)
+}
+
+// Inspect shows the configuration, layers and platforms of an image
+func Inspect(ctx context.Context) *cobra.Command {
+	options := &InspectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect <image>",
+		Short: "Show the configuration, exposed ports, environment, layers and platforms of an image",
+		Args:  utils.ExactArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#registry"),
+		Example: `  okteto registry inspect okteto.dev/api:okteto
+  okteto registry inspect okteto.global/api:1.0 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+			if err := initOktetoContext(ctx, options.K8sContext, options.Namespace, options.Output == ""); err != nil {
+				return err
+			}
+
+			reg := oktetoRegistry.NewOktetoRegistry(okteto.Config{})
+			return runInspect(reg, args[0], options.Output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace used to expand okteto.dev")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "okteto context of the registry")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+// runInspect shows the information of an image
+func runInspect(reg inspectRegistryInterface, image, output string, w io.Writer) error {
+	info, err := reg.InspectImage(image)
+	if err != nil {
+		return fmt.Errorf("failed to inspect '%s': %w", image, err)
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(info, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Image:\t%s\n", info.Image)
+	fmt.Fprintf(tw, "Digest:\t%s\n", info.Digest)
+	if !info.Created.IsZero() {
+		fmt.Fprintf(tw, "Created:\t%s\n", info.Created.Format(time.RFC3339))
+	}
+	fmt.Fprintf(tw, "Platforms:\t%s\n", strings.Join(info.Platforms, ", "))
+	if len(info.Platforms) > 1 {
+		fmt.Fprintf(tw, "Inspected platform:\t%s\n", info.Platform)
+	}
+	fmt.Fprintf(tw, "Size:\t%s\n", units.HumanSize(float64(info.Size)))
+	if len(info.Entrypoint) > 0 {
+		fmt.Fprintf(tw, "Entrypoint:\t%s\n", strings.Join(info.Entrypoint, " "))
+	}
+	if len(info.Cmd) > 0 {
+		fmt.Fprintf(tw, "Command:\t%s\n", strings.Join(info.Cmd, " "))
+	}
+	if info.Workdir != "" {
+		fmt.Fprintf(tw, "Workdir:\t%s\n", info.Workdir)
+	}
+	if info.User != "" {
+		fmt.Fprintf(tw, "User:\t%s\n", info.User)
+	}
+	if len(info.Ports) > 0 {
+		fmt.Fprintf(tw, "Exposed ports:\t%s\n", strings.Join(info.Ports, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(info.Env) > 0 {
+		fmt.Fprintln(w, "Environment:")
+		for _, env := range info.Env {
+			fmt.Fprintf(w, "  %s\n", env)
+		}
+	}
+
+	if len(info.Labels) > 0 {
+		fmt.Fprintln(w, "Labels:")
+		keys := make([]string, 0, len(info.Labels))
+		for key := range info.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "  %s=%s\n", key, info.Labels[key])
+		}
+	}
+
+	fmt.Fprintln(w, "Layers:")
+	tw = tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	for _, layer := range info.Layers {
+		fmt.Fprintf(tw, "  %s\t%s\n", layer.Digest, units.HumanSize(float64(layer.Size)))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInspectRegistry struct {
+	err  error
+	info oktetoRegistry.ImageInfo
+}
+
+func (fr fakeInspectRegistry) InspectImage(_ string) (oktetoRegistry.ImageInfo, error) {
+	return fr.info, fr.err
+}
+
+func TestRunInspect(t *testing.T) {
+	reg := fakeInspectRegistry{
+		info: oktetoRegistry.ImageInfo{
+			Image:     "registry.okteto.dev/cindy/api:1.0",
+			Digest:    "sha256:aaaa",
+			Created:   time.Date(2023, 5, 10, 8, 0, 0, 0, time.UTC),
+			Platforms: []string{"linux/amd64", "linux/arm64"},
+			Platform:  "linux/amd64",
+			Cmd:       []string{"yarn", "start"},
+			Workdir:   "/usr/src/app",
+			Ports:     []string{"8080/tcp"},
+			Env:       []string{"PORT=8080"},
+			Labels:    map[string]string{"version": "1.0", "app": "api"},
+			Layers: []oktetoRegistry.ImageLayer{
+				{Digest: "sha256:bbbb", Size: 2000},
+				{Digest: "sha256:cccc", Size: 1000},
+			},
+			Size: 3000,
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runInspect(reg, "okteto.dev/api:1.0", "", &out))
+	expected := `Image:               registry.okteto.dev/cindy/api:1.0
+Digest:              sha256:aaaa
+Created:             2023-05-10T08:00:00Z
+Platforms:           linux/amd64, linux/arm64
+Inspected platform:  linux/amd64
+Size:                3kB
+Command:             yarn start
+Workdir:             /usr/src/app
+Exposed ports:       8080/tcp
+Environment:
+  PORT=8080
+Labels:
+  app=api
+  version=1.0
+Layers:
+  sha256:bbbb  2kB
+  sha256:cccc  1kB
+`
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	require.NoError(t, runInspect(reg, "okteto.dev/api:1.0", "json", &out))
+	var info oktetoRegistry.ImageInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	assert.Equal(t, reg.info, info)
+
+	reg.err = oktetoErrors.ErrNotFound
+	assert.ErrorIs(t, runInspect(reg, "okteto.dev/unknown:1.0", "", &out), oktetoErrors.ErrNotFound)
+}
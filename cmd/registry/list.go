@@ -0,0 +1,174 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions defines the options of okteto registry ls
+type ListOptions struct {
+	Namespace  string
+	K8sContext string
+	Output     string
+	Global     bool
+}
+
+type listRegistryInterface interface {
+	ListRepositories(prefix string) ([]string, error)
+	ListImageTags(repository string) ([]oktetoRegistry.ImageTag, error)
+}
+
+// tagItem is the information of an image tag shown by okteto registry ls
+type tagItem struct {
+	Image   string `json:"image"`
+	Tag     string `json:"tag"`
+	Digest  string `json:"digest"`
+	Created string `json:"created,omitempty"`
+}
+
+// List lists the repositories of the Okteto Registry or the tags of a repository
+func List(ctx context.Context) *cobra.Command {
+	options := &ListOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls [repository]",
+		Aliases: []string{"list"},
+		Short:   "List the repositories of the Okteto Registry, or the tags of a repository",
+		Args:    utils.MaximumNArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#registry"),
+		Example: `  okteto registry ls
+  okteto registry ls --global
+  okteto registry ls okteto.dev/api`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.Output); err != nil {
+				return err
+			}
+			if err := initOktetoContext(ctx, options.K8sContext, options.Namespace, options.Output == ""); err != nil {
+				return err
+			}
+
+			reg := oktetoRegistry.NewOktetoRegistry(okteto.Config{})
+			if len(args) == 1 {
+				return runListTags(reg, args[0], options.Output, os.Stdout)
+			}
+			prefix := constants.DevRegistry
+			if options.Global {
+				prefix = constants.GlobalRegistry
+			}
+			return runListRepositories(reg, prefix, options.Output, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the repositories")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "okteto context of the registry")
+	cmd.Flags().BoolVar(&options.Global, "global", false, "list the repositories of the global registry instead of the ones of the namespace")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return fmt.Errorf("output format is not accepted. Value must be one of: ['json']")
+	}
+}
+
+// runListRepositories shows the repositories stored under a registry prefix
+func runListRepositories(reg listRegistryInterface, prefix, output string, w io.Writer) error {
+	repositories, err := reg.ListRepositories(prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list the repositories of '%s': %w", prefix, err)
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(repositories, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+		return nil
+	}
+
+	if len(repositories) == 0 {
+		oktetoLog.Information("There are no repositories in '%s'", prefix)
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Repository")
+	for _, repository := range repositories {
+		fmt.Fprintln(tw, repository)
+	}
+	return tw.Flush()
+}
+
+// runListTags shows the tags of a repository, the newest first
+func runListTags(reg listRegistryInterface, repository, output string, w io.Writer) error {
+	tags, err := reg.ListImageTags(repository)
+	if err != nil {
+		return fmt.Errorf("failed to list the tags of '%s': %w", repository, err)
+	}
+
+	items := make([]tagItem, 0, len(tags))
+	for _, tag := range tags {
+		item := tagItem{
+			Image:  tag.Image(),
+			Tag:    tag.Tag,
+			Digest: tag.Digest,
+		}
+		if !tag.Created.IsZero() {
+			item.Created = tag.Created.Format(time.RFC3339)
+		}
+		items = append(items, item)
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(items, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+		return nil
+	}
+
+	if len(items) == 0 {
+		oktetoLog.Information("There are no tags in '%s'", repository)
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Tag\tDigest\tCreated")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", item.Tag, shortDigest(item.Digest), item.Created)
+	}
+	return tw.Flush()
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > shortDigestLength {
+		return digest[:shortDigestLength]
+	}
+	return digest
+}
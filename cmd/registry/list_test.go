@@ -0,0 +1,103 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeListRegistry struct {
+	err          error
+	repositories map[string][]string
+	tags         map[string][]oktetoRegistry.ImageTag
+}
+
+func (fr fakeListRegistry) ListRepositories(prefix string) ([]string, error) {
+	return fr.repositories[prefix], fr.err
+}
+
+func (fr fakeListRegistry) ListImageTags(repository string) ([]oktetoRegistry.ImageTag, error) {
+	return fr.tags[repository], fr.err
+}
+
+func TestValidateOutput(t *testing.T) {
+	assert.NoError(t, validateOutput(""))
+	assert.NoError(t, validateOutput("json"))
+	assert.Error(t, validateOutput("yaml"))
+}
+
+func TestRunListRepositories(t *testing.T) {
+	reg := fakeListRegistry{
+		repositories: map[string][]string{
+			"okteto.dev": {"okteto.dev/api", "okteto.dev/frontend"},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runListRepositories(reg, "okteto.dev", "", &out))
+	assert.Equal(t, "Repository\nokteto.dev/api\nokteto.dev/frontend\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runListRepositories(reg, "okteto.dev", "json", &out))
+	var repositories []string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &repositories))
+	assert.Equal(t, []string{"okteto.dev/api", "okteto.dev/frontend"}, repositories)
+
+	out.Reset()
+	require.NoError(t, runListRepositories(reg, "okteto.global", "", &out))
+	assert.Empty(t, out.String())
+
+	reg.err = errors.New("catalog not supported")
+	assert.Error(t, runListRepositories(reg, "okteto.dev", "", &out))
+}
+
+func TestRunListTags(t *testing.T) {
+	created := time.Date(2023, 5, 10, 8, 0, 0, 0, time.UTC)
+	reg := fakeListRegistry{
+		tags: map[string][]oktetoRegistry.ImageTag{
+			"okteto.dev/api": {
+				{Repository: "registry.okteto.dev/cindy/api", Tag: "2.0", Digest: "sha256:5ab0f6e0a4c1d7e1e2a6b1c2", Created: created},
+				{Repository: "registry.okteto.dev/cindy/api", Tag: "1.0", Digest: "sha256:1b2c"},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runListTags(reg, "okteto.dev/api", "", &out))
+	expected := "Tag  Digest               Created\n" +
+		"2.0  sha256:5ab0f6e0a4c1  2023-05-10T08:00:00Z\n" +
+		"1.0  sha256:1b2c          \n"
+	assert.Equal(t, expected, out.String())
+
+	out.Reset()
+	require.NoError(t, runListTags(reg, "okteto.dev/api", "json", &out))
+	var items []tagItem
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	assert.Equal(t, []tagItem{
+		{Image: "registry.okteto.dev/cindy/api:2.0", Tag: "2.0", Digest: "sha256:5ab0f6e0a4c1d7e1e2a6b1c2", Created: "2023-05-10T08:00:00Z"},
+		{Image: "registry.okteto.dev/cindy/api:1.0", Tag: "1.0", Digest: "sha256:1b2c"},
+	}, items)
+
+	out.Reset()
+	require.NoError(t, runListTags(reg, "okteto.dev/unknown", "json", &out))
+	assert.Equal(t, "[]\n", out.String())
+}
@@ -0,0 +1,451 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/rollouts"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultKeep is the number of most recent tags of each repository kept by default
+	defaultKeep = 5
+
+	// shortDigestLength is the number of characters of the digest shown in the tables
+	shortDigestLength = 19
+
+	// dockerPullablePrefix is the prefix of the image IDs reported by the docker runtime
+	dockerPullablePrefix = "docker-pullable://"
+
+	statusKept     = "kept"
+	statusInUse    = "in use"
+	statusPrune    = "prune"
+	statusDeleted  = "deleted"
+	statusDryRun   = "to delete"
+	statusNotFound = "not found"
+)
+
+var errInvalidKeep = errors.New("--keep must be greater than or equal to 0")
+
+// PruneOptions defines the options of okteto registry prune
+type PruneOptions struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Output       string
+	Keep         int
+	DryRun       bool
+	Global       bool
+}
+
+type pruneRegistryInterface interface {
+	ListImageTags(repository string) ([]oktetoRegistry.ImageTag, error)
+	ListSignatureTags(repository string) ([]oktetoRegistry.ImageTag, error)
+	DeleteImage(imageWithDigest string) error
+}
+
+// pruneItem is the information of an image tag shown by okteto registry prune
+type pruneItem struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest"`
+	Created    string `json:"created,omitempty"`
+	Status     string `json:"status"`
+}
+
+// Prune deletes the stale images pushed by the builds of a development environment
+func Prune(ctx context.Context) *cobra.Command {
+	options := &PruneOptions{}
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete the stale images pushed to the Okteto Registry by the builds of a development environment",
+		Args:  utils.NoArgsAccepted("https://www.okteto.com/docs/reference/cli/#registry"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validatePruneOptions(options); err != nil {
+				return err
+			}
+
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+
+			ctxResource, err := utils.LoadManifestContext(options.ManifestPath)
+			if err != nil {
+				if !oktetoErrors.IsNotExist(err) {
+					return err
+				}
+				ctxResource = &model.ContextResource{}
+			}
+			if err := ctxResource.UpdateNamespace(options.Namespace); err != nil {
+				return err
+			}
+			if err := ctxResource.UpdateContext(options.K8sContext); err != nil {
+				return err
+			}
+
+			ctxOptions := &contextCMD.ContextOptions{
+				Context:   ctxResource.Context,
+				Namespace: ctxResource.Namespace,
+				Show:      options.Output == "",
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+				return err
+			}
+			if !okteto.IsOkteto() {
+				return oktetoErrors.ErrContextIsNotOktetoCluster
+			}
+
+			c, _, err := okteto.NewK8sClientProvider().Provide(okteto.Context().Cfg)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := model.GetManifestV2(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+			if options.Name == "" {
+				options.Name = manifest.Name
+			}
+			if options.Name == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get the current working directory: %w", err)
+				}
+				options.Name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, okteto.Context().Namespace, options.ManifestPath)
+			}
+
+			dc, _, err := okteto.GetDynamicClient()
+			if err != nil {
+				return err
+			}
+
+			// the images of the global registry can be used by any namespace
+			namespace := okteto.Context().Namespace
+			if options.Global {
+				namespace = metav1.NamespaceAll
+			}
+			inUse, err := getImagesInUse(ctx, namespace, c, dc)
+			if err != nil {
+				return err
+			}
+
+			reg := oktetoRegistry.NewOktetoRegistry(okteto.Config{})
+			repositories := getPruneRepositories(manifest, options.Name, options.Global)
+			return runPrune(reg, repositories, inUse, options, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "development environment name")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the development environment")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context of the development environment")
+	cmd.Flags().IntVar(&options.Keep, "keep", defaultKeep, "number of most recent tags of each image to keep")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "show the images that would be deleted without deleting them")
+	cmd.Flags().BoolVar(&options.Global, "global", false, "prune the images of the global registry instead of the ones of the namespace. The images in use are checked in all namespaces")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "", "output format. One of: ['json']")
+	return cmd
+}
+
+func validatePruneOptions(options *PruneOptions) error {
+	if options.Keep < 0 {
+		return errInvalidKeep
+	}
+	return validateOutput(options.Output)
+}
+
+// getPruneRepositories returns the repositories where the images of the manifest services are pushed
+func getPruneRepositories(manifest *model.Manifest, devName string, global bool) []string {
+	targetRegistry := constants.DevRegistry
+	if global {
+		targetRegistry = constants.GlobalRegistry
+	}
+	sanitizedName := format.ResourceK8sMetaString(devName)
+
+	repositories := map[string]bool{}
+	for svcName, buildInfo := range manifest.Build {
+		repositories[fmt.Sprintf("%s/%s-%s", targetRegistry, sanitizedName, svcName)] = true
+		if buildInfo == nil || !strings.HasPrefix(buildInfo.Image, targetRegistry+"/") {
+			continue
+		}
+		ref, err := name.ParseReference(buildInfo.Image)
+		if err != nil {
+			oktetoLog.Infof("could not parse image '%s': %s", buildInfo.Image, err)
+			continue
+		}
+		repositories[ref.Context().Name()] = true
+	}
+
+	result := make([]string, 0, len(repositories))
+	for repository := range repositories {
+		result = append(result, repository)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// getImagesInUse returns the images referenced by the workloads and pods of the namespace, or of every namespace if it is empty
+func getImagesInUse(ctx context.Context, namespace string, c kubernetes.Interface, dc dynamic.Interface) (map[string]bool, error) {
+	inUse := map[string]bool{}
+	addPodSpec := func(spec apiv1.PodSpec) {
+		for _, container := range spec.InitContainers {
+			inUse[container.Image] = true
+		}
+		for _, container := range spec.Containers {
+			inUse[container.Image] = true
+		}
+	}
+
+	deploymentList, err := deployments.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, getListError("deployments", namespace, err)
+	}
+	for _, d := range deploymentList {
+		addPodSpec(d.Spec.Template.Spec)
+	}
+
+	sfsList, err := statefulsets.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, getListError("statefulsets", namespace, err)
+	}
+	for _, sfs := range sfsList {
+		addPodSpec(sfs.Spec.Template.Spec)
+	}
+
+	dsList, err := c.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, getListError("daemonsets", namespace, err)
+	}
+	for _, ds := range dsList.Items {
+		addPodSpec(ds.Spec.Template.Spec)
+	}
+
+	jobList, err := jobs.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, getListError("jobs", namespace, err)
+	}
+	for _, j := range jobList {
+		addPodSpec(j.Spec.Template.Spec)
+	}
+
+	cronJobList, err := c.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, getListError("cronjobs", namespace, err)
+	}
+	for _, cj := range cronJobList.Items {
+		addPodSpec(cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	// the rollouts resource doesn't exist if argo rollouts is not installed in the cluster
+	rolloutList, err := rollouts.List(ctx, namespace, "", dc)
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return nil, getListError("argo rollouts", namespace, err)
+	}
+	for _, r := range rolloutList {
+		addPodSpec(r.Spec.Template.Spec)
+	}
+
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, getListError("pods", namespace, err)
+	}
+	for _, p := range podList.Items {
+		addPodSpec(p.Spec)
+		// image IDs include the digest of the images referenced by tag
+		for _, status := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+			if status.ImageID != "" {
+				inUse[strings.TrimPrefix(status.ImageID, dockerPullablePrefix)] = true
+			}
+		}
+	}
+	return inUse, nil
+}
+
+// getListError returns the error of listing the resources of a kind.
+// Pruning the global registry is refused if the images in use can't be checked in every namespace
+func getListError(kind, namespace string, err error) error {
+	if namespace == metav1.NamespaceAll && k8sErrors.IsForbidden(err) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to list %s in all namespaces: %w", kind, err),
+			Hint: "The images of the global registry can be used by any namespace. Run 'okteto registry prune --global' with a user allowed to list the workloads of every namespace",
+		}
+	}
+	return fmt.Errorf("failed to list %s: %w", kind, err)
+}
+
+// selectImagesToPrune returns the status of every tag: the most recent tags and the ones in use are kept.
+// Tags sharing the digest of a kept tag are also kept, since deleting a digest deletes all its tags
+func selectImagesToPrune(tags []oktetoRegistry.ImageTag, keep int, inUse map[string]bool) []pruneItem {
+	keptDigests := map[string]bool{}
+	statuses := make([]string, len(tags))
+	for i, tag := range tags {
+		switch {
+		case i < keep:
+			statuses[i] = statusKept
+		case inUse[tag.Image()] || inUse[tag.ImageWithDigest()]:
+			statuses[i] = statusInUse
+		default:
+			continue
+		}
+		keptDigests[tag.Digest] = true
+	}
+
+	result := make([]pruneItem, 0, len(tags))
+	for i, tag := range tags {
+		status := statuses[i]
+		if status == "" {
+			status = statusPrune
+			if keptDigests[tag.Digest] {
+				status = statusKept
+			}
+		}
+		item := pruneItem{
+			Repository: tag.Repository,
+			Tag:        tag.Tag,
+			Digest:     tag.Digest,
+			Status:     status,
+		}
+		if !tag.Created.IsZero() {
+			item.Created = tag.Created.Format(time.RFC3339)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// runPrune deletes the stale images of the repositories and shows the status of every tag.
+// The signatures of the images are deleted together with them
+func runPrune(reg pruneRegistryInterface, repositories []string, inUse map[string]bool, options *PruneOptions, w io.Writer) error {
+	items := []pruneItem{}
+	deleted := 0
+	for _, repository := range repositories {
+		images, err := reg.ListImageTags(repository)
+		if err != nil {
+			return fmt.Errorf("failed to list the tags of '%s': %w", repository, err)
+		}
+		signatures, err := reg.ListSignatureTags(repository)
+		if err != nil {
+			return fmt.Errorf("failed to list the signatures of '%s': %w", repository, err)
+		}
+
+		// every tag of a deleted digest is deleted with it
+		digestStatuses := map[string]string{}
+		deleteDigest := func(item pruneItem) (string, error) {
+			if status, ok := digestStatuses[item.Digest]; ok && status != statusKept && status != statusInUse {
+				return status, nil
+			}
+			if options.DryRun {
+				return statusDryRun, nil
+			}
+			err := reg.DeleteImage(fmt.Sprintf("%s@%s", item.Repository, item.Digest))
+			switch {
+			case err == nil:
+				deleted++
+				return statusDeleted, nil
+			case errors.Is(err, oktetoErrors.ErrNotFound):
+				return statusNotFound, nil
+			default:
+				return "", fmt.Errorf("failed to delete '%s:%s': %w", item.Repository, item.Tag, err)
+			}
+		}
+
+		for _, item := range selectImagesToPrune(images, options.Keep, inUse) {
+			if item.Status == statusPrune {
+				item.Status, err = deleteDigest(item)
+				if err != nil {
+					return err
+				}
+			}
+			digestStatuses[item.Digest] = item.Status
+			items = append(items, item)
+		}
+
+		// signatures are kept while the image they sign is kept
+		for _, item := range selectImagesToPrune(signatures, len(signatures), nil) {
+			signedDigest, _ := oktetoRegistry.GetSignedDigest(item.Tag)
+			if status, ok := digestStatuses[signedDigest]; !ok || (status != statusKept && status != statusInUse) {
+				item.Status, err = deleteDigest(item)
+				if err != nil {
+					return err
+				}
+			}
+			digestStatuses[item.Digest] = item.Status
+			items = append(items, item)
+		}
+	}
+
+	if options.Output == "json" {
+		bytes, err := json.MarshalIndent(items, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(bytes))
+		return nil
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintln(w, "There are no images to prune")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "Repository\tTag\tDigest\tCreated\tStatus")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.Repository, item.Tag, shortDigest(item.Digest), item.Created, item.Status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if options.DryRun {
+		oktetoLog.Information("Dry run: no image was deleted")
+		return nil
+	}
+	oktetoLog.Success("Deleted %d images", deleted)
+	return nil
+}
@@ -0,0 +1,398 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrRegistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/rollouts"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	oktetoRegistry "github.com/okteto/okteto/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+const testRepository = "registry.okteto.dev/test/movies-api"
+
+type fakePruneRegistry struct {
+	tags    map[string][]oktetoRegistry.ImageTag
+	deleted []string
+}
+
+func (fr *fakePruneRegistry) ListImageTags(repository string) ([]oktetoRegistry.ImageTag, error) {
+	return fr.tags[repository], nil
+}
+
+func (*fakePruneRegistry) ListSignatureTags(string) ([]oktetoRegistry.ImageTag, error) {
+	return nil, nil
+}
+
+func (fr *fakePruneRegistry) DeleteImage(imageWithDigest string) error {
+	for _, deleted := range fr.deleted {
+		if deleted == imageWithDigest {
+			return oktetoErrors.ErrNotFound
+		}
+	}
+	fr.deleted = append(fr.deleted, imageWithDigest)
+	return nil
+}
+
+func newTestTags() []oktetoRegistry.ImageTag {
+	now := time.Now()
+	return []oktetoRegistry.ImageTag{
+		{Repository: testRepository, Tag: "okteto", Digest: "sha256:d", Created: now},
+		{Repository: testRepository, Tag: "hash-d", Digest: "sha256:d", Created: now},
+		{Repository: testRepository, Tag: "hash-c", Digest: "sha256:c", Created: now.Add(-time.Hour)},
+		{Repository: testRepository, Tag: "hash-b", Digest: "sha256:b", Created: now.Add(-2 * time.Hour)},
+		{Repository: testRepository, Tag: "hash-a", Digest: "sha256:a", Created: now.Add(-3 * time.Hour)},
+	}
+}
+
+func TestValidatePruneOptions(t *testing.T) {
+	assert.NoError(t, validatePruneOptions(&PruneOptions{Keep: 0}))
+	assert.NoError(t, validatePruneOptions(&PruneOptions{Keep: 3, Output: "json"}))
+	assert.ErrorIs(t, validatePruneOptions(&PruneOptions{Keep: -1}), errInvalidKeep)
+	assert.Error(t, validatePruneOptions(&PruneOptions{Output: "yaml"}))
+}
+
+func TestGetPruneRepositories(t *testing.T) {
+	manifest := &model.Manifest{
+		Build: model.ManifestBuild{
+			"api":      &model.BuildInfo{Context: "api"},
+			"frontend": &model.BuildInfo{Context: "frontend", Image: "okteto.dev/web:1.0"},
+			"worker":   &model.BuildInfo{Context: "worker", Image: "okteto/worker:1.0"},
+		},
+	}
+	assert.Equal(t, []string{
+		"okteto.dev/movies-api",
+		"okteto.dev/movies-frontend",
+		"okteto.dev/movies-worker",
+		"okteto.dev/web",
+	}, getPruneRepositories(manifest, "Movies", false))
+
+	assert.Equal(t, []string{
+		"okteto.global/movies-api",
+		"okteto.global/movies-frontend",
+		"okteto.global/movies-worker",
+	}, getPruneRepositories(manifest, "movies", true))
+}
+
+func TestSelectImagesToPrune(t *testing.T) {
+	tests := []struct {
+		inUse    map[string]bool
+		name     string
+		expected []string
+		keep     int
+	}{
+		{
+			name:     "keep the most recent",
+			keep:     2,
+			expected: []string{statusKept, statusKept, statusPrune, statusPrune, statusPrune},
+		},
+		{
+			name:     "tags sharing a kept digest are kept",
+			keep:     1,
+			expected: []string{statusKept, statusKept, statusPrune, statusPrune, statusPrune},
+		},
+		{
+			name:     "in use by tag and by digest",
+			keep:     1,
+			inUse:    map[string]bool{testRepository + ":hash-b": true, testRepository + "@sha256:a": true},
+			expected: []string{statusKept, statusKept, statusPrune, statusInUse, statusInUse},
+		},
+		{
+			name:     "keep nothing",
+			keep:     0,
+			expected: []string{statusPrune, statusPrune, statusPrune, statusPrune, statusPrune},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := selectImagesToPrune(newTestTags(), tt.keep, tt.inUse)
+			statuses := []string{}
+			for _, item := range items {
+				statuses = append(statuses, item.Status)
+			}
+			assert.Equal(t, tt.expected, statuses)
+		})
+	}
+}
+
+func TestRunPrune(t *testing.T) {
+	reg := &fakePruneRegistry{
+		tags: map[string][]oktetoRegistry.ImageTag{testRepository: newTestTags()},
+	}
+
+	var out bytes.Buffer
+	err := runPrune(reg, []string{testRepository}, nil, &PruneOptions{Keep: 0, DryRun: true, Output: "json"}, &out)
+	require.NoError(t, err)
+	assert.Empty(t, reg.deleted)
+	var items []pruneItem
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	require.Len(t, items, 5)
+	for _, item := range items {
+		assert.Equal(t, statusDryRun, item.Status)
+	}
+
+	out.Reset()
+	err = runPrune(reg, []string{testRepository}, nil, &PruneOptions{Keep: 2}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testRepository + "@sha256:c", testRepository + "@sha256:b", testRepository + "@sha256:a"}, reg.deleted)
+	assert.Contains(t, out.String(), "hash-c")
+	assert.Contains(t, out.String(), statusDeleted)
+}
+
+// pushSignedTestImage pushes a random image created at the given time and signs it
+func pushSignedTestImage(t *testing.T, reg oktetoRegistry.OktetoRegistry, image string, created time.Time, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	require.NoError(t, reg.SignImage(image, key))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return digest.String()
+}
+
+func TestRunPruneSignatures(t *testing.T) {
+	server := httptest.NewServer(ggcrRegistry.New())
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test", Registry: u.Host},
+		},
+		CurrentContext: "test",
+	}
+	reg := oktetoRegistry.NewOktetoRegistry(okteto.Config{})
+	repository := fmt.Sprintf("%s/test/movies-api", u.Host)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	digestB := pushSignedTestImage(t, reg, repository+":hash-b", now, key)
+	digestA := pushSignedTestImage(t, reg, repository+":hash-a", now.Add(-time.Hour), key)
+	// the image of an orphan signature was deleted out of okteto
+	digestZ := pushSignedTestImage(t, reg, repository+":hash-z", now.Add(-2*time.Hour), key)
+	require.NoError(t, reg.DeleteImage(fmt.Sprintf("%s@%s", repository, digestZ)))
+	zRef, err := name.ParseReference(repository + ":hash-z")
+	require.NoError(t, err)
+	require.NoError(t, remote.Delete(zRef))
+
+	signatureDigests := map[string]string{}
+	signatures, err := reg.ListSignatureTags(repository)
+	require.NoError(t, err)
+	require.Len(t, signatures, 3)
+	for _, sig := range signatures {
+		signatureDigests[sig.Tag] = sig.Digest
+	}
+	sigTag := func(digest string) string {
+		return strings.Replace(digest, ":", "-", 1) + ".sig"
+	}
+
+	var out bytes.Buffer
+	err = runPrune(reg, []string{repository}, nil, &PruneOptions{Keep: 1, Output: "json"}, &out)
+	require.NoError(t, err)
+
+	var items []pruneItem
+	require.NoError(t, json.Unmarshal(out.Bytes(), &items))
+	statuses := map[string]string{}
+	for _, item := range items {
+		statuses[item.Tag] = item.Status
+	}
+	assert.Equal(t, map[string]string{
+		"hash-b":        statusKept,
+		"hash-a":        statusDeleted,
+		sigTag(digestB): statusKept,
+		sigTag(digestA): statusDeleted,
+		sigTag(digestZ): statusDeleted,
+	}, statuses)
+
+	exists := func(digest string) bool {
+		ref, err := name.ParseReference(fmt.Sprintf("%s@%s", repository, digest))
+		require.NoError(t, err)
+		_, err = remote.Head(ref)
+		return err == nil
+	}
+	assert.True(t, exists(digestB))
+	assert.False(t, exists(digestA))
+	assert.True(t, exists(signatureDigests[sigTag(digestB)]))
+	assert.False(t, exists(signatureDigests[sigTag(digestA)]))
+	assert.False(t, exists(signatureDigests[sigTag(digestZ)]))
+}
+
+func TestGetImagesInUse(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: testRepository + ":hash-b"}}},
+				},
+			},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+			Spec: appsv1.StatefulSetSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{InitContainers: []apiv1.Container{{Image: "busybox"}}},
+				},
+			},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test"},
+			Spec: appsv1.DaemonSetSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: "okteto/agent"}}},
+				},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrations", Namespace: "test"},
+			Spec: batchv1.JobSpec{
+				Template: apiv1.PodTemplateSpec{
+					Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: "okteto/migrations"}}},
+				},
+			},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "test"},
+			Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{
+					Spec: batchv1.JobSpec{
+						Template: apiv1.PodTemplateSpec{
+							Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Image: "okteto/backup"}}},
+						},
+					},
+				},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "test"},
+			Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: testRepository + ":okteto"}}},
+			Status: apiv1.PodStatus{
+				ContainerStatuses: []apiv1.ContainerStatus{{ImageID: "docker-pullable://" + testRepository + "@sha256:a"}},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+			Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: "other"}}},
+		},
+	)
+
+	dc := newFakeRolloutsClient(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "test"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{map[string]interface{}{"name": "web", "image": "okteto/web"}},
+					},
+				},
+			},
+		},
+	})
+
+	inUse, err := getImagesInUse(context.Background(), "test", c, dc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		testRepository + ":hash-b":   true,
+		"busybox":                    true,
+		"okteto/agent":               true,
+		"okteto/migrations":          true,
+		"okteto/backup":              true,
+		"okteto/web":                 true,
+		testRepository + ":okteto":   true,
+		testRepository + "@sha256:a": true,
+	}, inUse)
+
+	inUse, err = getImagesInUse(context.Background(), metav1.NamespaceAll, c, dc)
+	require.NoError(t, err)
+	assert.True(t, inUse["other"])
+}
+
+func TestGetImagesInUseWithoutRollouts(t *testing.T) {
+	c := fake.NewSimpleClientset(&apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "test"},
+		Spec:       apiv1.PodSpec{Containers: []apiv1.Container{{Image: testRepository + ":okteto"}}},
+	})
+	dc := newFakeRolloutsClient()
+	dc.PrependReactor("list", "rollouts", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewNotFound(rollouts.GVR.GroupResource(), "")
+	})
+
+	inUse, err := getImagesInUse(context.Background(), "test", c, dc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{testRepository + ":okteto": true}, inUse)
+}
+
+func TestGetImagesInUseGlobalForbidden(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("list", "deployments", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewForbidden(appsv1.Resource("deployments"), "", errors.New("forbidden"))
+	})
+
+	_, err := getImagesInUse(context.Background(), metav1.NamespaceAll, c, newFakeRolloutsClient())
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	_, err = getImagesInUse(context.Background(), "test", c, newFakeRolloutsClient())
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &oktetoErrors.UserError{}))
+}
+
+func newFakeRolloutsClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rollouts.GVR: "RolloutList"},
+		objects...,
+	)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+// Registry manages the images stored in the Okteto Registry
+func Registry(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Manage the images stored in the Okteto Registry",
+	}
+	cmd.AddCommand(List(ctx))
+	cmd.AddCommand(Inspect(ctx))
+	cmd.AddCommand(Copy(ctx))
+	cmd.AddCommand(Prune(ctx))
+	return cmd
+}
+
+// initOktetoContext loads the okteto context used by the registry commands
+func initOktetoContext(ctx context.Context, k8sContext, namespace string, show bool) error {
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   k8sContext,
+		Namespace: namespace,
+		Show:      show,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+	if !okteto.IsOkteto() {
+		return oktetoErrors.ErrContextIsNotOktetoCluster
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/cobra"
@@ -77,6 +79,10 @@ func Status() *cobra.Command {
 				}
 			}
 
+			if dev.GetSyncBackend() != model.SyncBackendSyncthing {
+				return fmt.Errorf("'okteto status' is only supported by the '%s' sync backend", model.SyncBackendSyncthing)
+			}
+
 			waitForStates := []config.UpState{config.Synchronizing, config.Ready}
 			if err := status.Wait(dev, waitForStates); err != nil {
 				return err
@@ -93,6 +99,7 @@ func Status() *cobra.Command {
 				oktetoLog.Information("Syncthing username: okteto")
 				oktetoLog.Information("Syncthing password: %s", sy.GUIPassword)
 			}
+			printSyncFolders(sy.Folders)
 
 			if watch {
 				err = runWithWatch(ctx, sy)
@@ -112,6 +119,23 @@ func Status() *cobra.Command {
 	return cmd
 }
 
+func printSyncFolders(folders []*syncthing.Folder) {
+	for _, folder := range folders {
+		oktetoLog.Information("Sync folder '%s:%s': %s", folder.LocalPath, folder.RemotePath, getSyncModeDescription(folder.Mode))
+	}
+}
+
+func getSyncModeDescription(mode string) string {
+	switch mode {
+	case model.SyncModeSend:
+		return "send only (local changes are synchronized to your development container)"
+	case model.SyncModeReceive:
+		return "receive only (changes in your development container are synchronized to your local folder)"
+	default:
+		return "send and receive"
+	}
+}
+
 func runWithWatch(ctx context.Context, sy *syncthing.Syncthing) error {
 	textSpinner := "Synchronizing your files..."
 	oktetoLog.Spinner(textSpinner)
@@ -0,0 +1,183 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// ConflictsOptions defines the options of the sync conflicts command
+type ConflictsOptions struct {
+	ManifestPath string
+	Path         string
+	Keep         string
+	Diff         bool
+}
+
+// Conflicts lists, shows and resolves the sync conflicts of a development container
+func Conflicts(_ context.Context) *cobra.Command {
+	options := &ConflictsOptions{}
+	cmd := &cobra.Command{
+		Use:   "conflicts [svc]",
+		Short: "List and resolve the files modified at the same time locally and in your development container",
+		Long: `List and resolve the files modified at the same time locally and in your development container.
+
+Conflicts are only preserved when 'sync.keepConflicts' is enabled in the okteto manifest.
+Syncthing keeps one of the versions in the original file and the other one in a '.sync-conflict-' copy.`,
+		Args: utils.MaximumNArgsAccepted(1, "https://www.okteto.com/docs/reference/cli/#sync"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Keep != "" && options.Keep != syncthing.LocalVersion && options.Keep != syncthing.RemoteVersion {
+				return fmt.Errorf("invalid value for --keep: must be '%s' or '%s'", syncthing.LocalVersion, syncthing.RemoteVersion)
+			}
+			if options.Keep != "" && options.Diff {
+				return errors.New("--keep and --diff can't be used at the same time")
+			}
+
+			if options.ManifestPath != "" {
+				workdir := model.GetWorkdirFromManifestPath(options.ManifestPath)
+				if err := os.Chdir(workdir); err != nil {
+					return err
+				}
+				options.ManifestPath = model.GetManifestPathFromWorkdir(options.ManifestPath, workdir)
+			}
+			manifest, err := model.GetManifestV2(options.ManifestPath)
+			if err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 1 {
+				devName = args[0]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select the development container:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+				if err != nil {
+					return err
+				}
+			}
+			if err := dev.PreparePathsAndExpandEnvFiles(manifest.ManifestPath); err != nil {
+				return err
+			}
+			if !dev.Sync.KeepConflicts {
+				oktetoLog.Warning("'sync.keepConflicts' is not enabled for '%s': conflicting changes are discarded instead of being preserved", dev.Name)
+			}
+
+			folders, err := getSyncFolders(dev)
+			if err != nil {
+				return err
+			}
+			return runConflicts(afero.NewOsFs(), folders, options, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "path to the okteto manifest file")
+	cmd.Flags().StringVar(&options.Path, "path", "", "only show or resolve the conflicts of this file")
+	cmd.Flags().BoolVar(&options.Diff, "diff", false, "show the differences between the local and the remote versions")
+	cmd.Flags().StringVar(&options.Keep, "keep", "", "resolve the conflicts keeping the 'local' or the 'remote' version")
+	return cmd
+}
+
+// getSyncFolders returns the local folders synchronized by the development container, excluding the nested ones
+func getSyncFolders(dev *model.Dev) ([]string, error) {
+	result := []string{}
+	for _, folder := range dev.Sync.Folders {
+		isSubPath, err := dev.IsSubPathFolder(folder.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		if !isSubPath {
+			result = append(result, folder.LocalPath)
+		}
+	}
+	return result, nil
+}
+
+func runConflicts(fs afero.Fs, folders []string, opts *ConflictsOptions, w io.Writer) error {
+	conflicts := []syncthing.Conflict{}
+	for _, folder := range folders {
+		folderConflicts, err := syncthing.FindConflicts(fs, folder)
+		if err != nil {
+			return err
+		}
+		for _, c := range folderConflicts {
+			if opts.Path != "" && !matchesPath(c, opts.Path) {
+				continue
+			}
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	if len(conflicts) == 0 {
+		oktetoLog.Success("There are no sync conflicts")
+		return nil
+	}
+
+	switch {
+	case opts.Keep != "":
+		for _, c := range conflicts {
+			if err := c.Resolve(fs, opts.Keep); err != nil {
+				return fmt.Errorf("failed to resolve the conflict of '%s': %w", c.Path, err)
+			}
+			oktetoLog.Success("Conflict of '%s' resolved keeping the %s version", c.Path, opts.Keep)
+		}
+	case opts.Diff:
+		for _, c := range conflicts {
+			diff, err := c.Diff(fs)
+			if err != nil {
+				return fmt.Errorf("failed to compare the versions of '%s': %w", c.Path, err)
+			}
+			fmt.Fprint(w, diff)
+		}
+	default:
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		cols := []string{"File", "Conflict Copy", "Modified By", "Date"}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+		for _, c := range conflicts {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", filepath.Join(c.Folder, c.Path), c.ConflictPath, c.ModifiedBy, c.Date.Format(time.RFC3339))
+		}
+		return tw.Flush()
+	}
+	return nil
+}
+
+// matchesPath returns if the conflict is of the given file, relative to the synchronized folder or to the current directory
+func matchesPath(c syncthing.Conflict, path string) bool {
+	if filepath.Clean(path) == c.Path {
+		return true
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return abs == filepath.Join(c.Folder, c.Path)
+}
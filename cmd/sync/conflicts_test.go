@@ -0,0 +1,84 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConflictsFs(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"/app/a.txt": "hello\nlocal\n",
+		"/app/a.sync-conflict-20231015-103000-ATOPHFJ.txt": "hello\nremote\n",
+		"/app/b.txt": "b",
+		"/app/b.sync-conflict-20231015-110000-ABKAVQF.txt": "b local",
+	}
+	for name, content := range files {
+		require.NoError(t, afero.WriteFile(fs, name, []byte(content), 0600))
+	}
+	return fs
+}
+
+func TestRunConflicts(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		fs := newConflictsFs(t)
+		var buf bytes.Buffer
+		require.NoError(t, runConflicts(fs, []string{"/app"}, &ConflictsOptions{}, &buf))
+		expected := `File        Conflict Copy                                Modified By  Date
+/app/a.txt  a.sync-conflict-20231015-103000-ATOPHFJ.txt  remote       2023-10-15T10:30:00Z
+/app/b.txt  b.sync-conflict-20231015-110000-ABKAVQF.txt  local        2023-10-15T11:00:00Z
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("diff filtered by path", func(t *testing.T) {
+		fs := newConflictsFs(t)
+		var buf bytes.Buffer
+		require.NoError(t, runConflicts(fs, []string{"/app"}, &ConflictsOptions{Diff: true, Path: "/app/a.txt"}, &buf))
+		assert.Contains(t, buf.String(), "-local\n+remote\n")
+		assert.NotContains(t, buf.String(), "b.txt")
+	})
+
+	t.Run("keep remote", func(t *testing.T) {
+		fs := newConflictsFs(t)
+		var buf bytes.Buffer
+		require.NoError(t, runConflicts(fs, []string{"/app"}, &ConflictsOptions{Keep: syncthing.RemoteVersion}, &buf))
+
+		content, err := afero.ReadFile(fs, "/app/a.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "hello\nremote\n", string(content))
+		content, err = afero.ReadFile(fs, "/app/b.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "b", string(content))
+
+		conflicts, err := syncthing.FindConflicts(fs, "/app")
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("no conflicts", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, "/app/a.txt", []byte("a"), 0600))
+		var buf bytes.Buffer
+		require.NoError(t, runConflicts(fs, []string{"/app"}, &ConflictsOptions{}, &buf))
+		assert.Empty(t, buf.String())
+	})
+}
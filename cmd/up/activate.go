@@ -119,8 +119,8 @@ func (up *upContext) activate() error {
 	}
 
 	go func() {
-		if err := up.initializeSyncthing(); err != nil {
-			oktetoLog.Infof("could not initialize syncthing: %s", err)
+		if err := up.initializeSynchronizer(); err != nil {
+			oktetoLog.Infof("could not initialize the synchronization service: %s", err)
 		}
 	}()
 	if err := up.setDevContainer(app); err != nil {
@@ -215,8 +215,16 @@ func (up *upContext) activate() error {
 		durationActivateUp := time.Since(up.StartTime)
 		up.analyticsMeta.ActivateDuration(durationActivateUp)
 
+		if up.isDetached() {
+			// the terminal of a detached session is opened by 'okteto attach'
+			if err := config.UpdateStateFile(up.Dev.Name, up.Dev.Namespace, config.Ready); err != nil {
+				up.CommandResult <- err
+			}
+			return
+		}
+
 		startRunCommand := time.Now()
-		up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
+		up.CommandResult <- up.runTerminal(ctx, up.RunCommand)
 		up.analyticsMeta.ExecDuration(time.Since(startRunCommand))
 
 	}()
@@ -300,7 +308,11 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	}
 
 	oktetoLog.Info("create deployment secrets")
-	if err := secrets.Create(ctx, up.Dev, k8sClient, up.Sy); err != nil {
+	remoteSyncthing, err := up.getRemoteSyncthingConfig()
+	if err != nil {
+		return err
+	}
+	if err := secrets.Create(ctx, up.Dev, k8sClient, remoteSyncthing); err != nil {
 		return err
 	}
 
@@ -0,0 +1,143 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/session"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/cobra"
+)
+
+// Attach opens a terminal in a development container running in a detached session
+func Attach() *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "attach [svc]",
+		Short: "Open a terminal in a development container started with 'okteto up --detach'",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/cli/#attach"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if okteto.InDevContainer() {
+				return oktetoErrors.ErrNotInDevContainer
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			s, err := getAttachSession(ctx, name, namespace, session.List)
+			if err != nil {
+				return err
+			}
+			return attach(ctx, s)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of the detached session")
+	return cmd
+}
+
+// getAttachSession returns the detached session matching name and namespace. Both are optional if there is only one session
+func getAttachSession(ctx context.Context, name, namespace string, listSessions listSessionsFn) (*session.Session, error) {
+	sessions, err := listSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []session.Session{}
+	for _, s := range sessions {
+		if (name == "" || s.Name == name) && (namespace == "" || s.Namespace == namespace) {
+			candidates = append(candidates, s)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		if name == "" {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("there are no detached sessions"),
+				Hint: "Run 'okteto up --detach' to start one",
+			}
+		}
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("development container '%s' is not running in a detached session", name),
+			Hint: fmt.Sprintf("Run 'okteto up %s --detach' to start it", name),
+		}
+	case 1:
+		return &candidates[0], nil
+	default:
+		names := []string{}
+		for _, s := range candidates {
+			names = append(names, fmt.Sprintf("%s/%s", s.Namespace, s.Name))
+		}
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("there are several detached sessions: %s", strings.Join(names, ", ")),
+			Hint: "Select one with 'okteto attach <svc> --namespace <namespace>'",
+		}
+	}
+}
+
+// execInSessionContainer runs the command of the session in its container through the Kubernetes API
+var execInSessionContainer = func(ctx context.Context, s *session.Session) error {
+	ctxOptions := &contextCMD.ContextOptions{
+		Context:   s.Context,
+		Namespace: s.Namespace,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return err
+	}
+	k8sClient, restConfig, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+	return k8sExec.Exec(ctx, k8sClient, restConfig, s.Namespace, s.Pod, s.Container, true, os.Stdin, os.Stdout, os.Stderr, s.Command)
+}
+
+// attach runs the command of the development container in the terminal, through the SSH tunnel of the session.
+// Sessions attached to another container of the development pod are run through the Kubernetes API
+func attach(ctx context.Context, s *session.Session) error {
+	if s.State != config.Ready {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the session of '%s' is not ready yet: it is in state '%s'", s.Name, s.State),
+			Hint: "Wait a few seconds and try again",
+		}
+	}
+	if s.Container != "" {
+		// the SSH server only runs in the main development container
+		oktetoLog.Infof("attaching to container '%s' of the session of '%s' (pid %d)", s.Container, s.Name, s.PID)
+		return execInSessionContainer(ctx, s)
+	}
+	if s.RemotePort == 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the session of '%s' doesn't have SSH access to the development container", s.Name),
+			Hint: fmt.Sprintf("Run 'okteto exec -n %s' to open a terminal in your development container", s.Namespace),
+		}
+	}
+
+	oktetoLog.Infof("attaching to the session of '%s' (pid %d)", s.Name, s.PID)
+	return ssh.Exec(ctx, s.Interface, s.RemotePort, true, os.Stdin, os.Stdout, os.Stderr, s.Command)
+}
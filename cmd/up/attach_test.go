@@ -0,0 +1,115 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/session"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSessions = []session.Session{
+	{Name: "api", Namespace: "dev", Context: "okteto", State: config.Ready, PID: 10},
+	{Name: "api", Namespace: "staging", Context: "okteto", State: config.Ready, PID: 11},
+	{Name: "web", Namespace: "dev", Context: "okteto", State: config.Synchronizing, PID: 12},
+}
+
+func listTestSessions(sessions []session.Session) listSessionsFn {
+	return func(context.Context) ([]session.Session, error) {
+		return sessions, nil
+	}
+}
+
+func TestGetAttachSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		devName     string
+		namespace   string
+		sessions    []session.Session
+		expectedPID int
+		expectErr   bool
+	}{
+		{
+			name:        "single-session",
+			sessions:    testSessions[2:],
+			expectedPID: 12,
+		},
+		{
+			name:        "by-name",
+			devName:     "web",
+			sessions:    testSessions,
+			expectedPID: 12,
+		},
+		{
+			name:        "by-name-and-namespace",
+			devName:     "api",
+			namespace:   "staging",
+			sessions:    testSessions,
+			expectedPID: 11,
+		},
+		{
+			name:      "ambiguous",
+			devName:   "api",
+			sessions:  testSessions,
+			expectErr: true,
+		},
+		{
+			name:      "not-found",
+			devName:   "worker",
+			sessions:  testSessions,
+			expectErr: true,
+		},
+		{
+			name:      "no-sessions",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := getAttachSession(context.Background(), tt.devName, tt.namespace, listTestSessions(tt.sessions))
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPID, s.PID)
+		})
+	}
+}
+
+func TestAttachNotReady(t *testing.T) {
+	err := attach(context.Background(), &testSessions[2])
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
+
+func TestAttachContainer(t *testing.T) {
+	original := execInSessionContainer
+	defer func() { execInSessionContainer = original }()
+
+	var attached *session.Session
+	execInSessionContainer = func(_ context.Context, s *session.Session) error {
+		attached = s
+		return nil
+	}
+
+	s := &session.Session{Name: "api", Namespace: "dev", State: config.Ready, Pod: "api-okteto-123", Container: "sidecar", Command: []string{"bash"}}
+	require.NoError(t, attach(context.Background(), s))
+	assert.Equal(t, s, attached)
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/session"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const (
+	// detachedSessionEnvVar is set in the environment of the daemon that runs a detached 'okteto up' session
+	detachedSessionEnvVar = "OKTETO_UP_DETACHED_SESSION"
+
+	daemonLogFile = "daemon.log"
+)
+
+// isDaemon returns true if the current process is the daemon of a detached session
+func isDaemon() bool {
+	return os.Getenv(detachedSessionEnvVar) == "true"
+}
+
+// detach starts the daemon that runs the 'okteto up' session of dev in the background and waits until it's ready
+func detach(ctx context.Context, dev *model.Dev, upOptions *UpOptions) error {
+	if dev.IsHybridModeEnabled() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'--detach' is not supported in hybrid mode"),
+			Hint: "Run 'okteto up' without '--detach'",
+		}
+	}
+	if s, err := session.Get(ctx, dev.Namespace, dev.Name); err == nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("development container '%s' is already running in a detached session (pid %d)", dev.Name, s.PID),
+			Hint: fmt.Sprintf("Run 'okteto attach %s' to open a terminal in your development container", dev.Name),
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the okteto binary: %w", err)
+	}
+	logPath := filepath.Join(config.GetAppHome(dev.Namespace, dev.Name), daemonLogFile)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the daemon log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, getDaemonArgs(dev, upOptions)...)
+	// the daemon resolves the manifest path from the directory where the user ran the command
+	cmd.Dir = upOptions.initialCWD
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=true", detachedSessionEnvVar))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = daemonSysProcAttr()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the okteto up daemon: %w", err)
+	}
+	oktetoLog.Infof("okteto up daemon started with pid %d", cmd.Process.Pid)
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	oktetoLog.Spinner("Activating your development container in the background...")
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			oktetoLog.Infof("CTRL+C received, stopping the okteto up daemon")
+			if err := session.Stop(ctx, dev.Namespace, dev.Name); err != nil {
+				oktetoLog.Infof("failed to stop the daemon session: %s", err)
+				if err := cmd.Process.Kill(); err != nil {
+					oktetoLog.Infof("failed to kill the daemon: %s", err)
+				}
+			}
+			return oktetoErrors.ErrIntSig
+		case err := <-exited:
+			oktetoLog.Infof("okteto up daemon exited: %v", err)
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the detached session of '%s' exited before your development container was ready", dev.Name),
+				Hint: fmt.Sprintf("Find the logs of the session at: %s", logPath),
+			}
+		case <-ticker.C:
+			s, err := session.Get(ctx, dev.Namespace, dev.Name)
+			if err != nil {
+				if !errors.Is(err, session.ErrNotFound) {
+					oktetoLog.Infof("failed to get the daemon session: %s", err)
+				}
+				continue
+			}
+			if s.State != config.Ready {
+				continue
+			}
+			oktetoLog.StopSpinner()
+			oktetoLog.Success("Development container '%s' is running in the background (pid %d)", dev.Name, s.PID)
+			oktetoLog.Information("Run 'okteto attach %s' to open a terminal in your development container", dev.Name)
+			oktetoLog.Information("Run 'okteto up ls' to list your detached sessions and 'okteto down %s' to stop it", dev.Name)
+			return nil
+		}
+	}
+}
+
+// getDaemonArgs returns the arguments of the 'okteto up' command run by the daemon
+func getDaemonArgs(dev *model.Dev, upOptions *UpOptions) []string {
+	args := []string{"up", dev.Name, "--detach"}
+	if upOptions.ManifestPathFlag != "" {
+		args = append(args, "--file", upOptions.ManifestPathFlag)
+	}
+	if upOptions.Namespace != "" {
+		args = append(args, "--namespace", upOptions.Namespace)
+	}
+	if upOptions.K8sContext != "" {
+		args = append(args, "--context", upOptions.K8sContext)
+	}
+	for _, e := range upOptions.Envs {
+		args = append(args, "--env", e)
+	}
+	if upOptions.Remote > 0 {
+		args = append(args, "--remote", strconv.Itoa(upOptions.Remote))
+	}
+	if upOptions.Deploy {
+		args = append(args, "--deploy")
+	}
+	if upOptions.ForcePull {
+		args = append(args, "--pull")
+	}
+	if upOptions.Reset {
+		args = append(args, "--reset")
+	}
+	for _, c := range upOptions.commandToExecute {
+		args = append(args, "--command", c)
+	}
+	if upOptions.Container != "" {
+		args = append(args, "--container", upOptions.Container)
+	}
+	return args
+}
+
+// serveSession exposes the session on its socket while the daemon runs. Stop requests are handled as an interrupt
+func (up *upContext) serveSession(stop chan os.Signal) (*session.Server, error) {
+	return session.Serve(up.Dev.Namespace, up.Dev.Name, up.getSession, func() {
+		select {
+		case stop <- syscall.SIGTERM:
+		default:
+		}
+	})
+}
+
+// getSession returns the current status of the detached session
+func (up *upContext) getSession() session.Session {
+	state, err := config.GetState(up.Dev.Name, up.Dev.Namespace)
+	if err != nil {
+		state = config.Activating
+	}
+	s := session.Session{
+		Name:       up.Dev.Name,
+		Namespace:  up.Dev.Namespace,
+		Context:    okteto.Context().Name,
+		State:      state,
+		PID:        os.Getpid(),
+		StartTime:  up.StartTime,
+		Interface:  up.Dev.Interface,
+		RemotePort: up.Dev.RemotePort,
+		Command:    up.getTerminalCommand(),
+	}
+	if up.Pod != nil {
+		s.Pod = up.Pod.Name
+	}
+	if container := up.getAttachedContainer(); container != up.Dev.Container {
+		s.Container = container
+	}
+	return s
+}
+
+// isDetached returns true if the session runs in the background without a terminal
+func (up *upContext) isDetached() bool {
+	return up.Options != nil && up.Options.Detach
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDaemonArgs(t *testing.T) {
+	tests := []struct {
+		options  *UpOptions
+		name     string
+		expected []string
+	}{
+		{
+			name:     "defaults",
+			options:  &UpOptions{},
+			expected: []string{"up", "api", "--detach"},
+		},
+		{
+			name: "all-options",
+			options: &UpOptions{
+				ManifestPath:     "/home/user/app/okteto.yml",
+				ManifestPathFlag: "app/okteto.yml",
+				Namespace:        "ns",
+				K8sContext:       "ctx",
+				Envs:             []string{"A=1", "B=2"},
+				Remote:           2222,
+				Reset:            true,
+				commandToExecute: []string{"make", "run"},
+				Container:        "sidecar",
+				Deploy:           true,
+				ForcePull:        true,
+			},
+			expected: []string{
+				"up", "api", "--detach",
+				"--file", "app/okteto.yml",
+				"--namespace", "ns",
+				"--context", "ctx",
+				"--env", "A=1", "--env", "B=2",
+				"--remote", "2222",
+				"--deploy",
+				"--pull",
+				"--reset",
+				"--command", "make", "--command", "run",
+				"--container", "sidecar",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getDaemonArgs(&model.Dev{Name: "api"}, tt.options))
+		})
+	}
+}
+
+func TestIsDaemon(t *testing.T) {
+	assert.False(t, isDaemon())
+	t.Setenv(detachedSessionEnvVar, "true")
+	assert.True(t, isDaemon())
+}
+
+func TestGetSessionContainer(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	okteto.CurrentStore = &okteto.OktetoContextStore{
+		Contexts: map[string]*okteto.OktetoContext{
+			"test": {Name: "test"},
+		},
+		CurrentContext: "test",
+	}
+
+	dev := &model.Dev{Name: "api", Namespace: "dev", Container: "api"}
+	pod := &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-okteto-123"}}
+
+	up := &upContext{Dev: dev, Pod: pod, Options: &UpOptions{}}
+	s := up.getSession()
+	assert.Equal(t, "api-okteto-123", s.Pod)
+	assert.Empty(t, s.Container)
+
+	up.Options.Container = "sidecar"
+	assert.Equal(t, "sidecar", up.getSession().Container)
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package up
+
+import (
+	"syscall"
+)
+
+// daemonSysProcAttr starts the daemon in its own session so it isn't stopped with the terminal
+func daemonSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
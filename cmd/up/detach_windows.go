@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package up
+
+import (
+	"syscall"
+)
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+)
+
+// daemonSysProcAttr starts the daemon without a console so it isn't stopped with the terminal
+func daemonSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: createNewProcessGroup | detachedProcess}
+}
@@ -408,6 +408,25 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 		return err
 	}
 
+	container := up.getAttachedContainer()
+	if container != up.Dev.Container {
+		// the SSH server only runs in the main development container
+		oktetoLog.Infof("attaching to container '%s'", container)
+		return k8sExec.Exec(
+			ctx,
+			k8sClient,
+			restConfig,
+			up.Dev.Namespace,
+			up.Pod.Name,
+			container,
+			true,
+			os.Stdin,
+			os.Stdout,
+			os.Stderr,
+			cmd,
+		)
+	}
+
 	if up.Dev.RemoteModeEnabled() {
 		if up.Dev.IsHybridModeEnabled() {
 			hybridCtx := &HybridExecCtx{
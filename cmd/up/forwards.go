@@ -25,7 +25,6 @@ import (
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/ssh"
-	"github.com/okteto/okteto/pkg/syncthing"
 )
 
 func (up *upContext) forwards(ctx context.Context) error {
@@ -63,12 +62,10 @@ func (up *upContext) forwards(ctx context.Context) error {
 		}
 	}
 
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
-		return err
-	}
-
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
-		return err
+	for _, f := range up.Sy.GetForwards() {
+		if err := up.Forwarder.Add(f); err != nil {
+			return err
+		}
 	}
 
 	err = up.Forwarder.Start(up.Pod.Name, up.Dev.Namespace)
@@ -97,12 +94,10 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 	}
 
 	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Dev.Namespace)
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
-		return err
-	}
-
-	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemoteGUIPort, Remote: syncthing.GUIPort}); err != nil {
-		return err
+	for _, f := range up.Sy.GetForwards() {
+		if err := up.Forwarder.Add(f); err != nil {
+			return err
+		}
 	}
 
 	if err := addToForwarder(up); err != nil {
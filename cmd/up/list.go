@@ -0,0 +1,87 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/session"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type listSessionsFn func(ctx context.Context) ([]session.Session, error)
+
+// list lists the detached 'okteto up' sessions
+func list() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List your detached development container sessions",
+		Args:    utils.NoArgsAccepted("https://okteto.com/docs/reference/cli/#up"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeListSessions(context.Background(), output, session.List, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json', 'yaml']")
+	return cmd
+}
+
+func executeListSessions(ctx context.Context, output string, listSessions listSessionsFn, w io.Writer) error {
+	sessions, err := listSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		bytes, err := json.MarshalIndent(sessions, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	case "yaml":
+		bytes, err := yaml.Marshal(sessions)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(bytes))
+	default:
+		if len(sessions) == 0 {
+			oktetoLog.Information("There are no detached sessions. Run 'okteto up --detach' to start one")
+			return nil
+		}
+		tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+		cols := []string{"Name", "Namespace", "Context", "State", "PID", "Age"}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+		for _, s := range sessions {
+			age := duration.HumanDuration(time.Since(s.StartTime))
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Name, s.Namespace, s.Context, s.State, strconv.Itoa(s.PID), age)
+		}
+		tw.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/session"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteListSessions(t *testing.T) {
+	sessions := []session.Session{
+		{Name: "api", Namespace: "dev", Context: "okteto", State: config.Ready, PID: 10, StartTime: time.Now().Add(-5 * time.Minute)},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, executeListSessions(context.Background(), "", listTestSessions(sessions), &b))
+	assert.Equal(t, "Name  Namespace  Context  State  PID  Age\napi   dev        okteto   ready  10   5m\n", b.String())
+
+	b.Reset()
+	require.NoError(t, executeListSessions(context.Background(), "json", listTestSessions(sessions), &b))
+	assert.Contains(t, b.String(), `"name": "api"`)
+}
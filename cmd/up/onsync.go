@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const (
+	onSyncPollInterval = 1 * time.Second
+)
+
+// runOnSyncCommand runs an 'onSync' command in the development container
+var runOnSyncCommand = func(ctx context.Context, dev *model.Dev, command string, out io.Writer) error {
+	return ssh.Run(ctx, dev.Interface, dev.RemotePort, command, out)
+}
+
+type syncedFilesGetter interface {
+	GetNewSyncedFiles(ctx context.Context) ([]syncthing.SyncedFile, error)
+}
+
+// monitorOnSync runs the 'onSync' commands when the synchronized files matching their globs change
+func (up *upContext) monitorOnSync(ctx context.Context) {
+	sy, ok := up.Sy.(*syncthing.Syncthing)
+	if !ok {
+		oktetoLog.Warning("'onSync' is only supported with the syncthing sync backend")
+		return
+	}
+	if !up.Dev.RemoteModeEnabled() {
+		oktetoLog.Warning("'onSync' requires SSH access to your development container")
+		return
+	}
+	up.dispatchOnSync(ctx, sy, onSyncPollInterval)
+}
+
+// dispatchOnSync notifies the hook of each 'onSync' rule when a synchronized file matches it
+func (up *upContext) dispatchOnSync(ctx context.Context, sy syncedFilesGetter, interval time.Duration) {
+	hooks := make([]chan struct{}, len(up.Dev.OnSync))
+	for i := range up.Dev.OnSync {
+		hooks[i] = make(chan struct{}, 1)
+		go up.runOnSyncHook(ctx, up.Dev.OnSync[i], hooks[i])
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			files, err := sy.GetNewSyncedFiles(ctx)
+			if err != nil {
+				oktetoLog.Infof("failed to get synchronized files: %s", err)
+				continue
+			}
+			for i := range up.Dev.OnSync {
+				if !matchesAnyFile(&up.Dev.OnSync[i], files) {
+					continue
+				}
+				select {
+				case hooks[i] <- struct{}{}:
+				default:
+					// the hook is already notified
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func matchesAnyFile(rule *model.OnSyncRule, files []syncthing.SyncedFile) bool {
+	for _, f := range files {
+		if rule.Matches(f.Path) {
+			oktetoLog.Infof("'%s' matches the onSync glob '%s'", f.Path, rule.Match)
+			return true
+		}
+	}
+	return false
+}
+
+// runOnSyncHook runs the command of an 'onSync' rule once no more changes are notified for its debounce time
+func (up *upContext) runOnSyncHook(ctx context.Context, rule model.OnSyncRule, changed <-chan struct{}) {
+	for {
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return
+		}
+
+		timer := time.NewTimer(rule.Debounce)
+		for debouncing := true; debouncing; {
+			select {
+			case <-changed:
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(rule.Debounce)
+			case <-timer.C:
+				debouncing = false
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		out := &bytes.Buffer{}
+		start := time.Now()
+		err := runOnSyncCommand(ctx, up.Dev, rule.Command, out)
+		if ctx.Err() != nil {
+			return
+		}
+		output := strings.TrimSpace(out.String())
+		oktetoLog.Infof("onSync command '%s' finished in %s: %v\n%s", rule.Command, time.Since(start), err, output)
+		if err != nil {
+			oktetoLog.Warning("onSync command '%s' failed: %s\n%s", rule.Command, err, output)
+		}
+	}
+}
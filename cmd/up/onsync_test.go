@@ -0,0 +1,89 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSyncedFilesGetter struct {
+	files [][]syncthing.SyncedFile
+	mu    sync.Mutex
+}
+
+func (f *fakeSyncedFilesGetter) GetNewSyncedFiles(context.Context) ([]syncthing.SyncedFile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.files) == 0 {
+		return []syncthing.SyncedFile{}, nil
+	}
+	result := f.files[0]
+	f.files = f.files[1:]
+	return result, nil
+}
+
+func TestDispatchOnSync(t *testing.T) {
+	var mu sync.Mutex
+	executed := []string{}
+	original := runOnSyncCommand
+	defer func() {
+		runOnSyncCommand = original
+	}()
+	runOnSyncCommand = func(_ context.Context, _ *model.Dev, command string, _ io.Writer) error {
+		mu.Lock()
+		defer mu.Unlock()
+		executed = append(executed, command)
+		return nil
+	}
+
+	up := &upContext{
+		Dev: &model.Dev{
+			OnSync: []model.OnSyncRule{
+				{Match: "**/*.go", Command: "go build", Debounce: 50 * time.Millisecond},
+				{Match: "package.json", Command: "npm install", Debounce: 50 * time.Millisecond},
+			},
+		},
+	}
+	getter := &fakeSyncedFilesGetter{
+		files: [][]syncthing.SyncedFile{
+			{{Folder: "/app", Path: "main.go"}},
+			{{Folder: "/app", Path: "pkg/handler.go"}, {Folder: "/app", Path: "README.md"}},
+			{{Folder: "/app", Path: "web/package.json"}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go up.dispatchOnSync(ctx, getter, 5*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(executed) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// the changes of consecutive polls are debounced into a single run, and non matching files are ignored
+	time.Sleep(200 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"go build"}, executed)
+}
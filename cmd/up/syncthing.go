@@ -15,13 +15,17 @@ package up
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/synchronizer"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/spf13/afero"
 )
@@ -31,7 +35,18 @@ const (
 	totalProgressValue      = 100
 )
 
-func (up *upContext) initializeSyncthing() error {
+func (up *upContext) initializeSynchronizer() error {
+	if up.Dev.GetSyncBackend() == model.SyncBackendSSH {
+		sy, err := synchronizer.NewSSH(up.Dev)
+		if err != nil {
+			return err
+		}
+		up.Sy = sy
+		oktetoLog.Infof("ssh synchronizer initialized")
+		up.hardTerminate <- up.Sy.HardTerminate()
+		return nil
+	}
+
 	sy, err := syncthing.New(up.Dev)
 	if err != nil {
 		return err
@@ -39,10 +54,10 @@ func (up *upContext) initializeSyncthing() error {
 	sy.ResetDatabase = up.resetSyncthing
 	up.Sy = sy
 
-	oktetoLog.Infof("local syncthing initialized: gui -> %d, sync -> %d", up.Sy.LocalGUIPort, up.Sy.LocalPort)
-	oktetoLog.Infof("remote syncthing initialized: gui -> %d, sync -> %d", up.Sy.RemoteGUIPort, up.Sy.RemotePort)
+	oktetoLog.Infof("local syncthing initialized: gui -> %d, sync -> %d", sy.LocalGUIPort, sy.LocalPort)
+	oktetoLog.Infof("remote syncthing initialized: gui -> %d, sync -> %d", sy.RemoteGUIPort, sy.RemotePort)
 
-	if err := up.Sy.SaveConfig(up.Dev); err != nil {
+	if err := sy.SaveConfig(up.Dev); err != nil {
 		oktetoLog.Infof("error saving syncthing object: %s", err)
 	}
 
@@ -52,7 +67,7 @@ func (up *upContext) initializeSyncthing() error {
 }
 
 func (up *upContext) sync(ctx context.Context) error {
-	if err := up.startSyncthing(ctx); err != nil {
+	if err := up.startSynchronizer(ctx); err != nil {
 		return err
 	}
 
@@ -83,19 +98,62 @@ func (up *upContext) sync(ctx context.Context) error {
     More information is available here: https://okteto.com/docs/reference/file-synchronization/`, elapsedString)
 	}
 
-	up.Sy.Type = "sendreceive"
-	up.Sy.IgnoreDelete = false
-	if err := up.Sy.UpdateConfig(); err != nil {
+	go up.Sy.Monitor(ctx, up.Disconnect)
+	go up.Sy.MonitorStatus(ctx, up.Disconnect)
+	if err := up.Sy.StartSendReceive(ctx); err != nil {
 		return err
 	}
 
-	go up.Sy.Monitor(ctx, up.Disconnect)
-	go up.Sy.MonitorStatus(ctx, up.Disconnect)
-	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
-	return up.Sy.Restart(ctx)
+	if up.Dev.Sync.KeepConflicts {
+		go up.monitorSyncConflicts(ctx)
+	}
+
+	if len(up.Dev.OnSync) > 0 {
+		go up.monitorOnSync(ctx)
+	}
+	return nil
+}
+
+// monitorSyncConflicts warns about the files modified at the same time in the local folder and in the development container
+func (up *upContext) monitorSyncConflicts(ctx context.Context) {
+	sy, ok := up.Sy.(*syncthing.Syncthing)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			conflicts, err := sy.GetNewConflicts(ctx)
+			if err != nil {
+				oktetoLog.Infof("failed to get sync conflicts: %s", err)
+				continue
+			}
+			for _, c := range conflicts {
+				oktetoLog.Warning("Sync conflict in '%s': the %s version was saved in '%s'. Run 'okteto sync conflicts' to resolve it", filepath.Join(c.Folder, c.Path), c.ModifiedBy, c.ConflictPath)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// getRemoteSyncthingConfig returns the configuration of the syncthing running in the development container.
+// With other sync backends it has no folders, so the remote syncthing doesn't synchronize anything
+func (up *upContext) getRemoteSyncthingConfig() (*syncthing.Syncthing, error) {
+	if sy, ok := up.Sy.(*syncthing.Syncthing); ok {
+		return sy, nil
+	}
+	sy, err := syncthing.New(up.Dev)
+	if err != nil {
+		return nil, err
+	}
+	sy.Folders = []*syncthing.Folder{}
+	return sy, nil
 }
 
-func (up *upContext) startSyncthing(ctx context.Context) error {
+func (up *upContext) startSynchronizer(ctx context.Context) error {
 	if !up.Dev.IsHybridModeEnabled() {
 		oktetoLog.Spinner("Starting the file synchronization service...")
 		oktetoLog.StartSpinner()
@@ -116,7 +174,8 @@ func (up *upContext) startSyncthing(ctx context.Context) error {
 
 	if err := up.Sy.WaitForPing(ctx, false); err != nil {
 		oktetoLog.Infof("failed to ping syncthing: %s", err.Error())
-		if oktetoErrors.IsTransient(err) {
+		var userErr oktetoErrors.UserError
+		if oktetoErrors.IsTransient(err) || errors.As(err, &userErr) {
 			return err
 		}
 		return up.checkOktetoStartError(ctx, "Failed to connect to the synchronization service")
@@ -24,7 +24,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
-	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/synchronizer"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	apiv1 "k8s.io/api/core/v1"
@@ -62,7 +62,7 @@ type upContext struct {
 	stateTerm             *term.State
 	CommandResult         chan error
 	Exit                  chan error
-	Sy                    *syncthing.Syncthing
+	Sy                    synchronizer.Synchronizer
 	cleaned               chan string
 	hardTerminate         chan error
 	Translations          map[string]*apps.Translation
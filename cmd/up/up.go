@@ -44,6 +44,7 @@ import (
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/linguist"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
@@ -55,8 +56,10 @@ import (
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	cryptoSSH "golang.org/x/crypto/ssh"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	k8sExecUtil "k8s.io/client-go/util/exec"
 )
 
 // ReconnectingMessage is the message shown when we are trying to reconnect
@@ -77,16 +80,20 @@ type UpOptions struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath     string
+	ManifestPath string
+	// initialCWD is the working directory where the command was executed, before moving to the manifest dir
+	initialCWD       string
 	Namespace        string
 	K8sContext       string
 	DevName          string
+	Container        string
 	Envs             []string
 	commandToExecute []string
 	Remote           int
 	Deploy           bool
 	ForcePull        bool
 	Reset            bool
+	Detach           bool
 }
 
 // Up starts a development container
@@ -134,6 +141,7 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("failed to get the current working directory: %w", err)
 				}
+				upOptions.initialCWD = initialCWD
 				manifestPathFlag, err := oktetoPath.GetRelativePathFromCWD(initialCWD, upOptions.ManifestPath)
 				if err != nil {
 					return err
@@ -285,7 +293,10 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 			// if manifest v1 - either set autocreate: true or pass --deploy (okteto forces autocreate: true)
 			// if manifest v2 - either set autocreate: true or pass --deploy with a deploy section at the manifest
 			forceAutocreate := false
-			if upOptions.Deploy && !up.Manifest.IsV2 {
+			if upOptions.Detach && !isDaemon() {
+				// the daemon of the detached session runs the deploy, so the commands are executed only once
+				oktetoLog.Info("the deploy is delegated to the okteto up daemon")
+			} else if upOptions.Deploy && !up.Manifest.IsV2 {
 				// the autocreate property is forced to be true
 				forceAutocreate = true
 			} else if upOptions.Deploy || (up.Manifest.IsV2 && !pipeline.IsDeployed(ctx, up.Manifest.Name, up.Manifest.Namespace, k8sClient)) {
@@ -319,6 +330,14 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				return fmt.Errorf("error in 'dev' section of your manifest: %w", err)
 			}
 
+			if err := setDebugger(dev, oktetoManifest.ManifestPath); err != nil {
+				return err
+			}
+
+			if upOptions.Detach && !isDaemon() {
+				return detach(ctx, dev, upOptions)
+			}
+
 			up.Dev = dev
 			if forceAutocreate {
 				// update autocreate property if needed to be forced
@@ -351,7 +370,7 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 				return err
 			}
 
-			if syncthing.ShouldUpgrade() {
+			if dev.GetSyncBackend() == model.SyncBackendSyncthing && syncthing.ShouldUpgrade() {
 				oktetoLog.Println("Installing dependencies...")
 				if err := downloadSyncthing(); err != nil {
 					oktetoLog.Infof("failed to upgrade syncthing: %s", err)
@@ -424,6 +443,9 @@ func Up(at analyticsTrackerInterface, ioCtrl *io.IOController) *cobra.Command {
 	}
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "reset the file synchronization database")
 	cmd.Flags().StringArrayVarP(&upOptions.commandToExecute, "command", "", []string{}, "external commands to be supplied to 'okteto up'")
+	cmd.Flags().StringVarP(&upOptions.Container, "container", "", "", "container of the development pod the terminal is attached to (defaults to the main development container)")
+	cmd.Flags().BoolVarP(&upOptions.Detach, "detach", "", false, "run the development container session in the background. Use 'okteto attach' to open a terminal")
+	cmd.AddCommand(list())
 	return cmd
 }
 
@@ -656,6 +678,18 @@ func (up *upContext) start() error {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	if up.isDetached() {
+		server, err := up.serveSession(stop)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := server.Close(); err != nil {
+				oktetoLog.Infof("failed to close the session server: %s", err)
+			}
+		}()
+	}
+
 	pidFileCh := make(chan error, 1)
 
 	up.analyticsMeta.ManifestProps(up.Manifest)
@@ -881,6 +915,31 @@ func (up *upContext) buildDevImage(ctx context.Context, app apps.App) error {
 	return nil
 }
 
+// setDebugger starts the command of the dev container with its remote debugger and writes the launch configurations to attach to it
+func setDebugger(dev *model.Dev, manifestPath string) error {
+	if dev.Debug == nil {
+		return nil
+	}
+	if dev.IsHybridModeEnabled() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'debug' is not supported in hybrid mode"),
+			Hint: "Start the debugger locally or remove the 'debug' section from your okteto manifest",
+		}
+	}
+	if err := linguist.SetDebugDefaults(dev); err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return err
+	}
+	if err := linguist.WriteDebugLaunchConfigs(dev, dir); err != nil {
+		oktetoLog.Warning("Could not write the debugger launch configurations: %s", err.Error())
+	}
+	return nil
+}
+
 func (up *upContext) setDevContainer(app apps.App) error {
 	devContainer := apps.GetDevContainer(app.PodSpec(), up.Dev.Container)
 	if devContainer == nil {
@@ -893,9 +952,107 @@ func (up *upContext) setDevContainer(app apps.App) error {
 		up.Dev.Image.Name = devContainer.Image
 	}
 
+	return up.validateAttachedContainer()
+}
+
+// validateAttachedContainer checks that the container selected with '--container' is in dev mode
+func (up *upContext) validateAttachedContainer() error {
+	if up.Options == nil || up.Options.Container == "" || up.Options.Container == up.Dev.Container {
+		return nil
+	}
+	if !up.Dev.HasContainer(up.Options.Container) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("container '%s' is not in dev mode", up.Options.Container),
+			Hint: fmt.Sprintf("Add it to the 'containers' field of '%s' in your okteto manifest and try again", up.Dev.Name),
+		}
+	}
+	if up.Dev.IsHybridModeEnabled() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'--container' is not supported in hybrid mode"),
+			Hint: "In hybrid mode your terminal runs on your local machine",
+		}
+	}
 	return nil
 }
 
+// getAttachedContainer returns the container of the development pod the terminal is attached to
+func (up *upContext) getAttachedContainer() string {
+	if up.Options == nil || up.Options.Container == "" {
+		return up.Dev.Container
+	}
+	return up.Options.Container
+}
+
+// runTerminal runs the command of the development container in the terminal.
+// When the development pod has more containers in dev mode, the terminal can be attached to another container
+// once the command exits, without restarting the session
+func (up *upContext) runTerminal(ctx context.Context, runCommand func(context.Context, []string) error) error {
+	for {
+		err := runCommand(ctx, up.getTerminalCommand())
+		if err != nil && !isExitStatusError(err) {
+			return err
+		}
+		if len(up.Dev.Containers) == 0 || up.Options == nil || ctx.Err() != nil || !isStdinTerminal() {
+			return err
+		}
+		if err != nil {
+			oktetoLog.Infof("terminal command exited: %s", err)
+		}
+
+		container, selectErr := selectAttachedContainer(up.Dev, up.getAttachedContainer())
+		if selectErr != nil {
+			return selectErr
+		}
+		if container == exitTerminalOption {
+			return err
+		}
+		up.Options.Container = container
+		oktetoLog.Success("Terminal attached to container '%s'", container)
+	}
+}
+
+// defaultTerminalShell is the command run in the terminal of the additional containers of the development pod
+const defaultTerminalShell = "sh"
+
+// getTerminalCommand returns the command run in the terminal of the attached container.
+// The command of an additional container is its main process and its image might not have the one of the main container,
+// so a shell is opened instead
+func (up *upContext) getTerminalCommand() []string {
+	if up.getAttachedContainer() != up.Dev.Container {
+		return []string{defaultTerminalShell}
+	}
+	return up.Dev.Command.Values
+}
+
+// isExitStatusError returns true if the terminal command ran and exited with a non-zero status
+func isExitStatusError(err error) bool {
+	var sshExitErr *cryptoSSH.ExitError
+	var execExitErr k8sExecUtil.ExitError
+	return errors.As(err, &sshExitErr) || errors.As(err, &execExitErr)
+}
+
+var isStdinTerminal = func() bool {
+	return term.IsTerminal(os.Stdin.Fd())
+}
+
+// exitTerminalOption is the option of the container selector to exit 'okteto up'. It is not a valid container name
+const exitTerminalOption = "[exit]"
+
+// selectAttachedContainer asks for the container of the development pod the terminal is attached to
+var selectAttachedContainer = func(dev *model.Dev, attached string) (string, error) {
+	options := []utils.SelectorItem{}
+	initialPosition := 0
+	for i, name := range append([]string{dev.Container}, dev.ContainerNames()...) {
+		if name == attached {
+			initialPosition = i
+		}
+		options = append(options, utils.SelectorItem{Name: name, Label: name, Enable: true})
+	}
+	options = append(options, utils.SelectorItem{Name: exitTerminalOption, Label: "Exit okteto up", Enable: true})
+	selector := utils.NewOktetoSelector("The command exited. Select the container to attach the terminal to:", "Container")
+	return selector.AskForOptionsOkteto(options, initialPosition)
+}
+
 func (up *upContext) getInsufficientSpaceError(err error) error {
 	if up.Dev.PersistentVolumeEnabled() {
 
@@ -1061,6 +1218,19 @@ func printDisplayContext(up *upContext) {
 		}
 	}
 
+	if len(up.Dev.Containers) > 0 {
+		attached := up.getAttachedContainer()
+		label := fmt.Sprintf("    %s", oktetoLog.BlueString("Container:"))
+		for _, name := range append([]string{up.Dev.Container}, up.Dev.ContainerNames()...) {
+			if name == attached {
+				name = fmt.Sprintf("%s (attached)", name)
+			}
+			oktetoLog.Println(fmt.Sprintf("%s %s", label, name))
+			label = "              "
+		}
+		oktetoLog.Println(fmt.Sprintf("    %s", oktetoLog.BlueString("Exit the command to attach the terminal to another container")))
+	}
+
 	oktetoLog.Println()
 }
 
@@ -36,6 +36,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/clientcmd/api"
+	k8sExecUtil "k8s.io/client-go/util/exec"
 )
 
 func Test_waitUntilExitOrInterrupt(t *testing.T) {
@@ -748,3 +749,157 @@ func Test_buildServicesAndSetBuildEnvs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAttachedContainer(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "api",
+		Container: "api",
+		Containers: model.DevContainers{
+			"worker": {},
+		},
+	}
+	var tests = []struct {
+		name             string
+		container        string
+		expectedAttached string
+		expectedErr      bool
+	}{
+		{
+			name:             "default",
+			expectedAttached: "api",
+		},
+		{
+			name:             "main-container",
+			container:        "api",
+			expectedAttached: "api",
+		},
+		{
+			name:             "additional-container",
+			container:        "worker",
+			expectedAttached: "worker",
+		},
+		{
+			name:        "container-not-in-dev-mode",
+			container:   "db",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{
+				Dev:     dev,
+				Options: &UpOptions{Container: tt.container},
+			}
+			err := up.validateAttachedContainer()
+			if tt.expectedErr {
+				var userErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &userErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttached, up.getAttachedContainer())
+		})
+	}
+}
+
+func TestRunTerminalSwitchesContainers(t *testing.T) {
+	originalIsStdinTerminal := isStdinTerminal
+	originalSelect := selectAttachedContainer
+	defer func() {
+		isStdinTerminal = originalIsStdinTerminal
+		selectAttachedContainer = originalSelect
+	}()
+	isStdinTerminal = func() bool { return true }
+	selections := []string{"worker", "api", exitTerminalOption}
+	selectAttachedContainer = func(dev *model.Dev, attached string) (string, error) {
+		selection := selections[0]
+		selections = selections[1:]
+		return selection, nil
+	}
+
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:       "api",
+			Container:  "api",
+			Containers: model.DevContainers{"worker": {}},
+			Command:    model.Command{Values: []string{"bash"}},
+		},
+		Options: &UpOptions{},
+	}
+	attached := []string{}
+	commands := [][]string{}
+	err := up.runTerminal(context.Background(), func(ctx context.Context, cmd []string) error {
+		attached = append(attached, up.getAttachedContainer())
+		commands = append(commands, cmd)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "worker", "api"}, attached)
+	// additional containers run a shell, their image might not have the command of the main container
+	assert.Equal(t, [][]string{{"bash"}, {"sh"}, {"bash"}}, commands)
+	assert.Empty(t, selections)
+}
+
+func TestRunTerminalSwitchesContainersAfterFailure(t *testing.T) {
+	originalIsStdinTerminal := isStdinTerminal
+	originalSelect := selectAttachedContainer
+	defer func() {
+		isStdinTerminal = originalIsStdinTerminal
+		selectAttachedContainer = originalSelect
+	}()
+	isStdinTerminal = func() bool { return true }
+	selections := []string{"worker", exitTerminalOption}
+	selectAttachedContainer = func(dev *model.Dev, attached string) (string, error) {
+		selection := selections[0]
+		selections = selections[1:]
+		return selection, nil
+	}
+
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:       "api",
+			Container:  "api",
+			Containers: model.DevContainers{"worker": {}},
+			Command:    model.Command{Values: []string{"bash"}},
+		},
+		Options: &UpOptions{},
+	}
+	exitErr := k8sExecUtil.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1}
+	attached := []string{}
+	err := up.runTerminal(context.Background(), func(ctx context.Context, cmd []string) error {
+		attached = append(attached, up.getAttachedContainer())
+		return exitErr
+	})
+	// the exit status of the last command is returned when exiting
+	assert.ErrorIs(t, err, exitErr)
+	assert.Equal(t, []string{"api", "worker"}, attached)
+	assert.Empty(t, selections)
+}
+
+func TestRunTerminalWithoutContainers(t *testing.T) {
+	originalIsStdinTerminal := isStdinTerminal
+	defer func() {
+		isStdinTerminal = originalIsStdinTerminal
+	}()
+	isStdinTerminal = func() bool { return true }
+
+	up := &upContext{
+		Dev:     &model.Dev{Name: "api", Container: "api"},
+		Options: &UpOptions{},
+	}
+	runs := 0
+	err := up.runTerminal(context.Background(), func(ctx context.Context, cmd []string) error {
+		runs++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, runs)
+
+	commandErr := errors.New("connection lost")
+	up.Dev.Containers = model.DevContainers{"worker": {}}
+	err = up.runTerminal(context.Background(), func(ctx context.Context, cmd []string) error {
+		return commandErr
+	})
+	assert.ErrorIs(t, err, commandErr)
+}
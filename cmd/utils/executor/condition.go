@@ -0,0 +1,81 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/a8m/envsubst/parse"
+)
+
+var falsyConditionValues = map[string]bool{
+	"":      true,
+	"0":     true,
+	"false": true,
+	"no":    true,
+}
+
+// evaluateCondition evaluates a 'when' expression against the given environment.
+// Supported expressions are 'A == B', 'A != B', '!A' and 'A', where a single value is true unless
+// it is empty, "0", "false" or "no". The expression is parsed before expanding the variables of each operand,
+// so the values of the variables can't change the expression
+func evaluateCondition(condition string, env []string) (bool, error) {
+	// the parser uses the first value found for a variable, but the last value added to the environment must win
+	environment := append(os.Environ(), env...)
+	reversed := make([]string, 0, len(environment))
+	for i := len(environment) - 1; i >= 0; i-- {
+		reversed = append(reversed, environment[i])
+	}
+	expand := func(operand string) (string, error) {
+		expanded, err := parse.New("string", reversed, &parse.Restrictions{}).Parse(operand)
+		if err != nil {
+			return "", fmt.Errorf("error expanding condition '%s': %w", condition, err)
+		}
+		return unquote(expanded), nil
+	}
+
+	for _, operator := range []string{"!=", "=="} {
+		lhs, rhs, found := strings.Cut(condition, operator)
+		if !found {
+			continue
+		}
+		lhs, err := expand(lhs)
+		if err != nil {
+			return false, err
+		}
+		rhs, err = expand(rhs)
+		if err != nil {
+			return false, err
+		}
+		return (lhs == rhs) == (operator == "=="), nil
+	}
+
+	value := strings.TrimSpace(condition)
+	negated := strings.HasPrefix(value, "!")
+	value, err := expand(strings.TrimPrefix(value, "!"))
+	if err != nil {
+		return false, err
+	}
+	return falsyConditionValues[strings.ToLower(value)] == negated, nil
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	t.Setenv("FROM_OS", "os-value")
+	tests := []struct {
+		name      string
+		condition string
+		env       []string
+		expected  bool
+	}{
+		{
+			name:      "set variable",
+			condition: "$FOO",
+			env:       []string{"FOO=bar"},
+			expected:  true,
+		},
+		{
+			name:      "unset variable",
+			condition: "${FOO}",
+			expected:  false,
+		},
+		{
+			name:      "false value",
+			condition: "$FOO",
+			env:       []string{"FOO=false"},
+			expected:  false,
+		},
+		{
+			name:      "negated unset variable",
+			condition: "!$FOO",
+			expected:  true,
+		},
+		{
+			name:      "equality",
+			condition: "$ENVIRONMENT == 'staging'",
+			env:       []string{"ENVIRONMENT=staging"},
+			expected:  true,
+		},
+		{
+			name:      "inequality",
+			condition: "$ENVIRONMENT != \"staging\"",
+			env:       []string{"ENVIRONMENT=staging"},
+			expected:  false,
+		},
+		{
+			name:      "last value wins",
+			condition: "$FOO == second",
+			env:       []string{"FOO=first", "FOO=second"},
+			expected:  true,
+		},
+		{
+			name:      "value with an equality operator",
+			condition: "$FOO == bar",
+			env:       []string{"FOO=bar == bar"},
+			expected:  false,
+		},
+		{
+			name:      "value with an inequality operator",
+			condition: "$FOO",
+			env:       []string{"FOO=a != a"},
+			expected:  true,
+		},
+		{
+			name:      "negated value starting with an exclamation mark",
+			condition: "!$FOO",
+			env:       []string{"FOO=!false"},
+			expected:  false,
+		},
+		{
+			name:      "environment variable from the os",
+			condition: "$FROM_OS == os-value",
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluateCondition(tt.condition, tt.env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEvaluateConditionError(t *testing.T) {
+	_, err := evaluateCondition("${FOO", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package executor
+
+import (
+	"os/exec"
+)
+
+// killProcessGroupOnCancel keeps the default behavior on windows, where the command is killed when the context is done
+func killProcessGroupOnCancel(_ *exec.Cmd) {}
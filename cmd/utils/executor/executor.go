@@ -14,9 +14,13 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
@@ -24,18 +28,35 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 )
 
+const (
+	// defaultRetryBackoff is the time to wait before retrying a failed command for the first time
+	defaultRetryBackoff = 2 * time.Second
+
+	// maxRetryBackoff is the maximum time to wait between retries of a failed command
+	maxRetryBackoff = 1 * time.Minute
+
+	// killWaitDelay is the time to wait for the output of a command to be closed after killing it
+	killWaitDelay = 5 * time.Second
+)
+
 // ManifestExecutor is the interface to execute a command
 type ManifestExecutor interface {
 	Execute(command model.DeployCommand, env []string) error
 	CleanUp(err error)
 }
 
-// Executor implements ManifestExecutor with a executor displayer
+// Executor implements ManifestExecutor with a executor displayer.
+// It is safe to execute several commands at the same time: each execution gets its own displayer
+// and, while more than one command is running, every output line is prefixed with the command name
 type Executor struct {
-	displayer      executorDisplayer
+	ctx            context.Context
+	cancel         context.CancelFunc
+	running        map[executorDisplayer]bool
 	outputMode     string
 	shell, dir     string
+	retryBackoff   time.Duration
 	runWithoutBash bool
+	mu             sync.Mutex
 }
 
 type executorDisplayer interface {
@@ -46,47 +67,108 @@ type executorDisplayer interface {
 
 // NewExecutor returns a new executor
 func NewExecutor(output string, runWithoutBash bool, dir string) *Executor {
-	var displayer executorDisplayer
-
-	switch output {
-	case oktetoLog.TTYFormat:
-		displayer = newTTYExecutor()
-	case oktetoLog.PlainFormat:
-		displayer = newPlainExecutor()
-	case oktetoLog.JSONFormat:
-		displayer = newJSONExecutor()
-	default:
-		displayer = newTTYExecutor()
-	}
-
 	shell := "bash"
 	if env.LoadBoolean(constants.OktetoDeployRemote) {
 		shell = "sh"
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Executor{
+		ctx:            ctx,
+		cancel:         cancel,
 		outputMode:     output,
-		displayer:      displayer,
+		running:        map[executorDisplayer]bool{},
+		retryBackoff:   defaultRetryBackoff,
 		runWithoutBash: runWithoutBash,
 		shell:          shell,
 		dir:            dir,
 	}
 }
 
-// Execute executes the specified command adding `env` to the execution environment
+func newExecutorDisplayer(output string, linePrefix func() string) executorDisplayer {
+	switch output {
+	case oktetoLog.PlainFormat:
+		d := newPlainExecutor()
+		d.linePrefix = linePrefix
+		return d
+	case oktetoLog.JSONFormat:
+		d := newJSONExecutor()
+		d.linePrefix = linePrefix
+		return d
+	default:
+		d := newTTYExecutor()
+		d.linePrefix = linePrefix
+		return d
+	}
+}
+
+// Execute executes the specified command adding `env` to the execution environment.
+// The command is skipped if its 'when' condition is not met, it is stopped when its timeout is exceeded
+// and it is retried with an exponential backoff as many times as its 'retries' value.
+// Commands still running or waiting to be retried are stopped when the executor is cleaned up with an error
 func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
+	if cmdInfo.When != "" {
+		shouldRun, err := evaluateCondition(cmdInfo.When, env)
+		if err != nil {
+			return err
+		}
+		if !shouldRun {
+			oktetoLog.Information("Skipping '%s': condition '%s' is not met", cmdInfo.Name, cmdInfo.When)
+			return nil
+		}
+	}
+
+	backoff := e.retryBackoff
+	err := e.execute(cmdInfo, env)
+	for attempt := 1; err != nil && attempt <= cmdInfo.Retries; attempt++ {
+		oktetoLog.Warning("Command '%s' failed: %s. Retrying in %s (%d/%d)", cmdInfo.Name, err.Error(), backoff, attempt, cmdInfo.Retries)
+		select {
+		case <-time.After(backoff):
+		case <-e.ctx.Done():
+			return err
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+		err = e.execute(cmdInfo, env)
+	}
+	return err
+}
 
-	cmd := exec.Command(e.shell, "-c", cmdInfo.Command)
+func (e *Executor) execute(cmdInfo model.DeployCommand, env []string) error {
+	displayer := newExecutorDisplayer(e.outputMode, e.linePrefix(cmdInfo.Name))
+	e.mu.Lock()
+	e.running[displayer] = true
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.running, displayer)
+		e.mu.Unlock()
+	}()
+
+	ctx := e.ctx
+	if cmdInfo.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmdInfo.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, e.shell, "-c", cmdInfo.Command)
 	if e.runWithoutBash {
-		cmd = exec.Command(cmdInfo.Command)
+		cmd = exec.CommandContext(ctx, cmdInfo.Command)
+	}
+	if cmdInfo.Timeout > 0 {
+		killProcessGroupOnCancel(cmd)
 	}
+	cmd.WaitDelay = killWaitDelay
 	cmd.Env = append(os.Environ(), env...)
 
 	if e.dir != "" {
 		cmd.Dir = e.dir
 	}
 
-	if err := e.displayer.startCommand(cmd); err != nil {
+	if err := displayer.startCommand(cmd); err != nil {
 		if execErr, ok := err.(*exec.Error); ok {
 			if execErr != nil && execErr.Name == e.shell {
 				return fmt.Errorf("%w: \"%s\" is a required dependency for executing the command", err, e.shell)
@@ -95,18 +177,39 @@ func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
 		return err
 	}
 
-	e.displayer.display(cmdInfo.Name)
+	displayer.display(cmdInfo.Name)
 
 	err := cmd.Wait()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timeout of %s exceeded", cmdInfo.Timeout)
+	}
 
-	e.CleanUp(err)
+	displayer.cleanUp(err)
 	return err
 }
 
-// CleanUp cleans the execution lines
+// CleanUp cleans the execution lines of the commands still running.
+// If err is not nil, the commands still running are stopped and no command is retried
 func (e *Executor) CleanUp(err error) {
-	if e.displayer != nil {
-		e.displayer.cleanUp(err)
+	if err != nil {
+		e.cancel()
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for displayer := range e.running {
+		displayer.cleanUp(err)
+	}
+}
+
+// linePrefix returns the function used to prefix the output lines of a command while other commands are running
+func (e *Executor) linePrefix(name string) func() string {
+	return func() string {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if len(e.running) > 1 {
+			return fmt.Sprintf("[%s] ", name)
+		}
+		return ""
 	}
 }
 
@@ -0,0 +1,155 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExecutor() *Executor {
+	e := NewExecutor(oktetoLog.PlainFormat, false, "")
+	e.shell = "sh"
+	e.retryBackoff = time.Millisecond
+	return e
+}
+
+func countAttempts(t *testing.T, path string) int {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(content), "attempt")
+}
+
+func TestExecuteRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		command          string
+		retries          int
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			name:             "success without retries",
+			command:          "echo attempt >> $ATTEMPTS_FILE",
+			retries:          2,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "failure is retried",
+			command:          "echo attempt >> $ATTEMPTS_FILE; exit 1",
+			retries:          2,
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+		{
+			name:             "succeeds after retrying",
+			command:          "echo attempt >> $ATTEMPTS_FILE; [ $(grep -c attempt $ATTEMPTS_FILE) -ge 2 ]",
+			retries:          3,
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attemptsFile := filepath.Join(t.TempDir(), "attempts")
+			cmd := model.DeployCommand{
+				Name:    tt.name,
+				Command: tt.command,
+				Retries: tt.retries,
+			}
+			err := newTestExecutor().Execute(cmd, []string{"ATTEMPTS_FILE=" + attemptsFile})
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, countAttempts(t, attemptsFile))
+		})
+	}
+}
+
+func TestExecuteWhen(t *testing.T) {
+	attemptsFile := filepath.Join(t.TempDir(), "attempts")
+	env := []string{"ATTEMPTS_FILE=" + attemptsFile, "ENABLED=false"}
+
+	cmd := model.DeployCommand{
+		Name:    "skipped",
+		Command: "echo attempt >> $ATTEMPTS_FILE",
+		When:    "$ENABLED",
+	}
+	require.NoError(t, newTestExecutor().Execute(cmd, env))
+	assert.Equal(t, 0, countAttempts(t, attemptsFile))
+
+	env = append(env, "ENABLED=true")
+	require.NoError(t, newTestExecutor().Execute(cmd, env))
+	assert.Equal(t, 1, countAttempts(t, attemptsFile))
+}
+
+func TestExecuteTimeout(t *testing.T) {
+	cmd := model.DeployCommand{
+		Name:    "sleep",
+		Command: "exec sleep 10",
+		Timeout: 100 * time.Millisecond,
+	}
+	start := time.Now()
+	err := newTestExecutor().Execute(cmd, nil)
+	require.ErrorContains(t, err, "timeout of 100ms exceeded")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestExecuteTimeoutKillsChildProcesses(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command is not run in a process group on windows")
+	}
+	cmd := model.DeployCommand{
+		Name:    "sleep",
+		Command: "sleep 10; echo done",
+		Timeout: 200 * time.Millisecond,
+	}
+	start := time.Now()
+	err := newTestExecutor().Execute(cmd, nil)
+	require.ErrorContains(t, err, "timeout of 200ms exceeded")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestExecuteCleanUpStopsRetries(t *testing.T) {
+	e := newTestExecutor()
+	e.retryBackoff = time.Minute
+	cmd := model.DeployCommand{
+		Name:    "fail",
+		Command: "exit 1",
+		Retries: 3,
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		e.CleanUp(errors.New("interrupt signal received"))
+	}()
+	start := time.Now()
+	require.Error(t, e.Execute(cmd, nil))
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
@@ -21,7 +21,8 @@ import (
 )
 
 type jsonExecutor struct {
-	displayer displayer.Displayer
+	displayer  displayer.Displayer
+	linePrefix func() string
 }
 
 func newJSONExecutor() *jsonExecutor {
@@ -38,7 +39,7 @@ func (e *jsonExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), newPrefixReader(stdoutReader, e.linePrefix), newPrefixReader(stderrReader, e.linePrefix))
 	return startCommand(cmd)
 }
 
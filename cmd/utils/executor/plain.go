@@ -21,7 +21,8 @@ import (
 )
 
 type plainExecutor struct {
-	displayer displayer.Displayer
+	displayer  displayer.Displayer
+	linePrefix func() string
 }
 
 func newPlainExecutor() *plainExecutor {
@@ -40,7 +41,7 @@ func (e *plainExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), newPrefixReader(stdoutReader, e.linePrefix), newPrefixReader(stderrReader, e.linePrefix))
 	return startCommand(cmd)
 }
 
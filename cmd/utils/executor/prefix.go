@@ -0,0 +1,59 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"io"
+)
+
+// prefixReader adds a prefix at the beginning of every line read from the underlying reader.
+// The prefix is evaluated when a new line starts, so it can be enabled or disabled while the command is running
+type prefixReader struct {
+	reader    io.Reader
+	prefix    func() string
+	pending   []byte
+	lineStart bool
+}
+
+func newPrefixReader(r io.Reader, prefix func() string) io.Reader {
+	if prefix == nil {
+		return r
+	}
+	return &prefixReader{
+		reader:    r,
+		prefix:    prefix,
+		lineStart: true,
+	}
+}
+
+// Read implements the io.Reader interface
+func (r *prefixReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		buf := make([]byte, len(p))
+		n, err := r.reader.Read(buf)
+		for _, b := range buf[:n] {
+			if r.lineStart {
+				r.pending = append(r.pending, r.prefix()...)
+			}
+			r.pending = append(r.pending, b)
+			r.lineStart = b == '\n'
+		}
+		if len(r.pending) == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixReader(t *testing.T) {
+	tests := []struct {
+		prefix   func() string
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no prefix function",
+			input:    "line 1\nline 2\n",
+			expected: "line 1\nline 2\n",
+		},
+		{
+			name:     "empty prefix",
+			prefix:   func() string { return "" },
+			input:    "line 1\nline 2\n",
+			expected: "line 1\nline 2\n",
+		},
+		{
+			name:     "prefix every line",
+			prefix:   func() string { return "[api] " },
+			input:    "line 1\nline 2\nline 3",
+			expected: "[api] line 1\n[api] line 2\n[api] line 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPrefixReader(strings.NewReader(tt.input), tt.prefix)
+			result, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestPrefixReaderSmallBuffer(t *testing.T) {
+	r := newPrefixReader(strings.NewReader("a\nb\n"), func() string { return "[cmd] " })
+	buf := make([]byte, 3)
+	result := []byte{}
+	for {
+		n, err := r.Read(buf)
+		result = append(result, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "[cmd] a\n[cmd] b\n", string(result))
+}
@@ -21,7 +21,8 @@ import (
 )
 
 type ttyExecutor struct {
-	displayer displayer.Displayer
+	displayer  displayer.Displayer
+	linePrefix func() string
 }
 
 func newTTYExecutor() *ttyExecutor {
@@ -48,6 +49,6 @@ func (e *ttyExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), newPrefixReader(stdoutReader, e.linePrefix), newPrefixReader(stderrReader, e.linePrefix))
 	return startCommand(cmd)
 }
@@ -29,13 +29,16 @@ import (
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
+	"github.com/okteto/okteto/cmd/divert"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
 	"github.com/okteto/okteto/cmd/namespace"
 	"github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/preview"
+	registryCMD "github.com/okteto/okteto/cmd/registry"
 	"github.com/okteto/okteto/cmd/registrytoken"
 	"github.com/okteto/okteto/cmd/stack"
+	syncCMD "github.com/okteto/okteto/cmd/sync"
 	"github.com/okteto/okteto/cmd/up"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/config"
@@ -154,6 +157,7 @@ func main() {
 	root.AddCommand(namespace.Namespace(ctx))
 	root.AddCommand(cmd.Init())
 	root.AddCommand(up.Up(at, ioController))
+	root.AddCommand(up.Attach())
 	root.AddCommand(cmd.Down())
 	root.AddCommand(cmd.Status())
 	root.AddCommand(cmd.Doctor())
@@ -164,6 +168,8 @@ func main() {
 	root.AddCommand(deploy.Deploy(ctx, at, ioController))
 	root.AddCommand(destroy.Destroy(ctx, at, ioController))
 	root.AddCommand(deploy.Endpoints(ctx))
+	root.AddCommand(divert.Divert(ctx))
+	root.AddCommand(syncCMD.Sync(ctx))
 	root.AddCommand(logs.Logs(ctx))
 	root.AddCommand(generateFigSpec.NewCmdGenFigSpec())
 
@@ -173,6 +179,7 @@ func main() {
 	root.AddCommand(cmd.Delete(ctx))
 	root.AddCommand(stack.Stack(ctx, at, ioController))
 	root.AddCommand(cmd.Push(ctx))
+	root.AddCommand(registryCMD.Registry(ctx))
 	root.AddCommand(pipeline.Pipeline(ctx))
 
 	err = root.Execute()
@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// maxBuildCacheEntries is the number of entries kept in the build cache. The oldest entries are evicted first
+	maxBuildCacheEntries = 1000
+
+	// maxBuildCacheAge is the time an entry is kept in the build cache
+	maxBuildCacheAge = 30 * 24 * time.Hour
+)
+
+// BuildCacheKey identifies a built image: the smart build hash of a service
+// of a manifest built in a given okteto context and namespace
+type BuildCacheKey struct {
+	Context   string
+	Namespace string
+	Manifest  string
+	Service   string
+	Hash      string
+}
+
+// BuildCacheEntry is an image stored in the build cache
+type BuildCacheEntry struct {
+	Context   string    `json:"context"`
+	Namespace string    `json:"namespace"`
+	Manifest  string    `json:"manifest"`
+	Service   string    `json:"service"`
+	Hash      string    `json:"hash"`
+	Image     string    `json:"image"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Key returns the key of the entry
+func (e BuildCacheEntry) Key() BuildCacheKey {
+	return BuildCacheKey{
+		Context:   e.Context,
+		Namespace: e.Namespace,
+		Manifest:  e.Manifest,
+		Service:   e.Service,
+		Hash:      e.Hash,
+	}
+}
+
+// LocalBuildCache stores the images built by smart builds in a file, so they can
+// be reused without querying the registry. It keeps up to maxBuildCacheEntries entries newer than maxBuildCacheAge
+type LocalBuildCache struct {
+	fs   afero.Fs
+	path string
+	mu   sync.Mutex
+}
+
+// NewLocalBuildCache returns a build cache stored at path
+func NewLocalBuildCache(fs afero.Fs, path string) *LocalBuildCache {
+	return &LocalBuildCache{
+		fs:   fs,
+		path: path,
+	}
+}
+
+// Get returns the image with digest stored for the given key
+func (c *LocalBuildCache) Get(key BuildCacheKey) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return "", false, err
+	}
+	for _, entry := range entries {
+		if entry.Key() == key && !isExpired(entry) {
+			return entry.Image, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// Delete removes the entry stored for the given key
+func (c *LocalBuildCache) Delete(key BuildCacheKey) error {
+	_, err := c.Prune(func(entry BuildCacheEntry) bool {
+		return entry.Key() == key
+	})
+	return err
+}
+
+// Set stores the image with digest for the given key, replacing any previous entry.
+// The expired entries and the oldest entries over the maximum size of the cache are evicted
+func (c *LocalBuildCache) Set(key BuildCacheKey, image string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	if len(entries) >= maxBuildCacheEntries {
+		entries = entries[len(entries)-maxBuildCacheEntries+1:]
+	}
+	result := []BuildCacheEntry{}
+	for _, entry := range entries {
+		if entry.Key() != key && !isExpired(entry) {
+			result = append(result, entry)
+		}
+	}
+	result = append(result, BuildCacheEntry{
+		Context:   key.Context,
+		Namespace: key.Namespace,
+		Manifest:  key.Manifest,
+		Service:   key.Service,
+		Hash:      key.Hash,
+		Image:     image,
+		CreatedAt: time.Now(),
+	})
+	return c.save(result)
+}
+
+// List returns the entries of the cache sorted by creation date, newest first
+func (c *LocalBuildCache) List() ([]BuildCacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+// Prune removes the entries matching the given function and returns how many were removed
+func (c *LocalBuildCache) Prune(shouldRemove func(BuildCacheEntry) bool) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return 0, err
+	}
+	result := []BuildCacheEntry{}
+	for _, entry := range entries {
+		if !shouldRemove(entry) {
+			result = append(result, entry)
+		}
+	}
+	removed := len(entries) - len(result)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, c.save(result)
+}
+
+func isExpired(entry BuildCacheEntry) bool {
+	return time.Since(entry.CreatedAt) > maxBuildCacheAge
+}
+
+func (c *LocalBuildCache) load() ([]BuildCacheEntry, error) {
+	b, err := afero.ReadFile(c.fs, c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []BuildCacheEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read build cache: %w", err)
+	}
+
+	entries := []BuildCacheEntry{}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse build cache '%s': %w", c.path, err)
+	}
+	return entries, nil
+}
+
+// save writes the entries to a temporary file and renames it, so a concurrent reader never sees a partial file
+func (c *LocalBuildCache) save(entries []BuildCacheEntry) error {
+	b, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to generate build cache: %w", err)
+	}
+	if err := c.fs.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("failed to create build cache folder: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := afero.WriteFile(c.fs, tmp, b, 0600); err != nil {
+		return fmt.Errorf("failed to save build cache: %w", err)
+	}
+	if err := c.fs.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to save build cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBuildCache(t *testing.T) {
+	c := NewLocalBuildCache(afero.NewMemMapFs(), "/okteto/build-cache.json")
+
+	key := BuildCacheKey{Context: "ctx", Manifest: "movies", Service: "api", Hash: "abc"}
+
+	_, found, err := c.Get(key)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, c.Set(key, "okteto.dev/movies-api@sha256:1"))
+	image, found, err := c.Get(key)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "okteto.dev/movies-api@sha256:1", image)
+
+	// a different context doesn't share the entry
+	otherCtx := key
+	otherCtx.Context = "other"
+	_, found, err = c.Get(otherCtx)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// setting the same key replaces the entry
+	require.NoError(t, c.Set(key, "okteto.dev/movies-api@sha256:2"))
+	require.NoError(t, c.Set(otherCtx, "registry/movies-api@sha256:3"))
+	entries, err := c.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "registry/movies-api@sha256:3", entries[0].Image)
+	assert.Equal(t, "okteto.dev/movies-api@sha256:2", entries[1].Image)
+}
+
+func TestLocalBuildCacheDelete(t *testing.T) {
+	c := NewLocalBuildCache(afero.NewMemMapFs(), "/okteto/build-cache.json")
+	key := BuildCacheKey{Manifest: "movies", Service: "api", Hash: "a"}
+	require.NoError(t, c.Set(key, "api@sha256:1"))
+	require.NoError(t, c.Delete(key))
+
+	_, found, err := c.Get(key)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestLocalBuildCacheEviction(t *testing.T) {
+	c := NewLocalBuildCache(afero.NewMemMapFs(), "/okteto/build-cache.json")
+
+	expired := BuildCacheEntry{Service: "expired", Hash: "a", Image: "expired@sha256:1", CreatedAt: time.Now().Add(-maxBuildCacheAge - time.Hour)}
+	entries := []BuildCacheEntry{expired}
+	for i := 0; i < maxBuildCacheEntries; i++ {
+		entries = append(entries, BuildCacheEntry{Service: "api", Hash: strconv.Itoa(i), Image: "api@sha256:1", CreatedAt: time.Now().Add(time.Duration(i-maxBuildCacheEntries) * time.Minute)})
+	}
+	require.NoError(t, c.save(entries))
+
+	// expired entries are not returned
+	_, found, err := c.Get(expired.Key())
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	newKey := BuildCacheKey{Service: "api", Hash: "new"}
+	require.NoError(t, c.Set(newKey, "api@sha256:2"))
+	result, err := c.List()
+	require.NoError(t, err)
+	require.Len(t, result, maxBuildCacheEntries)
+	assert.Equal(t, newKey, result[0].Key())
+	// the oldest entry is evicted
+	_, found, err = c.Get(BuildCacheKey{Service: "api", Hash: "0"})
+	require.NoError(t, err)
+	assert.False(t, found)
+	_, found, err = c.Get(BuildCacheKey{Service: "api", Hash: "1"})
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestLocalBuildCachePrune(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	c := NewLocalBuildCache(fs, "/okteto/build-cache.json")
+	require.NoError(t, c.Set(BuildCacheKey{Manifest: "movies", Service: "api", Hash: "a"}, "api@sha256:1"))
+	require.NoError(t, c.Set(BuildCacheKey{Manifest: "movies", Service: "frontend", Hash: "b"}, "frontend@sha256:1"))
+
+	removed, err := c.Prune(func(e BuildCacheEntry) bool { return e.Service == "api" })
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// a new instance reads the pruned state from disk
+	entries, err := NewLocalBuildCache(fs, "/okteto/build-cache.json").List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "frontend", entries[0].Service)
+	assert.WithinDuration(t, time.Now(), entries[0].CreatedAt, time.Minute)
+}
+
+func TestLocalBuildCacheInvalidFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/okteto/build-cache.json", []byte("not json"), 0600))
+	c := NewLocalBuildCache(fs, "/okteto/build-cache.json")
+
+	_, _, err := c.Get(BuildCacheKey{})
+	assert.Error(t, err)
+}
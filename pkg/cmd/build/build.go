@@ -51,6 +51,21 @@ var (
 		E:    fmt.Errorf("cannot connect to Docker Daemon"),
 		Hint: "Please start the Docker Daemon or configure a builder endpoint with 'okteto context --builder BUILDKIT_URL",
 	}
+
+	errDockerAttestations = oktetoErrors.UserError{
+		E:    fmt.Errorf("build attestations are not supported by the Docker Daemon"),
+		Hint: "Configure a BuildKit builder endpoint with 'okteto context --builder BUILDKIT_URL' or disable the 'sbom' and 'provenance' attestations",
+	}
+
+	errDockerOutputs = oktetoErrors.UserError{
+		E:    fmt.Errorf("build outputs are not supported by the Docker Daemon"),
+		Hint: "Configure a BuildKit builder endpoint with 'okteto context --builder BUILDKIT_URL' or remove the build outputs",
+	}
+
+	errDockerMultiPlatform = oktetoErrors.UserError{
+		E:    fmt.Errorf("multi-platform builds are not supported by the Docker Daemon"),
+		Hint: "Configure a BuildKit builder endpoint with 'okteto context --builder BUILDKIT_URL' or build a single platform",
+	}
 )
 
 // OktetoBuilderInterface runs the build of an image
@@ -69,6 +84,9 @@ type OktetoRegistryInterface interface {
 // Run runs the build sequence
 func (ob *OktetoBuilder) Run(ctx context.Context, buildOptions *types.BuildOptions, ioCtrl *io.IOController) error {
 	buildOptions.OutputMode = setOutputMode(buildOptions.OutputMode)
+	if err := newSecretsResolver().resolve(ctx, buildOptions); err != nil {
+		return err
+	}
 	if okteto.Context().Builder == "" {
 		if err := ob.buildWithDocker(ctx, buildOptions); err != nil {
 			return err
@@ -133,10 +151,15 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		}
 	}
 
-	// create a temp folder - this will be remove once the build has finished
-	secretTempFolder := filepath.Join(config.GetOktetoHome(), ".secret")
-	if err := os.MkdirAll(secretTempFolder, 0700); err != nil {
-		return fmt.Errorf("failed to create %s: %s", secretTempFolder, err)
+	// create a temp folder for each build, so builds running at the same time don't remove the secrets of each other.
+	// It will be removed once the build has finished
+	secretsFolder := filepath.Join(config.GetOktetoHome(), ".secret")
+	if err := os.MkdirAll(secretsFolder, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %s", secretsFolder, err)
+	}
+	secretTempFolder, err := os.MkdirTemp(secretsFolder, "build-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp folder at %s: %s", secretsFolder, err)
 	}
 	defer os.RemoveAll(secretTempFolder)
 
@@ -150,7 +173,7 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		return errors.Wrap(err, "failed to create build solver")
 	}
 
-	err = solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode, ioCtrl)
+	err = solveBuild(ctx, buildkitClient, opt, buildOptions, ioCtrl)
 	if err != nil {
 		oktetoLog.Infof("Failed to build image: %s", err.Error())
 	}
@@ -159,7 +182,7 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
   %s,
   Retrying ...`, buildOptions.Tag, err.Error())
 		success := true
-		err := solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode, ioCtrl)
+		err := solveBuild(ctx, buildkitClient, opt, buildOptions, ioCtrl)
 		if err != nil {
 			success = false
 			oktetoLog.Infof("Failed to build image: %s", err.Error())
@@ -169,13 +192,14 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		return err
 	}
 
-	if err == nil && buildOptions.Tag != "" {
+	// images exported to outputs are not pushed to the registry
+	if err == nil && buildOptions.Tag != "" && len(buildOptions.Outputs) == 0 {
 		if _, err := registry.NewOktetoRegistry(okteto.Config{}).GetImageTagWithDigest(buildOptions.Tag); err != nil {
 			oktetoLog.Yellow(`Failed to push '%s' metadata to the registry:
 	  %s,
 	  Retrying ...`, buildOptions.Tag, err.Error())
 			success := true
-			err := solveBuild(ctx, buildkitClient, opt, buildOptions.OutputMode, ioCtrl)
+			err := solveBuild(ctx, buildkitClient, opt, buildOptions, ioCtrl)
 			if err != nil {
 				success = false
 				oktetoLog.Infof("Failed to build image: %s", err.Error())
@@ -199,6 +223,15 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 
 // https://github.com/docker/cli/blob/56e5910181d8ac038a634a203a4f3550bb64991f/cli/command/image/build.go#L209
 func (ob *OktetoBuilder) buildWithDocker(ctx context.Context, buildOptions *types.BuildOptions) error {
+	if strings.Contains(buildOptions.Platform, ",") {
+		return errDockerMultiPlatform
+	}
+	if provenance, _ := model.ProvenanceMode(buildOptions.Provenance); buildOptions.SBOM || provenance != "" {
+		return errDockerAttestations
+	}
+	if len(buildOptions.Outputs) > 0 {
+		return errDockerOutputs
+	}
 
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
@@ -358,13 +391,34 @@ func OptsFromBuildInfo(manifestName, svcName string, b *model.BuildInfo, o *type
 		Platform:    o.Platform,
 	}
 
+	// platforms declared in the manifest take precedence over the --platform flag
+	if len(b.Platforms) > 0 {
+		opts.Platform = b.Platforms.String()
+	}
+
+	// attestations requested by the flags are added to the ones declared in the manifest
+	opts.SBOM = o.SBOM
+	opts.Provenance = o.Provenance
+	if b.Attestations != nil {
+		opts.SBOM = opts.SBOM || b.Attestations.SBOM
+		if opts.Provenance == "" {
+			opts.Provenance = b.Attestations.Provenance
+		}
+	}
+
+	// outputs defined by the flags take precedence over the ones declared in the manifest
+	opts.Outputs = o.Outputs
+	if len(opts.Outputs) == 0 && len(b.Outputs) > 0 {
+		opts.Outputs = b.Outputs.Strings()
+	}
+
 	// if secrets are present at the cmd flag, copy them to opts.Secrets
 	if o.Secrets != nil {
-		opts.Secrets = o.Secrets
+		opts.Secrets = append([]string{}, o.Secrets...)
 	}
 	// add to the build the secrets from the manifest build
-	for id, src := range b.Secrets {
-		opts.Secrets = append(opts.Secrets, fmt.Sprintf("id=%s,src=%s", id, src))
+	for id, secret := range b.Secrets {
+		opts.Secrets = append(opts.Secrets, secret.BuildkitSecret(id))
 	}
 
 	outputMode := oktetoLog.GetOutputFormat()
@@ -372,6 +426,7 @@ func OptsFromBuildInfo(manifestName, svcName string, b *model.BuildInfo, o *type
 		outputMode = o.OutputMode
 	}
 	opts.OutputMode = setOutputMode(outputMode)
+	opts.ProgressWriter = o.ProgressWriter
 
 	return opts
 }
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/containerd/console"
 	"github.com/docker/cli/cli/command"
@@ -40,7 +41,6 @@ import (
 	dockerRegistry "github.com/docker/docker/registry"
 	controlapi "github.com/moby/buildkit/api/services/control"
 	buildkitClient "github.com/moby/buildkit/client"
-	"github.com/moby/buildkit/cmd/buildctl/build"
 	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
@@ -68,8 +68,8 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 
 	dockerAuthProvider := authprovider.NewDockerAuthProvider(os.Stderr)
 	s.Allow(dockerAuthProvider)
-	if len(buildOptions.Secrets) > 0 {
-		secretProvider, err := build.ParseSecret(buildOptions.Secrets)
+	if len(buildOptions.Secrets) > 0 || len(buildOptions.SecretValues) > 0 {
+		secretProvider, err := getSecretProvider(buildOptions)
 		if err != nil {
 			return errors.Wrapf(err, "could not parse secrets: %v", buildOptions.Secrets)
 		}
@@ -160,7 +160,7 @@ func buildWithDockerDaemonBuildkit(ctx context.Context, buildOptions *types.Buil
 			return nil
 		})
 
-		return displayStatus(eg, response, buildOptions.OutputMode, dockerAuthProvider)
+		return displayStatus(eg, response, buildOptions, dockerAuthProvider)
 	})
 
 	return eg.Wait()
@@ -221,13 +221,16 @@ func buildWithDockerDaemon(ctx context.Context, buildOptions *types.BuildOptions
 
 }
 
-func displayStatus(eg *errgroup.Group, response dockerTypes.ImageBuildResponse, buildOutputMode string, at session.Attachable) error {
+func displayStatus(eg *errgroup.Group, response dockerTypes.ImageBuildResponse, buildOptions *types.BuildOptions, at session.Attachable) error {
+	buildOutputMode := buildOptions.OutputMode
 
-	displayStatus := func(out *os.File, displayCh chan *buildkitClient.SolveStatus) {
+	displayStatus := func(out io.Writer, displayCh chan *buildkitClient.SolveStatus) {
 		var c console.Console
 		// TODO: Handle tty output in non-tty environment.
-		if cons, err := console.ConsoleFromFile(out); err == nil && (buildOutputMode == "auto" || buildOutputMode == oktetoLog.TTYFormat) {
-			c = cons
+		if f, ok := out.(*os.File); ok {
+			if cons, err := console.ConsoleFromFile(f); err == nil && (buildOutputMode == "auto" || buildOutputMode == oktetoLog.TTYFormat) {
+				c = cons
+			}
 		}
 		// not using shared context to not disrupt display but let it finish reporting errors
 		eg.Go(func() error {
@@ -242,10 +245,15 @@ func displayStatus(eg *errgroup.Group, response dockerTypes.ImageBuildResponse,
 		}
 	}
 	displayCh := make(chan *buildkitClient.SolveStatus)
-	displayStatus(os.Stderr, displayCh)
+	var out io.Writer = os.Stderr
+	if buildOptions.ProgressWriter != nil {
+		out = buildOptions.ProgressWriter
+	}
+	displayStatus(out, displayCh)
 	defer close(displayCh)
 
 	buf := bytes.NewBuffer(nil)
+	collector := newTimingCollector(time.Now())
 
 	writeAux := func(msg jsonmessage.JSONMessage) {
 		if msg.ID == "moby.image.id" {
@@ -302,6 +310,7 @@ func displayStatus(eg *errgroup.Group, response dockerTypes.ImageBuildResponse,
 			})
 		}
 
+		collector.update(&s)
 		displayCh <- &s
 	}
 
@@ -315,6 +324,9 @@ func displayStatus(eg *errgroup.Group, response dockerTypes.ImageBuildResponse,
 			return fmt.Errorf("error building image (status code %d) : %s", jerr.Code, jerr.Message)
 		}
 	}
+	if err == nil && buildOptions.Timing != nil {
+		*buildOptions.Timing = collector.timing()
+	}
 
 	return err
 
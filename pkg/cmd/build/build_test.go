@@ -281,8 +281,8 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 						Value: "value1",
 					},
 				},
-				Secrets: map[string]string{
-					"mysecret": "source",
+				Secrets: model.BuildSecrets{
+					"mysecret": {File: "source"},
 				},
 				ExportCache: []string{"export-image"},
 			},
@@ -354,6 +354,95 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 				OutputMode: "tty",
 			},
 		},
+		{
+			name:        "has-manifest-platforms",
+			serviceName: "service",
+			buildInfo: &model.BuildInfo{
+				Platforms: model.BuildPlatforms{"linux/amd64", "linux/arm64"},
+			},
+			initialOpts: &types.BuildOptions{
+				Platform: "linux/amd64"},
+			isOkteto: true,
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			expected: &types.BuildOptions{
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				Platform:   "linux/amd64,linux/arm64",
+				Tag:        "okteto.dev/movies-service:okteto",
+				OutputMode: "tty",
+			},
+		},
+		{
+			name:        "has-manifest-attestations",
+			serviceName: "service",
+			buildInfo: &model.BuildInfo{
+				Attestations: &model.BuildAttestations{
+					SBOM:       true,
+					Provenance: "mode=min",
+				},
+			},
+			initialOpts: &types.BuildOptions{
+				Provenance: "mode=max",
+			},
+			isOkteto: true,
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			expected: &types.BuildOptions{
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				SBOM:       true,
+				Provenance: "mode=max",
+				Tag:        "okteto.dev/movies-service:okteto",
+				OutputMode: "tty",
+			},
+		},
+		{
+			name:        "has-manifest-outputs",
+			serviceName: "service",
+			buildInfo: &model.BuildInfo{
+				Outputs: model.BuildOutputs{{Type: model.BuildOutputLocal, Dest: "bin"}},
+			},
+			initialOpts: &types.BuildOptions{},
+			isOkteto:    true,
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			expected: &types.BuildOptions{
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				Outputs:    []string{"type=local,dest=bin"},
+				Tag:        "okteto.dev/movies-service:okteto",
+				OutputMode: "tty",
+			},
+		},
+		{
+			name:        "outputs-flag-overrides-manifest",
+			serviceName: "service",
+			buildInfo: &model.BuildInfo{
+				Outputs: model.BuildOutputs{{Type: model.BuildOutputLocal, Dest: "bin"}},
+			},
+			initialOpts: &types.BuildOptions{
+				Outputs: []string{"type=oci,dest=image.tar"},
+			},
+			isOkteto: true,
+			mr: mockRegistry{
+				isOktetoRegistry: true,
+				registry:         "okteto.dev",
+				repo:             "movies-service",
+			},
+			expected: &types.BuildOptions{
+				BuildArgs:  []string{namespaceEnvVar.String()},
+				Outputs:    []string{"type=oci,dest=image.tar"},
+				Tag:        "okteto.dev/movies-service:okteto",
+				OutputMode: "tty",
+			},
+		},
 		{
 			name:        "only key",
 			serviceName: "service",
@@ -17,14 +17,15 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	goio "io"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/containerd/console"
 	"github.com/moby/buildkit/client"
-	"github.com/moby/buildkit/cmd/buildctl/build"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/session/sshforward/sshprovider"
@@ -32,6 +33,7 @@ import (
 	"github.com/okteto/okteto/pkg/config"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/pkg/errors"
@@ -81,6 +83,9 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 	if buildOptions.NoCache {
 		frontendAttrs["no-cache"] = ""
 	}
+	if err := addAttestationAttrs(frontendAttrs, buildOptions); err != nil {
+		return nil, err
+	}
 
 	frontend := defaultFrontend
 
@@ -123,8 +128,8 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 		attachable = append(attachable, ssh)
 	}
 
-	if len(buildOptions.Secrets) > 0 {
-		secretProvider, err := build.ParseSecret(buildOptions.Secrets)
+	if len(buildOptions.Secrets) > 0 || len(buildOptions.SecretValues) > 0 {
+		secretProvider, err := getSecretProvider(buildOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -139,7 +144,13 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 		CacheExports:  []client.CacheOptionsEntry{},
 	}
 
-	if buildOptions.Tag != "" {
+	if len(buildOptions.Outputs) > 0 {
+		exports, err := getOutputExports(buildOptions.Outputs)
+		if err != nil {
+			return nil, err
+		}
+		opt.Exports = exports
+	} else if buildOptions.Tag != "" {
 		// add additional tag if DevTag is defined
 		if buildOptions.DevTag != "" {
 			opt.Exports = []client.ExportEntry{
@@ -199,6 +210,57 @@ func getSolveOpt(buildOptions *types.BuildOptions) (*client.SolveOpt, error) {
 	return opt, nil
 }
 
+// getOutputExports returns the buildkit exporter of the build output.
+// The destination directory of a 'local' output is created if it doesn't exist, while the destination file
+// of a 'tar' or 'oci' output is only created when buildkit exports the result, so failed builds don't leave empty files
+func getOutputExports(outputs []string) ([]client.ExportEntry, error) {
+	if err := model.ValidateBuildOutputs(outputs); err != nil {
+		return nil, err
+	}
+	exports := make([]client.ExportEntry, 0, len(outputs))
+	for _, value := range outputs {
+		output, err := model.ParseBuildOutput(value)
+		if err != nil {
+			return nil, err
+		}
+		export := client.ExportEntry{
+			Type:  output.Type,
+			Attrs: map[string]string{},
+		}
+		if output.Type == model.BuildOutputLocal {
+			if err := os.MkdirAll(output.Dest, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create output directory '%s': %w", output.Dest, err)
+			}
+			export.OutputDir = output.Dest
+		} else {
+			if info, err := os.Stat(output.Dest); err == nil && info.IsDir() {
+				return nil, fmt.Errorf("output destination '%s' is a directory", output.Dest)
+			}
+			dest := output.Dest
+			export.Output = func(map[string]string) (goio.WriteCloser, error) {
+				return os.Create(dest)
+			}
+		}
+		exports = append(exports, export)
+	}
+	return exports, nil
+}
+
+// addAttestationAttrs asks buildkit to generate the SBOM and provenance attestations of the image
+func addAttestationAttrs(frontendAttrs map[string]string, buildOptions *types.BuildOptions) error {
+	if buildOptions.SBOM {
+		frontendAttrs["attest:sbom"] = ""
+	}
+	provenance, err := model.ProvenanceMode(buildOptions.Provenance)
+	if err != nil {
+		return err
+	}
+	if provenance != "" {
+		frontendAttrs["attest:provenance"] = provenance
+	}
+	return nil
+}
+
 func getBuildkitClient(ctx context.Context) (*client.Client, error) {
 	buildkitHost := okteto.Context().Builder
 	octxStore := okteto.ContextStore()
@@ -257,7 +319,18 @@ func getClientForOktetoCluster(ctx context.Context) (*client.Client, error) {
 	return c, nil
 }
 
-func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, progress string, ioCtrl *io.IOController) error {
+// solveBuild runs the build in BuildKit displaying its progress.
+// The progress is written to buildOptions.ProgressWriter, if defined, without using the console
+func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, buildOptions *types.BuildOptions, ioCtrl *io.IOController) error {
+	progress := buildOptions.OutputMode
+	var out goio.Writer = ioCtrl.Out()
+	if buildOptions.ProgressWriter != nil {
+		out = buildOptions.ProgressWriter
+		if progress == oktetoLog.TTYFormat {
+			progress = oktetoLog.PlainFormat
+		}
+	}
+
 	logFilterRules := []Rule{
 		{
 			condition:   BuildKitMissingCacheCondition,
@@ -269,6 +342,7 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 	ttyChannel := make(chan *client.SolveStatus)
 	plainChannel := make(chan *client.SolveStatus)
 	commandFailChannel := make(chan error, 1)
+	collector := newTimingCollector(time.Now())
 
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
@@ -285,6 +359,7 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 				return ctx.Err()
 			case ss, ok := <-ch:
 				if ok {
+					collector.update(ss)
 					logFilter.Run(ss, progress)
 					plainChannel <- ss
 					if progress == oktetoLog.TTYFormat {
@@ -325,7 +400,7 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 			}()
 			// not using shared context to not disrupt display but let it finish reporting errors
 			// We need to wait until the tty channel is closed to avoid writing to stdout while the tty is being used
-			return progressui.DisplaySolveStatus(context.TODO(), "", c, ioCtrl.Out(), ttyChannel)
+			return progressui.DisplaySolveStatus(context.TODO(), "", c, out, ttyChannel)
 		case "deploy":
 			err := deployDisplayer(context.TODO(), plainChannel, &types.BuildOptions{OutputMode: "deploy"})
 			commandFailChannel <- err
@@ -336,7 +411,7 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 			return err
 		default:
 			// not using shared context to not disrupt display but let it finish reporting errors
-			return progressui.DisplaySolveStatus(context.TODO(), "", nil, ioCtrl.Out(), plainChannel)
+			return progressui.DisplaySolveStatus(context.TODO(), "", nil, out, plainChannel)
 		}
 	})
 
@@ -353,6 +428,9 @@ func solveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 			return err
 		}
 	}
+	if buildOptions.Timing != nil {
+		*buildOptions.Timing = collector.timing()
+	}
 	return nil
 }
 
@@ -0,0 +1,121 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_addAttestationAttrs(t *testing.T) {
+	tests := []struct {
+		options     *types.BuildOptions
+		expected    map[string]string
+		name        string
+		expectedErr bool
+	}{
+		{
+			name:     "no attestations",
+			options:  &types.BuildOptions{},
+			expected: map[string]string{},
+		},
+		{
+			name:    "sbom and provenance",
+			options: &types.BuildOptions{SBOM: true, Provenance: "mode=max"},
+			expected: map[string]string{
+				"attest:sbom":       "",
+				"attest:provenance": "mode=max",
+			},
+		},
+		{
+			name:    "provenance enabled",
+			options: &types.BuildOptions{Provenance: "true"},
+			expected: map[string]string{
+				"attest:provenance": "mode=min",
+			},
+		},
+		{
+			name:        "invalid provenance",
+			options:     &types.BuildOptions{Provenance: "full"},
+			expected:    map[string]string{},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := map[string]string{}
+			err := addAttestationAttrs(attrs, tt.options)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, attrs)
+		})
+	}
+}
+
+func Test_getOutputExports(t *testing.T) {
+	dir := t.TempDir()
+	localDest := filepath.Join(dir, "bin")
+	tarDest := filepath.Join(dir, "image.tar")
+
+	exports, err := getOutputExports([]string{"type=local,dest=" + localDest})
+	require.NoError(t, err)
+	require.Len(t, exports, 1)
+	assert.Equal(t, client.ExporterLocal, exports[0].Type)
+	assert.Equal(t, localDest, exports[0].OutputDir)
+	assert.DirExists(t, localDest)
+
+	exports, err = getOutputExports([]string{"type=oci,dest=" + tarDest})
+	require.NoError(t, err)
+	require.Len(t, exports, 1)
+	assert.Equal(t, client.ExporterOCI, exports[0].Type)
+	require.NotNil(t, exports[0].Output)
+	assert.NoFileExists(t, tarDest)
+
+	w, err := exports[0].Output(nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.FileExists(t, tarDest)
+
+	_, err = getOutputExports([]string{"type=image,dest=" + localDest})
+	assert.Error(t, err)
+
+	_, err = getOutputExports([]string{"type=tar,dest=" + localDest})
+	assert.Error(t, err)
+
+	_, err = getOutputExports([]string{"type=local,dest=" + localDest, "type=oci,dest=" + tarDest})
+	assert.Error(t, err)
+}
+
+func Test_getSolveOptWithOutputs(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM alpine"), 0600))
+	dest := filepath.Join(dir, "out")
+
+	opt, err := getSolveOpt(&types.BuildOptions{
+		Path:    dir,
+		File:    dockerfile,
+		Tag:     "okteto/test:1.0",
+		Outputs: []string{"type=local,dest=" + dest},
+	})
+	require.NoError(t, err)
+	require.Len(t, opt.Exports, 1)
+	assert.Equal(t, client.ExporterLocal, opt.Exports[0].Type)
+	assert.Equal(t, dest, opt.Exports[0].OutputDir)
+}
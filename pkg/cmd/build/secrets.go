@@ -0,0 +1,235 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// secretSourceOkteto is the key of the secrets sourced from the Okteto secret store: 'id=mysecret,okteto=NPM_TOKEN'
+	secretSourceOkteto = "okteto"
+	// secretSourceCommand is the key of the secrets sourced from the output of a command: 'id=mysecret,command=gh auth token'
+	secretSourceCommand = "command"
+)
+
+var errOktetoSecretsWithoutOkteto = oktetoErrors.UserError{
+	E:    fmt.Errorf("build secrets sourced from Okteto secrets require an Okteto context"),
+	Hint: "Run 'okteto context' to select an Okteto context, or use an environment variable or a command as the source of the secret",
+}
+
+type secretsGetterInterface interface {
+	GetUserSecrets(ctx context.Context) ([]types.Secret, error)
+}
+
+// secretsResolver reads the values of the secrets that are kept in memory
+type secretsResolver struct {
+	getSecretsGetter func() (secretsGetterInterface, error)
+	runCommand       func(ctx context.Context, command string) ([]byte, error)
+}
+
+func newSecretsResolver() secretsResolver {
+	return secretsResolver{
+		getSecretsGetter: getOktetoSecretsGetter,
+		runCommand:       runSecretCommand,
+	}
+}
+
+// resolve reads the values of the secrets sourced from Okteto secrets or from the output of a command.
+// These secrets are removed from buildOptions.Secrets and their values are kept in buildOptions.SecretValues
+func (sr secretsResolver) resolve(ctx context.Context, buildOptions *types.BuildOptions) error {
+	remaining := []string{}
+	var oktetoSecrets map[string]string
+	for _, value := range buildOptions.Secrets {
+		fields, err := csv.NewReader(strings.NewReader(value)).Read()
+		if err != nil {
+			return fmt.Errorf("error reading the csv secret, %w", err)
+		}
+
+		var id, source, sourceValue string
+		for _, field := range fields {
+			key, val, found := strings.Cut(field, "=")
+			if !found {
+				return fmt.Errorf("secret format error")
+			}
+			switch strings.ToLower(key) {
+			case "id":
+				id = val
+			case secretSourceOkteto, secretSourceCommand:
+				source, sourceValue = strings.ToLower(key), val
+			}
+		}
+		if source == "" {
+			remaining = append(remaining, value)
+			continue
+		}
+		if id == "" {
+			return fmt.Errorf("secret '%s' has no id", value)
+		}
+
+		if buildOptions.SecretValues == nil {
+			buildOptions.SecretValues = map[string][]byte{}
+		}
+		switch source {
+		case secretSourceOkteto:
+			if oktetoSecrets == nil {
+				oktetoSecrets, err = sr.getOktetoSecrets(ctx)
+				if err != nil {
+					return err
+				}
+			}
+			secretValue, ok := oktetoSecrets[sourceValue]
+			if !ok {
+				return fmt.Errorf("secret '%s': okteto secret '%s' not found", id, sourceValue)
+			}
+			buildOptions.SecretValues[id] = []byte(secretValue)
+		case secretSourceCommand:
+			oktetoLog.Infof("running the command of secret '%s'", id)
+			output, err := sr.runCommand(ctx, sourceValue)
+			if err != nil {
+				return fmt.Errorf("secret '%s': %w", id, err)
+			}
+			buildOptions.SecretValues[id] = output
+		}
+	}
+	buildOptions.Secrets = remaining
+	return nil
+}
+
+func (sr secretsResolver) getOktetoSecrets(ctx context.Context) (map[string]string, error) {
+	getter, err := sr.getSecretsGetter()
+	if err != nil {
+		return nil, err
+	}
+	userSecrets, err := getter.GetUserSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the okteto secrets: %w", err)
+	}
+	result := make(map[string]string, len(userSecrets))
+	for _, s := range userSecrets {
+		result[s.Name] = s.Value
+	}
+	return result, nil
+}
+
+func getOktetoSecretsGetter() (secretsGetterInterface, error) {
+	if !okteto.IsOkteto() {
+		return nil, errOktetoSecretsWithoutOkteto
+	}
+	c, err := okteto.NewOktetoClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.User(), nil
+}
+
+// runSecretCommand returns the standard output of a command without the trailing line break
+func runSecretCommand(ctx context.Context, command string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("command '%s' failed: %w: %s", command, err, msg)
+		}
+		return nil, fmt.Errorf("command '%s' failed: %w", command, err)
+	}
+	output = bytes.TrimSuffix(output, []byte("\n"))
+	return bytes.TrimSuffix(output, []byte("\r")), nil
+}
+
+// getSecretProvider returns the session attachable that provides the build secrets to BuildKit
+func getSecretProvider(buildOptions *types.BuildOptions) (session.Attachable, error) {
+	sources := make([]secretsprovider.Source, 0, len(buildOptions.Secrets))
+	for _, value := range buildOptions.Secrets {
+		source, err := parseSecretSource(value)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	store, err := secretsprovider.NewStore(sources)
+	if err != nil {
+		return nil, err
+	}
+	return secretsprovider.NewSecretProvider(&inMemorySecretStore{
+		values: buildOptions.SecretValues,
+		store:  store,
+	}), nil
+}
+
+// parseSecretSource parses a secret with the syntax 'id=mysecret,src=/local/secret' or 'id=mysecret,env=MY_SECRET'
+func parseSecretSource(value string) (secretsprovider.Source, error) {
+	source := secretsprovider.Source{}
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return source, fmt.Errorf("failed to parse csv secret: %w", err)
+	}
+
+	var typ string
+	for _, field := range fields {
+		key, val, found := strings.Cut(field, "=")
+		if !found {
+			return source, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		switch strings.ToLower(key) {
+		case "type":
+			if val != "file" && val != "env" {
+				return source, fmt.Errorf("unsupported secret type '%s'", val)
+			}
+			typ = val
+		case "id":
+			source.ID = val
+		case "source", "src":
+			source.FilePath = val
+		case "env":
+			source.Env = val
+		default:
+			return source, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+	if typ == "env" && source.Env == "" {
+		source.Env = source.FilePath
+		source.FilePath = ""
+	}
+	return source, nil
+}
+
+// inMemorySecretStore provides the secrets kept in memory and falls back to the secrets read from files or environment variables
+type inMemorySecretStore struct {
+	values map[string][]byte
+	store  secrets.SecretStore
+}
+
+// GetSecret returns the value of a secret
+func (s *inMemorySecretStore) GetSecret(ctx context.Context, id string) ([]byte, error) {
+	if value, ok := s.values[id]; ok {
+		return value, nil
+	}
+	return s.store.GetSecret(ctx, id)
+}
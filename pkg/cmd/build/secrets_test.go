@@ -0,0 +1,225 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecretsGetter struct {
+	err     error
+	secrets []types.Secret
+	calls   int
+}
+
+func (fsg *fakeSecretsGetter) GetUserSecrets(_ context.Context) ([]types.Secret, error) {
+	fsg.calls++
+	return fsg.secrets, fsg.err
+}
+
+func newFakeSecretsResolver(getter *fakeSecretsGetter) secretsResolver {
+	return secretsResolver{
+		getSecretsGetter: func() (secretsGetterInterface, error) {
+			return getter, nil
+		},
+		runCommand: func(_ context.Context, command string) ([]byte, error) {
+			if command == "fail" {
+				return nil, errors.New("exit status 1")
+			}
+			return []byte("output of " + command), nil
+		},
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	getter := &fakeSecretsGetter{
+		secrets: []types.Secret{
+			{Name: "NPM_TOKEN", Value: "npm-value"},
+			{Name: "GITHUB_TOKEN", Value: "github-value"},
+		},
+	}
+	buildOptions := &types.BuildOptions{
+		Secrets: []string{
+			"id=npmrc,src=.npmrc",
+			"id=npm,okteto=NPM_TOKEN",
+			"id=aws,env=AWS_SECRET_ACCESS_KEY",
+			`id=gh,"command=gh auth token, --hostname github.com"`,
+			"okteto=GITHUB_TOKEN,id=github",
+		},
+	}
+
+	require.NoError(t, newFakeSecretsResolver(getter).resolve(context.Background(), buildOptions))
+	assert.Equal(t, []string{"id=npmrc,src=.npmrc", "id=aws,env=AWS_SECRET_ACCESS_KEY"}, buildOptions.Secrets)
+	assert.Equal(t, map[string][]byte{
+		"npm":    []byte("npm-value"),
+		"gh":     []byte("output of gh auth token, --hostname github.com"),
+		"github": []byte("github-value"),
+	}, buildOptions.SecretValues)
+	assert.Equal(t, 1, getter.calls)
+}
+
+func TestResolveSecretsWithoutInMemorySecrets(t *testing.T) {
+	getter := &fakeSecretsGetter{}
+	buildOptions := &types.BuildOptions{Secrets: []string{"id=npmrc,src=.npmrc"}}
+	require.NoError(t, newFakeSecretsResolver(getter).resolve(context.Background(), buildOptions))
+	assert.Equal(t, []string{"id=npmrc,src=.npmrc"}, buildOptions.Secrets)
+	assert.Nil(t, buildOptions.SecretValues)
+	assert.Zero(t, getter.calls)
+}
+
+func TestResolveSecretsErrors(t *testing.T) {
+	tests := []struct {
+		getter *fakeSecretsGetter
+		name   string
+		secret string
+	}{
+		{
+			name:   "okteto secret not found",
+			getter: &fakeSecretsGetter{},
+			secret: "id=npm,okteto=NPM_TOKEN",
+		},
+		{
+			name:   "okteto api error",
+			getter: &fakeSecretsGetter{err: assert.AnError},
+			secret: "id=npm,okteto=NPM_TOKEN",
+		},
+		{
+			name:   "command error",
+			getter: &fakeSecretsGetter{},
+			secret: "id=npm,command=fail",
+		},
+		{
+			name:   "no id",
+			getter: &fakeSecretsGetter{},
+			secret: "okteto=NPM_TOKEN",
+		},
+		{
+			name:   "wrong format",
+			getter: &fakeSecretsGetter{},
+			secret: "id=npm,okteto",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildOptions := &types.BuildOptions{Secrets: []string{tt.secret}}
+			assert.Error(t, newFakeSecretsResolver(tt.getter).resolve(context.Background(), buildOptions))
+		})
+	}
+}
+
+func TestRunSecretCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test is not supported on windows")
+	}
+	output, err := runSecretCommand(context.Background(), "echo my-token")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("my-token"), output)
+
+	_, err = runSecretCommand(context.Background(), "echo failed >&2; exit 1")
+	assert.ErrorContains(t, err, "failed")
+}
+
+func TestParseSecretSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    secretsprovider.Source
+		expectedErr bool
+	}{
+		{
+			name:     "file",
+			value:    "id=npmrc,src=.npmrc",
+			expected: secretsprovider.Source{ID: "npmrc", FilePath: ".npmrc"},
+		},
+		{
+			name:     "env",
+			value:    "id=aws,env=AWS_SECRET_ACCESS_KEY",
+			expected: secretsprovider.Source{ID: "aws", Env: "AWS_SECRET_ACCESS_KEY"},
+		},
+		{
+			name:     "env type",
+			value:    "id=aws,type=env,source=AWS_SECRET_ACCESS_KEY",
+			expected: secretsprovider.Source{ID: "aws", Env: "AWS_SECRET_ACCESS_KEY"},
+		},
+		{
+			name:        "unknown type",
+			value:       "id=aws,type=vault",
+			expectedErr: true,
+		},
+		{
+			name:        "unknown key",
+			value:       "id=aws,okteto=AWS",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseSecretSource(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestInMemorySecretStore(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "npmrc")
+	require.NoError(t, os.WriteFile(secretPath, []byte("file-value"), 0600))
+	t.Setenv("OKTETO_TEST_BUILD_SECRET", "env-value")
+
+	store, err := secretsprovider.NewStore([]secretsprovider.Source{
+		{ID: "npmrc", FilePath: secretPath},
+		{ID: "env", Env: "OKTETO_TEST_BUILD_SECRET"},
+	})
+	require.NoError(t, err)
+	s := &inMemorySecretStore{
+		values: map[string][]byte{"npm": []byte("memory-value")},
+		store:  store,
+	}
+
+	ctx := context.Background()
+	for id, expected := range map[string]string{"npm": "memory-value", "npmrc": "file-value", "env": "env-value"} {
+		value, err := s.GetSecret(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(value))
+	}
+	_, err = s.GetSecret(ctx, "unknown")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+}
+
+func TestGetSecretProvider(t *testing.T) {
+	_, err := getSecretProvider(&types.BuildOptions{
+		Secrets:      []string{"id=aws,env=AWS_SECRET_ACCESS_KEY"},
+		SecretValues: map[string][]byte{"npm": []byte("value")},
+	})
+	assert.NoError(t, err)
+
+	_, err = getSecretProvider(&types.BuildOptions{Secrets: []string{"id=npmrc,src=/not/found"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+// timingCollector computes the timing of a build from the vertexes reported by BuildKit.
+// The vertex times come from the clock of the BuildKit server, so they are only compared between them:
+// the queue time is measured with the local clock, from the build request until the first vertex starts
+type timingCollector struct {
+	requested    time.Time
+	firstStarted time.Time
+	now          func() time.Time
+	vertexes     map[string]*client.Vertex
+}
+
+func newTimingCollector(requested time.Time) *timingCollector {
+	return &timingCollector{
+		requested: requested,
+		now:       time.Now,
+		vertexes:  map[string]*client.Vertex{},
+	}
+}
+
+// update merges the vertexes of a solve status into the ones already collected
+func (tc *timingCollector) update(ss *client.SolveStatus) {
+	for _, rawVertex := range ss.Vertexes {
+		if rawVertex.Started != nil && tc.firstStarted.IsZero() {
+			tc.firstStarted = tc.now()
+		}
+		v, ok := tc.vertexes[rawVertex.Digest.Encoded()]
+		if !ok {
+			v = &client.Vertex{
+				Digest: rawVertex.Digest,
+				Name:   rawVertex.Name,
+			}
+			tc.vertexes[rawVertex.Digest.Encoded()] = v
+		}
+		if rawVertex.Started != nil && (v.Started == nil || rawVertex.Started.Before(*v.Started)) {
+			v.Started = rawVertex.Started
+		}
+		if rawVertex.Completed != nil && (v.Completed == nil || rawVertex.Completed.After(*v.Completed)) {
+			v.Completed = rawVertex.Completed
+		}
+		v.Cached = v.Cached || rawVertex.Cached
+	}
+}
+
+// timing returns the timing of the build: the queue time is the time until the first vertex starts,
+// the push time is the span of the export vertexes and the solve time is the span of the rest of vertexes
+func (tc *timingCollector) timing() types.BuildTiming {
+	result := types.BuildTiming{}
+	solveVertexes := []*client.Vertex{}
+	exportVertexes := []*client.Vertex{}
+	for _, v := range tc.vertexes {
+		if isExportVertex(v.Name) {
+			exportVertexes = append(exportVertexes, v)
+			continue
+		}
+		solveVertexes = append(solveVertexes, v)
+		if isInternalVertex(v.Name) {
+			continue
+		}
+		result.Steps++
+		if v.Cached {
+			result.CachedSteps++
+		}
+	}
+
+	if tc.firstStarted.After(tc.requested) {
+		result.Queue = tc.firstStarted.Sub(tc.requested)
+	}
+	if started, completed := getVertexesSpan(solveVertexes); started != nil && completed != nil {
+		result.Solve = completed.Sub(*started)
+	}
+	if started, completed := getVertexesSpan(exportVertexes); started != nil && completed != nil {
+		result.Push = completed.Sub(*started)
+	}
+	return result
+}
+
+// getVertexesSpan returns the first start time and the last completion time of a list of vertexes
+func getVertexesSpan(vertexes []*client.Vertex) (*time.Time, *time.Time) {
+	var started, completed *time.Time
+	for _, v := range vertexes {
+		if v.Started != nil && (started == nil || v.Started.Before(*started)) {
+			started = v.Started
+		}
+		if v.Completed != nil && (completed == nil || v.Completed.After(*completed)) {
+			completed = v.Completed
+		}
+	}
+	return started, completed
+}
+
+func isExportVertex(name string) bool {
+	return strings.HasPrefix(name, "exporting")
+}
+
+func isInternalVertex(name string) bool {
+	return strings.HasPrefix(name, "[internal]")
+}
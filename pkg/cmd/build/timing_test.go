@@ -0,0 +1,72 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimingCollector(t *testing.T) {
+	requested := time.Now()
+	// the clock of the buildkit server is ahead of the local clock
+	serverRequested := requested.Add(time.Hour)
+	at := func(seconds int) *time.Time {
+		t := serverRequested.Add(time.Duration(seconds) * time.Second)
+		return &t
+	}
+
+	collector := newTimingCollector(requested)
+	collector.now = func() time.Time {
+		return requested.Add(2 * time.Second)
+	}
+	collector.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:internal", Name: "[internal] load build definition from Dockerfile", Started: at(2)},
+			{Digest: "sha256:from", Name: "[1/3] FROM alpine", Started: at(3)},
+		},
+	})
+	collector.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:internal", Name: "[internal] load build definition from Dockerfile", Started: at(2), Completed: at(3)},
+			{Digest: "sha256:from", Name: "[1/3] FROM alpine", Started: at(3), Completed: at(4), Cached: true},
+			{Digest: "sha256:copy", Name: "[2/3] COPY . .", Started: at(4), Completed: at(5), Cached: true},
+			{Digest: "sha256:run", Name: "[3/3] RUN make", Started: at(5), Completed: at(12)},
+			{Digest: "sha256:export", Name: "exporting to image", Started: at(12)},
+		},
+	})
+	collector.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:export", Name: "exporting to image", Completed: at(15)},
+		},
+	})
+
+	expected := types.BuildTiming{
+		Queue:       2 * time.Second,
+		Solve:       10 * time.Second,
+		Push:        3 * time.Second,
+		Steps:       3,
+		CachedSteps: 2,
+	}
+	assert.Equal(t, expected, collector.timing())
+}
+
+func TestTimingCollectorWithoutVertexes(t *testing.T) {
+	collector := newTimingCollector(time.Now())
+	assert.Equal(t, types.BuildTiming{}, collector.timing())
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	historyField = "history"
+
+	// MaxRevisions is the number of deploy revisions kept in the pipeline configmap
+	MaxRevisions = 10
+
+	// maxHistorySize is the maximum size of the encoded history. The oldest revisions are discarded
+	// when it is exceeded to leave room in the configmap for the deploy output
+	maxHistorySize = 100 * 1024
+)
+
+// Revision represents the input of a deploy, stored in the pipeline configmap to be able to roll it back
+type Revision struct {
+	Date       time.Time         `json:"date"`
+	Images     map[string]string `json:"images,omitempty"`
+	Status     string            `json:"status"`
+	GitCommit  string            `json:"gitCommit,omitempty"`
+	Branch     string            `json:"branch,omitempty"`
+	Manifest   []byte            `json:"manifest,omitempty"`
+	Variables  []string          `json:"variables,omitempty"`
+	Number     int               `json:"revision"`
+	RollbackOf int               `json:"rollbackOf,omitempty"`
+}
+
+// GetHistory returns the revisions stored in Data["history"], from the oldest to the newest
+func GetHistory(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]Revision, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return decodeHistory(name, cmap.Data[historyField])
+}
+
+// AddRevision stores a new revision in the configmap, discarding the oldest ones when there are more than MaxRevisions.
+// It returns the number assigned to the revision
+func AddRevision(ctx context.Context, name, namespace string, revision Revision, c kubernetes.Interface) (int, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return 0, err
+	}
+
+	history, err := decodeHistory(name, cmap.Data[historyField])
+	if err != nil {
+		return 0, err
+	}
+
+	revision.Number = 1
+	if len(history) > 0 {
+		revision.Number = history[len(history)-1].Number + 1
+	}
+	history = append(history, revision)
+	if len(history) > MaxRevisions {
+		history = history[len(history)-MaxRevisions:]
+	}
+
+	encodedHistory, err := encodeHistory(history)
+	if err != nil {
+		return 0, err
+	}
+	for len(encodedHistory) > maxHistorySize && len(history) > 1 {
+		history = history[1:]
+		encodedHistory, err = encodeHistory(history)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	cmap.Data[historyField] = encodedHistory
+	if err := configmaps.Deploy(ctx, cmap, cmap.Namespace, c); err != nil {
+		return 0, err
+	}
+	return revision.Number, nil
+}
+
+func encodeHistory(history []Revision) (string, error) {
+	bytes, err := json.Marshal(history)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bytes), nil
+}
+
+func decodeHistory(name, encodedHistory string) ([]Revision, error) {
+	if encodedHistory == "" {
+		return nil, nil
+	}
+	decodedHistory, err := base64.StdEncoding.DecodeString(encodedHistory)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the deploy history of '%s': %w", name, err)
+	}
+	var history []Revision
+	if err := json.Unmarshal(decodedHistory, &history); err != nil {
+		return nil, fmt.Errorf("could not decode the deploy history of '%s': %w", name, err)
+	}
+	return history, nil
+}
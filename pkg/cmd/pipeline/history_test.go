@@ -0,0 +1,99 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_addAndGetHistory(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test"
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(cmap)
+
+	history, err := GetHistory(ctx, "test", namespace, fakeClient)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	for i := 1; i <= MaxRevisions+2; i++ {
+		number, err := AddRevision(ctx, "test", namespace, Revision{
+			Status:    DeployedStatus,
+			Manifest:  []byte("deploy:\n  - echo hello"),
+			Variables: []string{"A=B"},
+			Images:    map[string]string{"OKTETO_BUILD_API_IMAGE": "okteto.dev/api@sha256:123"},
+			GitCommit: "1234567",
+		}, fakeClient)
+		require.NoError(t, err)
+		assert.Equal(t, i, number)
+	}
+
+	history, err = GetHistory(ctx, "test", namespace, fakeClient)
+	require.NoError(t, err)
+	require.Len(t, history, MaxRevisions)
+	assert.Equal(t, 3, history[0].Number)
+	assert.Equal(t, MaxRevisions+2, history[MaxRevisions-1].Number)
+	assert.Equal(t, []byte("deploy:\n  - echo hello"), history[0].Manifest)
+	assert.Equal(t, []string{"A=B"}, history[0].Variables)
+	assert.Equal(t, "okteto.dev/api@sha256:123", history[0].Images["OKTETO_BUILD_API_IMAGE"])
+
+	history, err = GetHistory(ctx, "not-found", namespace, fakeClient)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	_, err = AddRevision(ctx, "not-found", namespace, Revision{}, fakeClient)
+	assert.Error(t, err)
+}
+
+func Test_addRevisionDiscardsOldRevisionsWhenHistoryIsTooLarge(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test"
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Data: map[string]string{},
+	}
+	fakeClient := fake.NewSimpleClientset(cmap)
+
+	manifest := []byte(strings.Repeat("a", maxHistorySize/4))
+	for i := 0; i < 4; i++ {
+		_, err := AddRevision(ctx, "test", namespace, Revision{Manifest: manifest}, fakeClient)
+		require.NoError(t, err)
+	}
+
+	history, err := GetHistory(ctx, "test", namespace, fakeClient)
+	require.NoError(t, err)
+	assert.Less(t, len(history), 4)
+	assert.Equal(t, 4, history[len(history)-1].Number)
+}
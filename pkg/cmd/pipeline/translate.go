@@ -53,6 +53,7 @@ const (
 	actionLockField = "actionLock"
 	actionNameField = "actionName"
 	variablesField  = "variables"
+	inventoryField  = "inventory"
 
 	actionDefaultName = "cli"
 
@@ -92,6 +93,16 @@ type CfgData struct {
 	Variables  []string
 }
 
+// InventoryItem identifies an object applied by the deploy commands
+type InventoryItem struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
 // GetConfigmapVariablesEncoded returns Data["variables"] content from Configmap
 func GetConfigmapVariablesEncoded(ctx context.Context, name, namespace string, c kubernetes.Interface) (string, error) {
 	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
@@ -186,6 +197,46 @@ func UpdateEnvs(ctx context.Context, name, namespace string, envs []string, c ku
 	return nil
 }
 
+// GetInventory returns the objects applied by the last successful deploy, stored in Data["inventory"]
+func GetInventory(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]InventoryItem, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	encodedInventory, ok := cmap.Data[inventoryField]
+	if !ok || encodedInventory == "" {
+		return nil, nil
+	}
+	decodedInventory, err := base64.StdEncoding.DecodeString(encodedInventory)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the inventory of '%s': %w", name, err)
+	}
+	var inventory []InventoryItem
+	if err := json.Unmarshal(decodedInventory, &inventory); err != nil {
+		return nil, fmt.Errorf("could not decode the inventory of '%s': %w", name, err)
+	}
+	return inventory, nil
+}
+
+// UpdateInventory updates the configmap with the objects applied by the deploy
+func UpdateInventory(ctx context.Context, name, namespace string, inventory []InventoryItem, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	encodedInventory, err := json.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+	cmap.Data[inventoryField] = base64.StdEncoding.EncodeToString(encodedInventory)
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
 // TranslatePipelineName translate the name into the configmap name
 func TranslatePipelineName(name string) string {
 	return fmt.Sprintf("okteto-git-%s", format.ResourceK8sMetaString(name))
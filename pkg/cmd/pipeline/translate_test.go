@@ -143,6 +143,42 @@ func Test_updateEnvsWithError(t *testing.T) {
 	}
 }
 
+func Test_updateAndGetInventory(t *testing.T) {
+	ctx := context.Background()
+	namespace := "test"
+	cmap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Data: map[string]string{
+			statusField: DeployedStatus,
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(cmap)
+
+	inventory, err := GetInventory(ctx, "test", namespace, fakeClient)
+	assert.NoError(t, err)
+	assert.Empty(t, inventory)
+
+	expected := []InventoryItem{
+		{Version: "v1", Kind: "Service", Resource: "services", Namespace: namespace, Name: "api"},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments", Namespace: namespace, Name: "api"},
+	}
+	assert.NoError(t, UpdateInventory(ctx, "test", namespace, expected, fakeClient))
+
+	inventory, err = GetInventory(ctx, "test", namespace, fakeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, inventory)
+
+	inventory, err = GetInventory(ctx, "not-found", namespace, fakeClient)
+	assert.NoError(t, err)
+	assert.Empty(t, inventory)
+
+	assert.Error(t, UpdateInventory(ctx, "not-found", namespace, expected, fakeClient))
+}
+
 func Test_AddDevAnnotations(t *testing.T) {
 	ctx := context.Background()
 	d := &appsv1.Deployment{
@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// socketHashLength is the length of the hash that identifies the socket of a session
+	socketHashLength = 12
+	requestTimeout   = 5 * time.Second
+
+	// the host is ignored: requests are always sent to the socket of the session
+	sessionURL = "http://okteto/session"
+	stopURL    = "http://okteto/stop"
+)
+
+var (
+	// ErrNotFound is returned when there is no daemon running for a session
+	ErrNotFound = errors.New("detached session not found")
+)
+
+// Session is the status of a detached 'okteto up' session as exposed by its daemon
+type Session struct {
+	StartTime  time.Time      `json:"startTime" yaml:"startTime"`
+	Name       string         `json:"name" yaml:"name"`
+	Namespace  string         `json:"namespace" yaml:"namespace"`
+	Context    string         `json:"context" yaml:"context"`
+	State      config.UpState `json:"state" yaml:"state"`
+	Interface  string         `json:"interface" yaml:"interface"`
+	Pod        string         `json:"pod" yaml:"pod"`
+	Container  string         `json:"container,omitempty" yaml:"container,omitempty"`
+	Command    []string       `json:"command" yaml:"command"`
+	RemotePort int            `json:"remotePort" yaml:"remotePort"`
+	PID        int            `json:"pid" yaml:"pid"`
+}
+
+// Server exposes the session of a daemon on its local socket
+type Server struct {
+	server *http.Server
+	path   string
+}
+
+// SocketPath returns the path of the socket where the daemon of a session listens.
+// Unix socket paths are limited to about 100 bytes, so the socket is created in the temp dir with a hashed name
+func SocketPath(namespace, name string) string {
+	return getSocketPath(config.GetAppHome(namespace, name))
+}
+
+func getSocketPath(appHome string) string {
+	hash := sha256.Sum256([]byte(appHome))
+	return filepath.Join(os.TempDir(), fmt.Sprintf("okteto-%s.sock", hex.EncodeToString(hash[:])[:socketHashLength]))
+}
+
+// Serve exposes the session of a dev container on its socket.
+// getSession returns the current status of the session and stop is called when a client asks the daemon to stop
+func Serve(namespace, name string, getSession func() Session, stop func()) (*Server, error) {
+	path := SocketPath(namespace, name)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to remove the socket '%s': %w", path, err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on '%s': %w", path, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/session", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(getSession()); err != nil {
+			oktetoLog.Infof("failed to write the session: %s", err)
+		}
+	})
+	mux.HandleFunc("/stop", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		go stop()
+	})
+
+	s := &Server{
+		server: &http.Server{Handler: mux, ReadHeaderTimeout: requestTimeout},
+		path:   path,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			oktetoLog.Infof("session server stopped: %s", err)
+		}
+	}()
+	return s, nil
+}
+
+// Close stops serving the session and removes its socket
+func (s *Server) Close() error {
+	if err := s.server.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Get returns the session of a dev container from its daemon
+func Get(ctx context.Context, namespace, name string) (*Session, error) {
+	return get(ctx, SocketPath(namespace, name))
+}
+
+// Stop asks the daemon of a session to stop it
+func Stop(ctx context.Context, namespace, name string) error {
+	resp, err := request(ctx, SocketPath(namespace, name), http.MethodPost, stopURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("failed to stop the session of '%s': %s", name, resp.Status)
+	}
+	return nil
+}
+
+// List returns the sessions of all the running daemons, sorted by namespace and name.
+// Sockets left behind by daemons that are not running anymore are removed
+func List(ctx context.Context) ([]Session, error) {
+	appHomes, err := filepath.Glob(filepath.Join(config.GetOktetoHome(), "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Session{}
+	for _, appHome := range appHomes {
+		path := getSocketPath(appHome)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		s, err := get(ctx, path)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				oktetoLog.Infof("removing stale socket '%s': %s", path, err)
+				if err := os.Remove(path); err != nil {
+					oktetoLog.Infof("failed to remove stale socket '%s': %s", path, err)
+				}
+				continue
+			}
+			oktetoLog.Infof("failed to get the session from '%s': %s", path, err)
+			continue
+		}
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func get(ctx context.Context, path string) (*Session, error) {
+	resp, err := request(ctx, path, http.MethodGet, sessionURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the session from '%s': %s", path, resp.Status)
+	}
+
+	s := &Session{}
+	if err := json.NewDecoder(resp.Body).Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to decode the session from '%s': %w", path, err)
+	}
+	return s, nil
+}
+
+func request(ctx context.Context, path, method, url string) (*http.Response, error) {
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			// the daemon isn't listening: the socket doesn't exist or it was left behind
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeAndGet(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	ctx := context.Background()
+
+	expected := Session{
+		Name:       "api",
+		Namespace:  "ns",
+		State:      config.Ready,
+		PID:        1234,
+		RemotePort: 2222,
+		Interface:  "localhost",
+		Command:    []string{"bash"},
+		StartTime:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	stopped := make(chan bool, 1)
+	s, err := Serve("ns", "api", func() Session { return expected }, func() { stopped <- true })
+	require.NoError(t, err)
+
+	got, err := Get(ctx, "ns", "api")
+	require.NoError(t, err)
+	assert.Equal(t, expected, *got)
+
+	sessions, err := List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Session{expected}, sessions)
+
+	require.NoError(t, Stop(ctx, "ns", "api"))
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stop function wasn't called")
+	}
+
+	require.NoError(t, s.Close())
+	_, err = Get(ctx, "ns", "api")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestListRemovesStaleSockets(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	ctx := context.Background()
+
+	stale := SocketPath("ns", "stale")
+	require.NoError(t, os.WriteFile(stale, []byte{}, 0600))
+
+	s, err := Serve("ns", "api", func() Session { return Session{Name: "api", Namespace: "ns"} }, func() {})
+	require.NoError(t, err)
+	defer s.Close()
+
+	sessions, err := List(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "api", sessions[0].Name)
+
+	_, err = os.Stat(stale)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(SocketPath("ns", "api"))
+	assert.NoError(t, err)
+}
+
+func TestSocketPathLength(t *testing.T) {
+	home := filepath.Join(t.TempDir(), strings.Repeat("a", 100))
+	require.NoError(t, os.Mkdir(home, 0700))
+	t.Setenv(constants.OktetoFolderEnvVar, home)
+	name := strings.Repeat("b", 63)
+	path := SocketPath(strings.Repeat("c", 63), name)
+	assert.Less(t, len(path), 104)
+	assert.NotEqual(t, path, SocketPath("other", name))
+
+	s, err := Serve(strings.Repeat("c", 63), name, func() Session { return Session{Name: name} }, func() {})
+	require.NoError(t, err)
+	defer s.Close()
+	got, err := Get(context.Background(), strings.Repeat("c", 63), name)
+	require.NoError(t, err)
+	assert.Equal(t, name, got.Name)
+}
+
+func TestStopNotRunning(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	err := Stop(context.Background(), "ns", "api")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -36,6 +36,7 @@ const (
 	tokenFile               = ".token.json"
 	contextDir              = "context"
 	contextsStoreFile       = "config.json"
+	buildCacheFile          = "build-cache.json"
 
 	oktetoFolderName = ".okteto"
 	// Activating up started
@@ -266,6 +267,11 @@ func GetOktetoContextsStorePath() string {
 	return filepath.Join(GetOktetoContextFolder(), contextsStoreFile)
 }
 
+// GetBuildCachePath returns the path to the local build cache
+func GetBuildCachePath() string {
+	return filepath.Join(GetOktetoHome(), buildCacheFile)
+}
+
 // GetCertificatePath returns the path to the certificate of the okteto buildkit
 func GetCertificatePath() string {
 	return filepath.Join(GetOktetoHome(), ".ca.crt")
@@ -102,6 +102,18 @@ const (
 	// OktetoGitCommitEnvVar is the SHA1 hash of the last commit of the branch.
 	OktetoGitCommitEnvVar = "OKTETO_GIT_COMMIT"
 
+	// OktetoSignKeyEnvVar is the PEM encoded private key used to sign images when no key file is given
+	OktetoSignKeyEnvVar = "OKTETO_SIGN_KEY"
+
+	// OktetoSignKeyPasswordEnvVar is the password of the private key used to sign images
+	OktetoSignKeyPasswordEnvVar = "OKTETO_SIGN_KEY_PASSWORD"
+
+	// CosignPasswordEnvVar is the password of the private key used by cosign
+	CosignPasswordEnvVar = "COSIGN_PASSWORD"
+
+	// OktetoVerifyKeyEnvVar is the PEM encoded public key used to verify images when no key file is given
+	OktetoVerifyKeyEnvVar = "OKTETO_VERIFY_KEY"
+
 	// OktetoNamespaceLabel is the label used to identify the namespace where the resource lives
 	OktetoNamespaceLabel = "dev.okteto.com/namespace"
 
@@ -111,6 +123,9 @@ const (
 	// OktetoDivertIstioDriver is the divert driver for istio
 	OktetoDivertIstioDriver = "istio"
 
+	// OktetoDivertGatewayDriver is the divert driver for the kubernetes gateway API
+	OktetoDivertGatewayDriver = "gateway"
+
 	// OktetoDivertBaggageHeader represents the baggage header
 	OktetoDivertBaggageHeader = "baggage"
 
@@ -18,15 +18,19 @@ import (
 	"fmt"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert/gateway"
 	"github.com/okteto/okteto/pkg/divert/istio"
+	"github.com/okteto/okteto/pkg/divert/status"
 	"github.com/okteto/okteto/pkg/divert/weaver"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/httproutes"
 	"github.com/okteto/okteto/pkg/k8s/virtualservices"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 type Driver interface {
@@ -34,6 +38,8 @@ type Driver interface {
 	Destroy(ctx context.Context) error
 	UpdatePod(spec apiv1.PodSpec) apiv1.PodSpec
 	UpdateVirtualService(vs *istioNetworkingV1beta1.VirtualService)
+	UpdateHTTPRoute(route *gatewayV1beta1.HTTPRouteSpec)
+	Status(ctx context.Context) ([]status.Route, error)
 }
 
 func New(m *model.Manifest, c kubernetes.Interface) (Driver, error) {
@@ -45,6 +51,14 @@ func New(m *model.Manifest, c kubernetes.Interface) (Driver, error) {
 		return weaver.New(m, c), nil
 	}
 
+	if m.Deploy.Divert.Driver == constants.OktetoDivertGatewayDriver {
+		gc, err := httproutes.GetGatewayClient()
+		if err != nil {
+			return nil, fmt.Errorf("error creating gateway API client: %w", err)
+		}
+		return gateway.New(m, c, gc), nil
+	}
+
 	ic, err := virtualservices.GetIstioClient()
 	if err != nil {
 		return nil, fmt.Errorf("error creating istio client: %w", err)
@@ -0,0 +1,224 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/httproutes"
+	"github.com/okteto/okteto/pkg/k8s/labels"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	gatewayV1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+)
+
+const (
+	updateConflictRetries = 20
+
+	// referenceGrantTemplate is the name of the reference grant that allows the http routes of a namespace
+	// to send traffic to the services of the diverted namespace
+	referenceGrantTemplate = "okteto-divert-%s"
+)
+
+// Driver gateway struct for the divert driver
+type Driver struct {
+	client        kubernetes.Interface
+	gatewayClient gatewayclientset.Interface
+	name          string
+	namespace     string
+	divert        model.DivertDeploy
+}
+
+// New returns a divert driver that adds header matched rules to gateway API http routes
+func New(m *model.Manifest, c kubernetes.Interface, gc gatewayclientset.Interface) *Driver {
+	return &Driver{
+		name:          m.Name,
+		namespace:     m.Namespace,
+		divert:        *m.Deploy.Divert,
+		client:        c,
+		gatewayClient: gc,
+	}
+}
+
+func (d *Driver) Deploy(ctx context.Context) error {
+	services, err := d.getDivertedServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range d.divert.HTTPRoutes {
+		select {
+		case <-ctx.Done():
+			oktetoLog.Infof("deployDivert context cancelled")
+			return ctx.Err()
+		default:
+			route := d.divert.HTTPRoutes[i]
+			oktetoLog.Spinner(fmt.Sprintf("Diverting http route %s/%s...", route.Namespace, route.Name))
+			oktetoLog.StartSpinner()
+			defer oktetoLog.StopSpinner()
+			if err := d.deployReferenceGrant(ctx, route.Namespace); err != nil {
+				return err
+			}
+			if err := d.retryTranslateDivertHTTPRoute(ctx, route, services); err != nil {
+				return err
+			}
+			oktetoLog.StopSpinner()
+			oktetoLog.Success("HTTP route '%s/%s' successfully diverted", route.Namespace, route.Name)
+		}
+	}
+	return nil
+}
+
+func (d *Driver) Destroy(ctx context.Context) error {
+	for i := range d.divert.HTTPRoutes {
+		route := d.divert.HTTPRoutes[i]
+		oktetoLog.Spinner(fmt.Sprintf("Restoring http route %s/%s...", route.Namespace, route.Name))
+		oktetoLog.StartSpinner()
+		defer oktetoLog.StopSpinner()
+		if err := d.retryRestoreDivertHTTPRoute(ctx, route); err != nil {
+			return err
+		}
+		err := d.gatewayClient.GatewayV1alpha2().ReferenceGrants(d.namespace).Delete(ctx, getReferenceGrantName(route.Namespace), metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		oktetoLog.StopSpinner()
+		oktetoLog.Success("HTTP route '%s/%s' successfully restored", route.Namespace, route.Name)
+	}
+	return nil
+}
+
+func (d *Driver) UpdatePod(pod apiv1.PodSpec) apiv1.PodSpec {
+	return pod
+}
+
+func (d *Driver) UpdateVirtualService(vs *istioNetworkingV1beta1.VirtualService) {}
+
+func (d *Driver) UpdateHTTPRoute(route *gatewayV1beta1.HTTPRouteSpec) {
+	d.injectDivertHeader(route)
+}
+
+// getDivertedServices returns the names of the services deployed in the diverted namespace
+func (d *Driver) getDivertedServices(ctx context.Context) (map[string]bool, error) {
+	services, err := d.client.CoreV1().Services(d.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing services of namespace '%s': %w", d.namespace, err)
+	}
+	result := map[string]bool{}
+	for _, s := range services.Items {
+		result[s.Name] = true
+	}
+	return result, nil
+}
+
+// deployReferenceGrant allows the http routes of the given namespace to reference the services of the diverted namespace
+func (d *Driver) deployReferenceGrant(ctx context.Context, namespace string) error {
+	grant := d.translateReferenceGrant(namespace)
+	old, err := d.gatewayClient.GatewayV1alpha2().ReferenceGrants(d.namespace).Get(ctx, grant.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		_, err = d.gatewayClient.GatewayV1alpha2().ReferenceGrants(d.namespace).Create(ctx, grant, metav1.CreateOptions{})
+		if k8sErrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	grant.ResourceVersion = old.ResourceVersion
+	_, err = d.gatewayClient.GatewayV1alpha2().ReferenceGrants(d.namespace).Update(ctx, grant, metav1.UpdateOptions{})
+	return err
+}
+
+func (d *Driver) translateReferenceGrant(namespace string) *gatewayV1alpha2.ReferenceGrant {
+	grant := &gatewayV1alpha2.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getReferenceGrantName(namespace),
+			Namespace: d.namespace,
+		},
+		Spec: gatewayV1alpha2.ReferenceGrantSpec{
+			From: []gatewayV1alpha2.ReferenceGrantFrom{
+				{
+					Group:     gatewayV1alpha2.GroupName,
+					Kind:      "HTTPRoute",
+					Namespace: gatewayV1alpha2.Namespace(namespace),
+				},
+			},
+			To: []gatewayV1alpha2.ReferenceGrantTo{
+				{
+					Group: "",
+					Kind:  "Service",
+				},
+			},
+		},
+	}
+	labels.SetInMetadata(&grant.ObjectMeta, model.DeployedByLabel, format.ResourceK8sMetaString(d.name))
+	return grant
+}
+
+func getReferenceGrantName(namespace string) string {
+	return format.ResourceK8sMetaString(fmt.Sprintf(referenceGrantTemplate, namespace))
+}
+
+func (d *Driver) retryTranslateDivertHTTPRoute(ctx context.Context, divertRoute model.DivertHTTPRoute, services map[string]bool) error {
+	var err error
+	for retries := 0; retries < updateConflictRetries; retries++ {
+		var route *gatewayV1beta1.HTTPRoute
+		route, err = httproutes.Get(ctx, divertRoute.Name, divertRoute.Namespace, d.gatewayClient)
+		if err != nil {
+			return err
+		}
+		translatedRoute := d.translateDivertHTTPRoute(route, services)
+		err = httproutes.Update(ctx, translatedRoute, d.gatewayClient)
+		if err == nil {
+			return nil
+		}
+		if !k8sErrors.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (d *Driver) retryRestoreDivertHTTPRoute(ctx context.Context, divertRoute model.DivertHTTPRoute) error {
+	var err error
+	for retries := 0; retries < updateConflictRetries; retries++ {
+		var route *gatewayV1beta1.HTTPRoute
+		route, err = httproutes.Get(ctx, divertRoute.Name, divertRoute.Namespace, d.gatewayClient)
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		restoredRoute := d.restoreDivertHTTPRoute(route)
+		err = httproutes.Update(ctx, restoredRoute, d.gatewayClient)
+		if err == nil {
+			return nil
+		}
+		if !k8sErrors.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayFake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestDeployAndDestroy(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+	})
+	route := &gatewayV1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "staging"},
+		Spec: gatewayV1beta1.HTTPRouteSpec{
+			Rules: []gatewayV1beta1.HTTPRouteRule{
+				{BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("api", nil)}},
+				{BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("frontend", nil)}},
+			},
+		},
+	}
+	gc := gatewayFake.NewSimpleClientset(route)
+
+	m := &model.Manifest{
+		Name:      "movies",
+		Namespace: "cindy",
+		Deploy: &model.DeployInfo{
+			Divert: &model.DivertDeploy{
+				HTTPRoutes: []model.DivertHTTPRoute{{Name: "frontend", Namespace: "staging"}},
+			},
+		},
+	}
+	d := New(m, c, gc)
+
+	require.NoError(t, d.Deploy(ctx))
+
+	diverted, err := gc.GatewayV1beta1().HTTPRoutes("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, diverted.Spec.Rules, 3)
+	assert.Equal(t, "cindy", GetDivertNamespace(diverted.Spec.Rules[2]))
+	assert.Equal(t, gatewayV1beta1.Namespace("cindy"), *diverted.Spec.Rules[2].BackendRefs[0].Namespace)
+
+	grant, err := gc.GatewayV1alpha2().ReferenceGrants("cindy").Get(ctx, "okteto-divert-staging", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", string(grant.Spec.From[0].Namespace))
+	assert.Equal(t, "movies", grant.Labels[model.DeployedByLabel])
+
+	// deploying again is idempotent
+	require.NoError(t, d.Deploy(ctx))
+	diverted, err = gc.GatewayV1beta1().HTTPRoutes("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, diverted.Spec.Rules, 3)
+
+	require.NoError(t, d.Destroy(ctx))
+	restored, err := gc.GatewayV1beta1().HTTPRoutes("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, route.Spec.Rules, restored.Spec.Rules)
+	_, err = gc.GatewayV1alpha2().ReferenceGrants("cindy").Get(ctx, "okteto-divert-staging", metav1.GetOptions{})
+	assert.True(t, k8sErrors.IsNotFound(err))
+}
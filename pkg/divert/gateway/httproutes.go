@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"github.com/okteto/okteto/pkg/constants"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const serviceKind = "Service"
+
+// translateDivertHTTPRoute adds a copy of every rule that sends traffic to a service deployed in the diverted namespace.
+// The copy only matches the requests with the divert header and sends them to the services of the diverted namespace
+func (d *Driver) translateDivertHTTPRoute(route *gatewayV1beta1.HTTPRoute, services map[string]bool) *gatewayV1beta1.HTTPRoute {
+	result := d.restoreDivertHTTPRoute(route)
+	var divertedRules []gatewayV1beta1.HTTPRouteRule
+	for i := range result.Spec.Rules {
+		if GetDivertNamespace(result.Spec.Rules[i]) != "" {
+			// rules added by other diverted namespaces
+			continue
+		}
+		if rule, ok := d.translateDivertRule(result.Spec.Rules[i], route.Namespace, services); ok {
+			divertedRules = append(divertedRules, rule)
+		}
+	}
+	result.Spec.Rules = append(result.Spec.Rules, divertedRules...)
+	return result
+}
+
+// restoreDivertHTTPRoute removes the rules added for the diverted namespace
+func (d *Driver) restoreDivertHTTPRoute(route *gatewayV1beta1.HTTPRoute) *gatewayV1beta1.HTTPRoute {
+	result := route.DeepCopy()
+	rules := []gatewayV1beta1.HTTPRouteRule{}
+	for _, rule := range result.Spec.Rules {
+		if GetDivertNamespace(rule) == d.namespace {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	result.Spec.Rules = rules
+	return result
+}
+
+func (d *Driver) translateDivertRule(rule gatewayV1beta1.HTTPRouteRule, routeNamespace string, services map[string]bool) (gatewayV1beta1.HTTPRouteRule, bool) {
+	result := *rule.DeepCopy()
+	diverted := false
+	for i := range result.BackendRefs {
+		ref := &result.BackendRefs[i].BackendObjectReference
+		if !isServiceReference(ref) {
+			continue
+		}
+		if ref.Namespace != nil && string(*ref.Namespace) != routeNamespace {
+			continue
+		}
+		if !services[string(ref.Name)] {
+			continue
+		}
+		namespace := gatewayV1beta1.Namespace(d.namespace)
+		ref.Namespace = &namespace
+		diverted = true
+	}
+	if !diverted {
+		return result, false
+	}
+
+	if len(result.Matches) == 0 {
+		result.Matches = []gatewayV1beta1.HTTPRouteMatch{{}}
+	}
+	matchType := gatewayV1beta1.HeaderMatchExact
+	for i := range result.Matches {
+		result.Matches[i].Headers = append(result.Matches[i].Headers, gatewayV1beta1.HTTPHeaderMatch{
+			Type:  &matchType,
+			Name:  constants.OktetoDivertHeaderName,
+			Value: d.namespace,
+		})
+	}
+	return result, true
+}
+
+// injectDivertHeader sets the divert header in the requests routed by the http routes of the diverted namespace
+func (d *Driver) injectDivertHeader(route *gatewayV1beta1.HTTPRouteSpec) {
+	header := gatewayV1beta1.HTTPHeader{
+		Name:  constants.OktetoDivertHeaderName,
+		Value: d.namespace,
+	}
+	for i := range route.Rules {
+		found := false
+		for j := range route.Rules[i].Filters {
+			filter := &route.Rules[i].Filters[j]
+			if filter.Type != gatewayV1beta1.HTTPRouteFilterRequestHeaderModifier || filter.RequestHeaderModifier == nil {
+				continue
+			}
+			found = true
+			filter.RequestHeaderModifier.Set = setHeader(filter.RequestHeaderModifier.Set, header)
+		}
+		if !found {
+			route.Rules[i].Filters = append(route.Rules[i].Filters, gatewayV1beta1.HTTPRouteFilter{
+				Type: gatewayV1beta1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &gatewayV1beta1.HTTPRequestHeaderFilter{
+					Set: []gatewayV1beta1.HTTPHeader{header},
+				},
+			})
+		}
+	}
+}
+
+// GetDivertNamespace returns the namespace a rule is diverted to, or an empty string if the rule isn't diverted
+func GetDivertNamespace(rule gatewayV1beta1.HTTPRouteRule) string {
+	for _, match := range rule.Matches {
+		for _, header := range match.Headers {
+			if string(header.Name) == constants.OktetoDivertHeaderName {
+				return header.Value
+			}
+		}
+	}
+	return ""
+}
+
+func isServiceReference(ref *gatewayV1beta1.BackendObjectReference) bool {
+	if ref.Group != nil && *ref.Group != "" {
+		return false
+	}
+	return ref.Kind == nil || *ref.Kind == serviceKind
+}
+
+func setHeader(headers []gatewayV1beta1.HTTPHeader, header gatewayV1beta1.HTTPHeader) []gatewayV1beta1.HTTPHeader {
+	for i := range headers {
+		if headers[i].Name == header.Name {
+			headers[i].Value = header.Value
+			return headers
+		}
+	}
+	return append(headers, header)
+}
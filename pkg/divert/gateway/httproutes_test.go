@@ -0,0 +1,145 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func divertHeaderMatch(namespace string) gatewayV1beta1.HTTPHeaderMatch {
+	return gatewayV1beta1.HTTPHeaderMatch{
+		Type:  ptr(gatewayV1beta1.HeaderMatchExact),
+		Name:  constants.OktetoDivertHeaderName,
+		Value: namespace,
+	}
+}
+
+func backendRef(name string, namespace *gatewayV1beta1.Namespace) gatewayV1beta1.HTTPBackendRef {
+	return gatewayV1beta1.HTTPBackendRef{
+		BackendRef: gatewayV1beta1.BackendRef{
+			BackendObjectReference: gatewayV1beta1.BackendObjectReference{
+				Name:      gatewayV1beta1.ObjectName(name),
+				Namespace: namespace,
+				Port:      ptr(gatewayV1beta1.PortNumber(8080)),
+			},
+		},
+	}
+}
+
+func Test_translateDivertHTTPRoute(t *testing.T) {
+	d := &Driver{name: "movies", namespace: "cindy"}
+	apiMatch := gatewayV1beta1.HTTPRouteMatch{
+		Path: &gatewayV1beta1.HTTPPathMatch{
+			Type:  ptr(gatewayV1beta1.PathMatchPathPrefix),
+			Value: ptr("/api"),
+		},
+	}
+	route := &gatewayV1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "staging"},
+		Spec: gatewayV1beta1.HTTPRouteSpec{
+			Rules: []gatewayV1beta1.HTTPRouteRule{
+				{
+					Matches:     []gatewayV1beta1.HTTPRouteMatch{apiMatch},
+					BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("api", nil)},
+				},
+				{
+					BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("frontend", nil)},
+				},
+				{
+					// rule added by another diverted namespace
+					Matches:     []gatewayV1beta1.HTTPRouteMatch{{Headers: []gatewayV1beta1.HTTPHeaderMatch{divertHeaderMatch("alice")}}},
+					BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("frontend", ptr(gatewayV1beta1.Namespace("alice")))},
+				},
+			},
+		},
+	}
+
+	result := d.translateDivertHTTPRoute(route, map[string]bool{"api": true, "frontend": true})
+	expectedAPIMatch := *apiMatch.DeepCopy()
+	expectedAPIMatch.Headers = []gatewayV1beta1.HTTPHeaderMatch{divertHeaderMatch("cindy")}
+	expectedRules := append(route.DeepCopy().Spec.Rules,
+		gatewayV1beta1.HTTPRouteRule{
+			Matches:     []gatewayV1beta1.HTTPRouteMatch{expectedAPIMatch},
+			BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("api", ptr(gatewayV1beta1.Namespace("cindy")))},
+		},
+		gatewayV1beta1.HTTPRouteRule{
+			Matches:     []gatewayV1beta1.HTTPRouteMatch{{Headers: []gatewayV1beta1.HTTPHeaderMatch{divertHeaderMatch("cindy")}}},
+			BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("frontend", ptr(gatewayV1beta1.Namespace("cindy")))},
+		},
+	)
+	assert.Equal(t, expectedRules, result.Spec.Rules)
+
+	// the original route is not modified
+	assert.Len(t, route.Spec.Rules, 3)
+
+	// translating again doesn't duplicate the diverted rules
+	assert.Equal(t, expectedRules, d.translateDivertHTTPRoute(result, map[string]bool{"api": true, "frontend": true}).Spec.Rules)
+
+	// only the services deployed in the diverted namespace are diverted
+	result = d.translateDivertHTTPRoute(route, map[string]bool{"api": true})
+	assert.Len(t, result.Spec.Rules, 4)
+	assert.Equal(t, "cindy", GetDivertNamespace(result.Spec.Rules[3]))
+
+	// restoring removes the rules of the diverted namespace
+	assert.Equal(t, route.Spec.Rules, d.restoreDivertHTTPRoute(result).Spec.Rules)
+}
+
+func Test_injectDivertHeader(t *testing.T) {
+	d := &Driver{name: "movies", namespace: "cindy"}
+	spec := &gatewayV1beta1.HTTPRouteSpec{
+		Rules: []gatewayV1beta1.HTTPRouteRule{
+			{
+				BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("api", nil)},
+			},
+			{
+				Filters: []gatewayV1beta1.HTTPRouteFilter{
+					{
+						Type: gatewayV1beta1.HTTPRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayV1beta1.HTTPRequestHeaderFilter{
+							Set: []gatewayV1beta1.HTTPHeader{{Name: "x-version", Value: "v2"}},
+						},
+					},
+				},
+				BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("frontend", nil)},
+			},
+		},
+	}
+
+	d.UpdateHTTPRoute(spec)
+
+	divertHeader := gatewayV1beta1.HTTPHeader{Name: constants.OktetoDivertHeaderName, Value: "cindy"}
+	assert.Equal(t, []gatewayV1beta1.HTTPRouteFilter{
+		{
+			Type: gatewayV1beta1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayV1beta1.HTTPRequestHeaderFilter{
+				Set: []gatewayV1beta1.HTTPHeader{divertHeader},
+			},
+		},
+	}, spec.Rules[0].Filters)
+	assert.Equal(t, []gatewayV1beta1.HTTPHeader{{Name: "x-version", Value: "v2"}, divertHeader}, spec.Rules[1].Filters[0].RequestHeaderModifier.Set)
+
+	// the header is not added twice
+	d.UpdateHTTPRoute(spec)
+	assert.Len(t, spec.Rules[0].Filters, 1)
+	assert.Len(t, spec.Rules[1].Filters[0].RequestHeaderModifier.Set, 2)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/k8s/httproutes"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// Status returns the divert state of the http routes of the divert section
+func (d *Driver) Status(ctx context.Context) ([]status.Route, error) {
+	services, err := d.getDivertedServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("%s: %s", constants.OktetoDivertHeaderName, d.namespace)
+	result := []status.Route{}
+	for _, divertRoute := range d.divert.HTTPRoutes {
+		route := status.Route{
+			Kind:              "HTTPRoute",
+			Name:              divertRoute.Name,
+			OriginalNamespace: divertRoute.Namespace,
+			DivertedNamespace: d.namespace,
+			Header:            header,
+		}
+		httpRoute, err := httproutes.Get(ctx, divertRoute.Name, divertRoute.Namespace, d.gatewayClient)
+		if err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				return nil, err
+			}
+			route.Status = status.Missing
+			result = append(result, route)
+			continue
+		}
+		route.Host = getHostnames(httpRoute)
+
+		translatedRoute := d.translateDivertHTTPRoute(httpRoute, services)
+		switch {
+		case !d.hasDivertRules(httpRoute):
+			route.Status = status.Missing
+		case reflect.DeepEqual(httpRoute.Spec.Rules, translatedRoute.Spec.Rules):
+			route.Status = status.Diverted
+		default:
+			route.Status = status.Outdated
+		}
+		result = append(result, route)
+	}
+	return result, nil
+}
+
+func (d *Driver) hasDivertRules(route *gatewayV1beta1.HTTPRoute) bool {
+	for _, rule := range route.Spec.Rules {
+		if GetDivertNamespace(rule) == d.namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func getHostnames(route *gatewayV1beta1.HTTPRoute) string {
+	hostnames := make([]string, 0, len(route.Spec.Hostnames))
+	for _, hostname := range route.Spec.Hostnames {
+		hostnames = append(hostnames, string(hostname))
+	}
+	return strings.Join(hostnames, ",")
+}
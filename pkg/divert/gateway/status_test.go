@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayFake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+	})
+	route := &gatewayV1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "staging"},
+		Spec: gatewayV1beta1.HTTPRouteSpec{
+			Hostnames: []gatewayV1beta1.Hostname{"movies.okteto.dev"},
+			Rules: []gatewayV1beta1.HTTPRouteRule{
+				{BackendRefs: []gatewayV1beta1.HTTPBackendRef{backendRef("api", nil)}},
+			},
+		},
+	}
+	gc := gatewayFake.NewSimpleClientset(route)
+	m := &model.Manifest{
+		Name:      "movies",
+		Namespace: "cindy",
+		Deploy: &model.DeployInfo{
+			Divert: &model.DivertDeploy{
+				HTTPRoutes: []model.DivertHTTPRoute{
+					{Name: "frontend", Namespace: "staging"},
+					{Name: "admin", Namespace: "staging"},
+				},
+			},
+		},
+	}
+	d := New(m, c, gc)
+
+	result, err := d.Status(ctx)
+	require.NoError(t, err)
+	expected := []status.Route{
+		{
+			Kind:              "HTTPRoute",
+			Name:              "frontend",
+			Host:              "movies.okteto.dev",
+			OriginalNamespace: "staging",
+			DivertedNamespace: "cindy",
+			Header:            "okteto-divert: cindy",
+			Status:            status.Missing,
+		},
+		{
+			Kind:              "HTTPRoute",
+			Name:              "admin",
+			OriginalNamespace: "staging",
+			DivertedNamespace: "cindy",
+			Header:            "okteto-divert: cindy",
+			Status:            status.Missing,
+		},
+	}
+	assert.Equal(t, expected, result)
+
+	d.divert.HTTPRoutes = d.divert.HTTPRoutes[:1]
+	require.NoError(t, d.Deploy(ctx))
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, status.Diverted, result[0].Status)
+
+	diverted, err := gc.GatewayV1beta1().HTTPRoutes("staging").Get(ctx, "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	diverted.Spec.Rules[1].BackendRefs = nil
+	_, err = gc.GatewayV1beta1().HTTPRoutes("staging").Update(ctx, diverted, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, status.Outdated, result[0].Status)
+}
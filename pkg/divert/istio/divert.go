@@ -25,6 +25,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 const (
@@ -127,6 +128,8 @@ func (d *Driver) UpdateVirtualService(vs *istioNetworkingV1beta1.VirtualService)
 	d.injectDivertHeader(vs)
 }
 
+func (d *Driver) UpdateHTTPRoute(route *gatewayV1beta1.HTTPRouteSpec) {}
+
 func (d *Driver) retryTranslateDivertVirtualService(ctx context.Context, divertVS model.DivertVirtualService) error {
 	var err error
 	for retries := 0; retries < UPDATE_CONFLICT_RETRIES; retries++ {
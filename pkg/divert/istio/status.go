@@ -0,0 +1,132 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/k8s/virtualservices"
+	"github.com/okteto/okteto/pkg/model"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Status returns the divert state of the virtual services and hosts of the divert section
+func (d *Driver) Status(ctx context.Context) ([]status.Route, error) {
+	header := fmt.Sprintf("%s: %s=%s", constants.OktetoDivertBaggageHeader, constants.OktetoDivertHeaderName, d.namespace)
+	result := []status.Route{}
+	for _, divertVS := range d.divert.VirtualServices {
+		route := status.Route{
+			Kind:              "VirtualService",
+			Name:              divertVS.Name,
+			OriginalNamespace: divertVS.Namespace,
+			DivertedNamespace: d.namespace,
+			Header:            header,
+		}
+		vs, err := virtualservices.Get(ctx, divertVS.Name, divertVS.Namespace, d.istioClient)
+		if err != nil {
+			if !k8sErrors.IsNotFound(err) {
+				return nil, err
+			}
+			route.Status = status.Missing
+			result = append(result, route)
+			continue
+		}
+		route.Host = strings.Join(vs.Spec.Hosts, ",")
+		translatedVS, err := d.translateDivertVirtualService(vs, divertVS.Routes)
+		if err != nil {
+			return nil, err
+		}
+		annotation, ok := vs.Annotations[d.getDivertAnnotationName()]
+		switch {
+		case !ok:
+			route.Status = status.Missing
+		case annotation != translatedVS.Annotations[d.getDivertAnnotationName()]:
+			route.Status = status.Outdated
+		default:
+			route.Status = status.Diverted
+		}
+		result = append(result, route)
+	}
+
+	for _, divertHost := range d.divert.Hosts {
+		route, err := d.getHostStatus(ctx, divertHost, header)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, route)
+	}
+	return result, nil
+}
+
+func (d *Driver) getHostStatus(ctx context.Context, divertHost model.DivertHost, header string) (status.Route, error) {
+	route := status.Route{
+		Kind:              "Host",
+		Name:              divertHost.VirtualService,
+		OriginalNamespace: divertHost.Namespace,
+		DivertedNamespace: d.namespace,
+		Header:            header,
+	}
+	vs, err := virtualservices.Get(ctx, divertHost.VirtualService, divertHost.Namespace, d.istioClient)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return route, err
+		}
+		route.Status = status.Missing
+		return route, nil
+	}
+	translatedVS := d.translateDivertHost(vs)
+	route.Host = strings.Join(translatedVS.Spec.Hosts, ",")
+
+	devVS, err := virtualservices.Get(ctx, divertHost.VirtualService, d.namespace, d.istioClient)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return route, err
+		}
+		route.Status = status.Missing
+		return route, nil
+	}
+	if devVS.Labels[model.OktetoAutoCreateAnnotation] != "true" {
+		route.Status = status.Deployed
+		route.Host = strings.Join(devVS.Spec.Hosts, ",")
+		return route, nil
+	}
+
+	equal, err := isEqualVirtualServiceSpec(devVS, translatedVS)
+	if err != nil {
+		return route, err
+	}
+	route.Status = status.Outdated
+	if equal {
+		route.Status = status.Diverted
+	}
+	return route, nil
+}
+
+func isEqualVirtualServiceSpec(vs1, vs2 *istioV1beta1.VirtualService) (bool, error) {
+	spec1, err := json.Marshal(&vs1.Spec)
+	if err != nil {
+		return false, err
+	}
+	spec2, err := json.Marshal(&vs2.Spec)
+	if err != nil {
+		return false, err
+	}
+	return string(spec1) == string(spec2), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioV1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istioFake "istio.io/client-go/pkg/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	vs := &istioV1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "staging"},
+	}
+	ic := istioFake.NewSimpleClientset(vs)
+	m := &model.Manifest{
+		Name:      "movies",
+		Namespace: "cindy",
+		Deploy: &model.DeployInfo{
+			Divert: &model.DivertDeploy{
+				VirtualServices: []model.DivertVirtualService{
+					{Name: "frontend", Namespace: "staging"},
+					{Name: "api", Namespace: "staging"},
+				},
+			},
+		},
+	}
+	d := New(m, fake.NewSimpleClientset(), ic)
+
+	result, err := d.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, status.Route{
+		Kind:              "VirtualService",
+		Name:              "frontend",
+		OriginalNamespace: "staging",
+		DivertedNamespace: "cindy",
+		Header:            "baggage: okteto-divert=cindy",
+		Status:            status.Missing,
+	}, result[0])
+	assert.Equal(t, status.Missing, result[1].Status)
+
+	translated, err := d.translateDivertVirtualService(vs.DeepCopy(), nil)
+	require.NoError(t, err)
+	_, err = ic.NetworkingV1beta1().VirtualServices("staging").Update(ctx, translated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, status.Diverted, result[0].Status)
+
+	translated.Annotations[d.getDivertAnnotationName()] = `{"namespace":"other"}`
+	_, err = ic.NetworkingV1beta1().VirtualServices("staging").Update(ctx, translated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, status.Outdated, result[0].Status)
+}
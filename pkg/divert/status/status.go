@@ -0,0 +1,40 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package status describes the routing state of a diverted development environment
+package status
+
+const (
+	// Diverted means the object matches the translation applied by the divert driver
+	Diverted = "diverted"
+
+	// Outdated means the object was translated by the divert driver but it doesn't match the translation anymore
+	Outdated = "outdated"
+
+	// Missing means the divert translation hasn't been applied
+	Missing = "missing"
+
+	// Deployed means the object is deployed by the development environment instead of being translated by the divert driver
+	Deployed = "deployed"
+)
+
+// Route represents the divert state of a host or route
+type Route struct {
+	Kind              string `json:"kind"`
+	Name              string `json:"name"`
+	Host              string `json:"host,omitempty"`
+	OriginalNamespace string `json:"originalNamespace"`
+	DivertedNamespace string `json:"divertedNamespace"`
+	Header            string `json:"header"`
+	Status            string `json:"status"`
+}
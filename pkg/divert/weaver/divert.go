@@ -22,6 +22,7 @@ import (
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // Driver weaver struct for the divert driver
@@ -89,3 +90,5 @@ func (d *Driver) UpdatePod(pod apiv1.PodSpec) apiv1.PodSpec {
 }
 
 func (d *Driver) UpdateVirtualService(vs *istioNetworkingV1beta1.VirtualService) {}
+
+func (d *Driver) UpdateHTTPRoute(route *gatewayV1beta1.HTTPRouteSpec) {}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/model"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// Status returns the divert state of the ingresses of the diverted namespace and the services they route to
+func (d *Driver) Status(ctx context.Context) ([]status.Route, error) {
+	if err := d.initCache(ctx); err != nil {
+		return nil, err
+	}
+
+	header := fmt.Sprintf("%s: %s=%s", constants.OktetoDivertBaggageHeader, constants.OktetoDivertHeaderName, d.namespace)
+	ingressNames := make([]string, 0, len(d.cache.divertIngresses))
+	for name := range d.cache.divertIngresses {
+		ingressNames = append(ingressNames, name)
+	}
+	sort.Strings(ingressNames)
+
+	result := []status.Route{}
+	serviceNames := []string{}
+	visitedServices := map[string]bool{}
+	for _, name := range ingressNames {
+		from := d.cache.divertIngresses[name]
+		translated := translateIngress(d.name, d.namespace, from.DeepCopy())
+		route := status.Route{
+			Kind:              "Ingress",
+			Name:              name,
+			OriginalNamespace: d.divert.Namespace,
+			DivertedNamespace: d.namespace,
+			Header:            header,
+			Host:              getIngressHosts(translated),
+		}
+		in, ok := d.cache.developerIngresses[name]
+		switch {
+		case !ok:
+			route.Status = status.Missing
+		case in.Annotations[model.OktetoAutoCreateAnnotation] != "true":
+			route.Status = status.Deployed
+			route.Host = getIngressHosts(in)
+		case isEqualIngress(in.DeepCopy(), translated):
+			route.Status = status.Diverted
+		default:
+			route.Status = status.Outdated
+		}
+		result = append(result, route)
+
+		for _, rule := range from.Spec.Rules {
+			if rule.IngressRuleValue.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.IngressRuleValue.HTTP.Paths {
+				if path.Backend.Service == nil || visitedServices[path.Backend.Service.Name] {
+					continue
+				}
+				visitedServices[path.Backend.Service.Name] = true
+				serviceNames = append(serviceNames, path.Backend.Service.Name)
+			}
+		}
+	}
+
+	for _, name := range serviceNames {
+		result = append(result, d.getServiceStatus(name, header))
+	}
+	return result, nil
+}
+
+func (d *Driver) getServiceStatus(name, header string) status.Route {
+	route := status.Route{
+		Kind:              "Service",
+		Name:              name,
+		Host:              fmt.Sprintf("%s.%s.svc.cluster.local", name, d.namespace),
+		OriginalNamespace: d.divert.Namespace,
+		DivertedNamespace: d.namespace,
+		Header:            header,
+	}
+	from, ok := d.cache.divertServices[name]
+	if !ok {
+		route.Status = status.Missing
+		return route
+	}
+	s, ok := d.cache.developerServices[name]
+	switch {
+	case !ok:
+		route.Status = status.Missing
+	case s.Annotations[model.OktetoAutoCreateAnnotation] != "true":
+		route.Status = status.Deployed
+	case !isEqualService(s, translateService(d.name, d.namespace, from.DeepCopy())):
+		route.Status = status.Outdated
+	default:
+		e, ok := d.cache.developerEndpoints[name]
+		switch {
+		case !ok:
+			route.Status = status.Missing
+		case !isEqualEndpoints(e, translateEndpoints(d.name, d.namespace, from.DeepCopy())):
+			route.Status = status.Outdated
+		default:
+			route.Status = status.Diverted
+		}
+	}
+	return route
+}
+
+func getIngressHosts(in *networkingv1.Ingress) string {
+	hosts := []string{}
+	for _, rule := range in.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	return strings.Join(hosts, ",")
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/divert/status"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	in := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "web-staging.okteto.dev",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}},
+								{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	web := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"},
+		Spec:       apiv1.ServiceSpec{ClusterIP: "10.0.0.1", Ports: []apiv1.ServicePort{{Port: 8080}}},
+	}
+	api := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "staging"},
+		Spec:       apiv1.ServiceSpec{ClusterIP: "10.0.0.2", Ports: []apiv1.ServicePort{{Port: 8080}}},
+	}
+	devAPI := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "cindy"},
+		Spec:       apiv1.ServiceSpec{Ports: []apiv1.ServicePort{{Port: 8080}}},
+	}
+	c := fake.NewSimpleClientset(in, web, api, devAPI)
+	d := &Driver{client: c, name: "test", namespace: "cindy", divert: model.DivertDeploy{Namespace: "staging"}}
+
+	result, err := d.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, status.Route{
+		Kind:              "Ingress",
+		Name:              "web",
+		Host:              "web-cindy.okteto.dev",
+		OriginalNamespace: "staging",
+		DivertedNamespace: "cindy",
+		Header:            "baggage: okteto-divert=cindy",
+		Status:            status.Missing,
+	}, result[0])
+	assert.Equal(t, status.Missing, result[1].Status)
+	assert.Equal(t, status.Deployed, result[2].Status)
+
+	require.NoError(t, d.Deploy(ctx))
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, status.Diverted, result[0].Status)
+	assert.Equal(t, "web", result[1].Name)
+	assert.Equal(t, status.Diverted, result[1].Status)
+	assert.Equal(t, "api", result[2].Name)
+	assert.Equal(t, status.Deployed, result[2].Status)
+
+	devWeb, err := c.CoreV1().Services("cindy").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	devWeb.Spec.Ports = []apiv1.ServicePort{{Port: 9090}}
+	_, err = c.CoreV1().Services("cindy").Update(ctx, devWeb, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	result, err = d.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, status.Outdated, result[1].Status)
+}
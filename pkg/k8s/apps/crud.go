@@ -15,19 +15,36 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/rollouts"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
+// getDynamicClient returns the client used to look up custom resources like argo rollouts
+var getDynamicClient = func() (dynamic.Interface, error) {
+	if !okteto.IsContextInitialized() {
+		return nil, fmt.Errorf("okteto context not initialized")
+	}
+	dc, _, err := okteto.GetDynamicClient()
+	return dc, err
+}
+
 type ErrApplicationNotFound struct {
 	Name string
 }
@@ -47,13 +64,87 @@ func Get(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Int
 	}
 
 	sfs, err := statefulsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return &StatefulSetApp{sfs: sfs}, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	ds, err := daemonsets.GetByDev(ctx, dev, namespace, c)
+	if err == nil {
+		return NewDaemonSetApp(ds), nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	app, err := getRollout(ctx, dev, namespace)
+	if errors.Is(err, ErrApplicationNotFound{Name: dev.Name}) {
+		if barePodErr := checkBarePod(ctx, dev, namespace, c); barePodErr != nil {
+			return nil, barePodErr
+		}
+	}
+	return app, err
+}
+
+// checkBarePod returns an explicit error when the dev targets a pod that isn't managed by a controller.
+// Bare pods are not supported: a pod can't be scaled down and restored the way the App interface expects
+func checkBarePod(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) error {
+	var pods []apiv1.Pod
+	if len(dev.Selector) == 0 {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, dev.Name, metav1.GetOptions{})
+		if err != nil {
+			oktetoLog.Infof("error looking for a pod named '%s': %s", dev.Name, err)
+			return nil
+		}
+		pods = append(pods, *pod)
+	} else {
+		podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: dev.LabelsSelector()})
+		if err != nil {
+			oktetoLog.Infof("error looking for pods with labels '%s': %s", dev.LabelsSelector(), err)
+			return nil
+		}
+		pods = podList.Items
+	}
+
+	for i := range pods {
+		if metav1.GetControllerOf(&pods[i]) != nil {
+			continue
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("bare pods are not supported: the pod '%s' isn't managed by a deployment, statefulset, daemonset or rollout", pods[i].Name),
+			Hint: "Create the pod from a deployment or a statefulset to develop on it with okteto",
+		}
+	}
+	return nil
+}
+
+// getRollout returns the argo rollout of a dev. Clusters without argo rollouts don't have the resource at all,
+// so a missing resource type is reported as a missing application
+func getRollout(ctx context.Context, dev *model.Dev, namespace string) (App, error) {
+	dc, err := getDynamicClient()
+	if err != nil {
+		oktetoLog.Infof("error getting dynamic client to look for rollouts: %s", err)
+		return nil, ErrApplicationNotFound{Name: dev.Name}
+	}
+
+	r, err := rollouts.GetByDev(ctx, dev, namespace, dc)
 	if err != nil {
-		if oktetoErrors.IsNotFound(err) {
+		if oktetoErrors.IsNotFound(err) || k8sErrors.IsNotFound(err) {
 			return nil, ErrApplicationNotFound{Name: dev.Name}
 		}
 		return nil, err
 	}
-	return &StatefulSetApp{sfs: sfs}, nil
+	if r.Spec.WorkloadRef != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("the rollout '%s' references the workload '%s' instead of defining a pod template", r.Name, r.Spec.WorkloadRef.Name),
+			Hint: fmt.Sprintf("Use the name or labels of the %s '%s' in your okteto manifest to develop on it", strings.ToLower(r.Spec.WorkloadRef.Kind), r.Spec.WorkloadRef.Name),
+		}
+	}
+	return NewRolloutApp(r, dc), nil
 }
 
 // IsDevModeOn returns if a statefulset is in devmode
@@ -116,6 +207,9 @@ func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c
 		App:     app,
 		Rules:   []*model.TranslationRule{dev.ToTranslationRule(dev, reset)},
 	}
+	for _, name := range dev.ContainerNames() {
+		mainTr.Rules = append(mainTr.Rules, dev.ToContainerTranslationRule(name))
+	}
 	result := map[string]*Translation{app.ObjectMeta().Name: mainTr}
 
 	if err := loadServiceTranslations(ctx, dev, reset, result, c); err != nil {
@@ -123,11 +217,16 @@ func GetTranslations(ctx context.Context, dev *model.Dev, app App, reset bool, c
 	}
 
 	for _, tr := range result {
+		translated := map[string]bool{}
 		for _, rule := range tr.Rules {
 			devContainer := GetDevContainer(tr.App.PodSpec(), rule.Container)
 			if devContainer == nil {
 				return nil, fmt.Errorf("%s '%s': container '%s' not found", tr.App.Kind(), tr.App.ObjectMeta().Name, rule.Container)
 			}
+			if translated[devContainer.Name] {
+				return nil, fmt.Errorf("%s '%s': container '%s' is defined more than once in dev mode", tr.App.Kind(), tr.App.ObjectMeta().Name, devContainer.Name)
+			}
+			translated[devContainer.Name] = true
 			rule.Container = devContainer.Name
 			if rule.Image == "" {
 				rule.Image = devContainer.Image
@@ -0,0 +1,219 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/daemonsets"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+// DaemonSetApp is the App implementation for daemonsets.
+// Daemonsets can't be scaled, so scaling them to 0 replicas adds a node selector that no node matches,
+// and their dev clone is a deployment with a single replica
+type DaemonSetApp struct {
+	ds   *appsv1.DaemonSet
+	kind string
+}
+
+func NewDaemonSetApp(ds *appsv1.DaemonSet) *DaemonSetApp {
+	return &DaemonSetApp{kind: okteto.DaemonSet, ds: ds}
+}
+
+func (i *DaemonSetApp) Kind() string {
+	return i.kind
+}
+
+func (i *DaemonSetApp) ObjectMeta() metav1.ObjectMeta {
+	if i.ds.ObjectMeta.Annotations == nil {
+		i.ds.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.ObjectMeta.Labels == nil {
+		i.ds.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.ObjectMeta
+}
+
+func (i *DaemonSetApp) Replicas() int32 {
+	if _, ok := i.ds.Spec.Template.Spec.NodeSelector[model.ScaledDownNodeSelector]; ok {
+		return 0
+	}
+	return 1
+}
+
+func (i *DaemonSetApp) SetReplicas(n int32) {
+	if n > 0 {
+		delete(i.ds.Spec.Template.Spec.NodeSelector, model.ScaledDownNodeSelector)
+		if len(i.ds.Spec.Template.Spec.NodeSelector) == 0 {
+			i.ds.Spec.Template.Spec.NodeSelector = nil
+		}
+		return
+	}
+	if i.ds.Spec.Template.Spec.NodeSelector == nil {
+		i.ds.Spec.Template.Spec.NodeSelector = map[string]string{}
+	}
+	i.ds.Spec.Template.Spec.NodeSelector[model.ScaledDownNodeSelector] = "true"
+}
+
+func (i *DaemonSetApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.ds.Spec.Template.ObjectMeta.Annotations == nil {
+		i.ds.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.ds.Spec.Template.ObjectMeta.Labels == nil {
+		i.ds.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.ds.Spec.Template.ObjectMeta
+}
+
+func (i *DaemonSetApp) PodSpec() *apiv1.PodSpec {
+	return &i.ds.Spec.Template.Spec
+}
+
+// DevClone returns a deployment running a single pod of the daemonset
+func (i *DaemonSetApp) DevClone() App {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.ds.Name),
+			Namespace:   i.ds.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Selector: i.ds.Spec.Selector.DeepCopy(),
+			Template: *i.ds.Spec.Template.DeepCopy(),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	clone.Labels[model.DevCloneLabel] = string(i.ds.UID)
+	for k, v := range i.ds.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.ds.Annotations {
+		clone.Annotations[k] = v
+	}
+	delete(clone.Spec.Template.Spec.NodeSelector, model.ScaledDownNodeSelector)
+	return NewDeploymentApp(clone)
+}
+
+func (i *DaemonSetApp) CheckConditionErrors(_ *model.Dev) error {
+	return daemonsets.CheckConditionErrors(i.ds)
+}
+
+func (i *DaemonSetApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	if i.ds.Generation != i.ds.Status.ObservedGeneration {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	return pods.GetPodByDaemonSet(ctx, i.ds, c)
+}
+
+// RestoreOriginal is a no-op: daemonsets never used the deprecated devmodeoff behavior
+func (*DaemonSetApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *DaemonSetApp) Refresh(ctx context.Context, c kubernetes.Interface) error {
+	ds, err := daemonsets.Get(ctx, i.ds.Name, i.ds.Namespace, c)
+	if err == nil {
+		i.ds = ds
+	}
+	return err
+}
+
+func (i *DaemonSetApp) Watch(ctx context.Context, result chan error, c kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.ds.Name),
+	}
+
+	watcher, err := c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received daemonset '%s' event: %s", i.ds.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating daemonset '%s' watcher", i.ds.Name)
+				watcher, err = c.AppsV1().DaemonSets(i.ds.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if e.Type == watch.Deleted {
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			} else if e.Type == watch.Modified {
+				ds, ok := e.Object.(*appsv1.DaemonSet)
+				if !ok {
+					oktetoLog.Debugf("Failed to parse daemonset event: %s", e)
+					continue
+				}
+				if ds.Generation != i.ds.Generation {
+					result <- oktetoErrors.ErrApplyToApp
+					return
+				}
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *DaemonSetApp) Deploy(ctx context.Context, c kubernetes.Interface) error {
+	ds, err := daemonsets.Deploy(ctx, i.ds, c)
+	if err == nil {
+		i.ds = ds
+	}
+	return err
+}
+
+func (i *DaemonSetApp) PatchAnnotations(ctx context.Context, c kubernetes.Interface) error {
+	return daemonsets.PatchAnnotations(ctx, i.ds, c)
+}
+
+func (i *DaemonSetApp) Destroy(ctx context.Context, c kubernetes.Interface) error {
+	return daemonsets.Destroy(ctx, i.ds.Name, i.ds.Namespace, c)
+}
+
+// GetDevClone Returns from Kubernetes the deployment cloned from the daemonset
+func (i *DaemonSetApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	clonedName := model.DevCloneName(i.ds.Name)
+	d, err := deployments.Get(ctx, clonedName, i.ds.Namespace, c)
+	if err == nil {
+		return NewDeploymentApp(d), nil
+	}
+	return nil, err
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "test",
+			UID:       "ds-uid",
+			Labels:    map[string]string{"app": "agent"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "agent"},
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "agent"},
+				},
+				Spec: apiv1.PodSpec{
+					NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+					Containers: []apiv1.Container{
+						{Name: "agent", Image: "okteto/agent"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDaemonSetReplicas(t *testing.T) {
+	app := NewDaemonSetApp(newTestDaemonSet())
+	assert.Equal(t, int32(1), app.Replicas())
+
+	app.SetReplicas(0)
+	assert.Equal(t, int32(0), app.Replicas())
+	assert.Equal(t, "true", app.PodSpec().NodeSelector[model.ScaledDownNodeSelector])
+	assert.Equal(t, "linux", app.PodSpec().NodeSelector["kubernetes.io/os"])
+
+	app.SetReplicas(1)
+	assert.Equal(t, int32(1), app.Replicas())
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, app.PodSpec().NodeSelector)
+}
+
+func TestDaemonSetDevClone(t *testing.T) {
+	app := NewDaemonSetApp(newTestDaemonSet())
+	app.SetReplicas(0)
+
+	clone := app.DevClone()
+
+	require.IsType(t, &DeploymentApp{}, clone)
+	d := clone.(*DeploymentApp).d
+	assert.Equal(t, "agent-okteto", d.Name)
+	assert.Equal(t, "ds-uid", d.Labels[model.DevCloneLabel])
+	assert.Equal(t, "agent", d.Labels["app"])
+	assert.Equal(t, int32(1), *d.Spec.Replicas)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, d.Spec.Strategy.Type)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, d.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, "true", app.PodSpec().NodeSelector[model.ScaledDownNodeSelector])
+}
+
+func TestDaemonSetGetRunningPod(t *testing.T) {
+	ds := newTestDaemonSet()
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "agent-abcde",
+			Namespace:       "test",
+			OwnerReferences: []metav1.OwnerReference{{UID: ds.UID}},
+		},
+	}
+	c := fake.NewSimpleClientset(pod)
+	app := NewDaemonSetApp(ds)
+
+	result, err := app.GetRunningPod(context.Background(), c)
+	require.NoError(t, err)
+	assert.Equal(t, "agent-abcde", result.Name)
+
+	ds.Generation = 2
+	_, err = app.GetRunningPod(context.Background(), c)
+	require.Error(t, err)
+}
+
+func TestDaemonSetGetDevClone(t *testing.T) {
+	cloned := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent-okteto",
+			Namespace: "test",
+		},
+	}
+	c := fake.NewSimpleClientset(cloned)
+	app := NewDaemonSetApp(newTestDaemonSet())
+
+	result, err := app.GetDevClone(context.Background(), c)
+
+	require.NoError(t, err)
+	assert.Equal(t, NewDeploymentApp(cloned), result)
+}
+
+func TestGetDaemonSet(t *testing.T) {
+	c := fake.NewSimpleClientset(newTestDaemonSet())
+	dev := &model.Dev{Name: "dev", Selector: model.Selector{"app": "agent"}}
+
+	app, err := Get(context.Background(), dev, "test", c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "DaemonSet", app.Kind())
+	assert.Equal(t, "agent", app.ObjectMeta().Name)
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/rollouts"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+)
+
+// RolloutApp is the App implementation for argo rollouts.
+// Rollouts are custom resources, so they are managed with a dynamic client, and their dev clone is a deployment
+type RolloutApp struct {
+	r    *rollouts.Rollout
+	dc   dynamic.Interface
+	kind string
+}
+
+func NewRolloutApp(r *rollouts.Rollout, dc dynamic.Interface) *RolloutApp {
+	return &RolloutApp{kind: okteto.Rollout, r: r, dc: dc}
+}
+
+func (i *RolloutApp) Kind() string {
+	return i.kind
+}
+
+func (i *RolloutApp) ObjectMeta() metav1.ObjectMeta {
+	if i.r.ObjectMeta.Annotations == nil {
+		i.r.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.r.ObjectMeta.Labels == nil {
+		i.r.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.r.ObjectMeta
+}
+
+func (i *RolloutApp) Replicas() int32 {
+	if i.r.Spec.Replicas == nil {
+		return 1
+	}
+	return *i.r.Spec.Replicas
+}
+
+func (i *RolloutApp) SetReplicas(n int32) {
+	i.r.Spec.Replicas = pointer.Int32Ptr(n)
+}
+
+func (i *RolloutApp) TemplateObjectMeta() metav1.ObjectMeta {
+	if i.r.Spec.Template.ObjectMeta.Annotations == nil {
+		i.r.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	if i.r.Spec.Template.ObjectMeta.Labels == nil {
+		i.r.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	return i.r.Spec.Template.ObjectMeta
+}
+
+func (i *RolloutApp) PodSpec() *apiv1.PodSpec {
+	return &i.r.Spec.Template.Spec
+}
+
+// DevClone returns a deployment running the pod template of the rollout
+func (i *RolloutApp) DevClone() App {
+	clone := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        model.DevCloneName(i.r.Name),
+			Namespace:   i.r.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(i.Replicas()),
+			Selector: i.r.Spec.Selector.DeepCopy(),
+			Template: *i.r.Spec.Template.DeepCopy(),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+	}
+	clone.Labels[model.DevCloneLabel] = string(i.r.UID)
+	for k, v := range i.r.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range i.r.Annotations {
+		clone.Annotations[k] = v
+	}
+	delete(clone.Spec.Template.Labels, rollouts.PodTemplateHashLabel)
+	return NewDeploymentApp(clone)
+}
+
+func (i *RolloutApp) CheckConditionErrors(_ *model.Dev) error {
+	return rollouts.CheckConditionErrors(i.r)
+}
+
+func (i *RolloutApp) GetRunningPod(ctx context.Context, c kubernetes.Interface) (*apiv1.Pod, error) {
+	rs, err := rollouts.GetCurrentReplicaSet(ctx, i.r, c)
+	if err != nil {
+		return nil, err
+	}
+	return pods.GetPodByReplicaSet(ctx, rs, c)
+}
+
+// RestoreOriginal is a no-op: rollouts never used the deprecated devmodeoff behavior
+func (*RolloutApp) RestoreOriginal() error {
+	return nil
+}
+
+func (i *RolloutApp) Refresh(ctx context.Context, _ kubernetes.Interface) error {
+	r, err := rollouts.Get(ctx, i.r.Name, i.r.Namespace, i.dc)
+	if err == nil {
+		i.r = r
+	}
+	return err
+}
+
+func (i *RolloutApp) Watch(ctx context.Context, result chan error, _ kubernetes.Interface) {
+	optsWatch := metav1.ListOptions{
+		Watch:         true,
+		FieldSelector: fmt.Sprintf("metadata.name=%s", i.r.Name),
+	}
+
+	watcher, err := i.dc.Resource(rollouts.GVR).Namespace(i.r.Namespace).Watch(ctx, optsWatch)
+	if err != nil {
+		result <- err
+		return
+	}
+
+	for {
+		select {
+		case e := <-watcher.ResultChan():
+			oktetoLog.Debugf("Received rollout '%s' event: %s", i.r.Name, e)
+			if e.Object == nil {
+				oktetoLog.Debugf("Recreating rollout '%s' watcher", i.r.Name)
+				watcher, err = i.dc.Resource(rollouts.GVR).Namespace(i.r.Namespace).Watch(ctx, optsWatch)
+				if err != nil {
+					result <- err
+					return
+				}
+				continue
+			}
+			if e.Type == watch.Deleted {
+				result <- oktetoErrors.ErrDeleteToApp
+				return
+			} else if e.Type == watch.Modified {
+				u, ok := e.Object.(*unstructured.Unstructured)
+				if !ok {
+					oktetoLog.Debugf("Failed to parse rollout event: %s", e)
+					continue
+				}
+				if u.GetGeneration() != i.r.Generation {
+					result <- oktetoErrors.ErrApplyToApp
+					return
+				}
+			}
+		case err := <-ctx.Done():
+			oktetoLog.Debugf("call to up.applyToApp cancelled: %v", err)
+			return
+		}
+	}
+}
+
+func (i *RolloutApp) Deploy(ctx context.Context, _ kubernetes.Interface) error {
+	r, err := rollouts.Deploy(ctx, i.r, i.dc)
+	if err == nil {
+		i.r = r
+	}
+	return err
+}
+
+func (i *RolloutApp) PatchAnnotations(ctx context.Context, _ kubernetes.Interface) error {
+	return rollouts.PatchAnnotations(ctx, i.r, i.dc)
+}
+
+func (i *RolloutApp) Destroy(ctx context.Context, _ kubernetes.Interface) error {
+	return rollouts.Destroy(ctx, i.r.Name, i.r.Namespace, i.dc)
+}
+
+// GetDevClone Returns from Kubernetes the deployment cloned from the rollout
+func (i *RolloutApp) GetDevClone(ctx context.Context, c kubernetes.Interface) (App, error) {
+	clonedName := model.DevCloneName(i.r.Name)
+	d, err := deployments.Get(ctx, clonedName, i.r.Namespace, c)
+	if err == nil {
+		return NewDeploymentApp(d), nil
+	}
+	return nil, err
+}
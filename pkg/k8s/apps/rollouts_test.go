@@ -0,0 +1,266 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/rollouts"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestRollout(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata": map[string]interface{}{
+				"name":      "api",
+				"namespace": "test",
+				"uid":       "rollout-uid",
+				"labels":    map[string]interface{}{"app": "api"},
+			},
+			"spec": spec,
+			"status": map[string]interface{}{
+				"currentPodHash": "abc123",
+			},
+		},
+	}
+}
+
+func newTestRolloutSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"replicas": int64(3),
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "api"},
+		},
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app": "api", rollouts.PodTemplateHashLabel: "abc123"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "api", "image": "okteto/api"},
+				},
+			},
+		},
+		"strategy": map[string]interface{}{
+			"blueGreen": map[string]interface{}{"activeService": "api"},
+		},
+	}
+}
+
+func setFakeDynamicClient(t *testing.T, objects ...runtime.Object) dynamic.Interface {
+	dc := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rollouts.GVR: "RolloutList"},
+		objects...,
+	)
+	previous := getDynamicClient
+	getDynamicClient = func() (dynamic.Interface, error) {
+		return dc, nil
+	}
+	t.Cleanup(func() {
+		getDynamicClient = previous
+	})
+	return dc
+}
+
+func TestGetRollout(t *testing.T) {
+	setFakeDynamicClient(t, newTestRollout(newTestRolloutSpec()))
+
+	app, err := Get(context.Background(), &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Rollout", app.Kind())
+	assert.Equal(t, int32(3), app.Replicas())
+	assert.Equal(t, "okteto/api", app.PodSpec().Containers[0].Image)
+}
+
+func TestGetRolloutNotFound(t *testing.T) {
+	setFakeDynamicClient(t)
+
+	_, err := Get(context.Background(), &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+
+	assert.ErrorIs(t, err, ErrApplicationNotFound{Name: "api"})
+}
+
+func TestGetRolloutWithoutDynamicClient(t *testing.T) {
+	previous := getDynamicClient
+	getDynamicClient = func() (dynamic.Interface, error) {
+		return nil, errors.New("no cluster")
+	}
+	defer func() {
+		getDynamicClient = previous
+	}()
+
+	_, err := Get(context.Background(), &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+
+	assert.ErrorIs(t, err, ErrApplicationNotFound{Name: "api"})
+}
+
+func TestGetRolloutWithWorkloadRef(t *testing.T) {
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "api"},
+		},
+		"workloadRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       "api-template",
+		},
+	}
+	setFakeDynamicClient(t, newTestRollout(spec))
+
+	_, err := Get(context.Background(), &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, userErr.Hint, "deployment 'api-template'")
+}
+
+func TestRolloutDevClone(t *testing.T) {
+	r, err := rollouts.FromUnstructured(newTestRollout(newTestRolloutSpec()))
+	require.NoError(t, err)
+	app := NewRolloutApp(r, nil)
+
+	clone := app.DevClone()
+
+	require.IsType(t, &DeploymentApp{}, clone)
+	d := clone.(*DeploymentApp).d
+	assert.Equal(t, "api-okteto", d.Name)
+	assert.Equal(t, "rollout-uid", d.Labels[model.DevCloneLabel])
+	assert.Equal(t, map[string]string{"app": "api"}, d.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{"app": "api"}, d.Spec.Selector.MatchLabels)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, d.Spec.Strategy.Type)
+}
+
+func TestRolloutGetRunningPod(t *testing.T) {
+	r, err := rollouts.FromUnstructured(newTestRollout(newTestRolloutSpec()))
+	require.NoError(t, err)
+	c := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "api-abc123",
+				Namespace:       "test",
+				UID:             "rs-uid",
+				Labels:          map[string]string{rollouts.PodTemplateHashLabel: "abc123"},
+				OwnerReferences: []metav1.OwnerReference{{UID: "rollout-uid"}},
+			},
+		},
+		&apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "api-abc123-xyz",
+				Namespace:       "test",
+				OwnerReferences: []metav1.OwnerReference{{UID: "rs-uid"}},
+			},
+		},
+	)
+	app := NewRolloutApp(r, nil)
+
+	pod, err := app.GetRunningPod(context.Background(), c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "api-abc123-xyz", pod.Name)
+}
+
+func TestRolloutDeployKeepsStrategy(t *testing.T) {
+	dc := setFakeDynamicClient(t, newTestRollout(newTestRolloutSpec()))
+	ctx := context.Background()
+	app, err := Get(ctx, &model.Dev{Name: "api"}, "test", fake.NewSimpleClientset())
+	require.NoError(t, err)
+
+	app.SetReplicas(0)
+	app.ObjectMeta().Annotations[model.AppReplicasAnnotation] = "3"
+	require.NoError(t, app.Deploy(ctx, nil))
+
+	u, err := dc.Resource(rollouts.GVR).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	replicas, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), replicas)
+	assert.Equal(t, "3", u.GetAnnotations()[model.AppReplicasAnnotation])
+	activeService, _, err := unstructured.NestedString(u.Object, "spec", "strategy", "blueGreen", "activeService")
+	require.NoError(t, err)
+	assert.Equal(t, "api", activeService)
+}
+
+func TestGetBarePod(t *testing.T) {
+	isController := true
+	tests := []struct {
+		name        string
+		dev         *model.Dev
+		pod         *apiv1.Pod
+		expectedErr bool
+	}{
+		{
+			name: "bare-pod-by-name",
+			dev:  &model.Dev{Name: "api"},
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bare-pod-by-selector",
+			dev:  &model.Dev{Name: "dev", Selector: model.Selector{"app": "api"}},
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test", Labels: map[string]string{"app": "api"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "pod-with-controller",
+			dev:  &model.Dev{Name: "dev", Selector: model.Selector{"app": "api"}},
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "api-abc123",
+					Namespace:       "test",
+					Labels:          map[string]string{"app": "api"},
+					OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-abc", Controller: &isController}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setFakeDynamicClient(t)
+
+			_, err := Get(context.Background(), tt.dev, "test", fake.NewSimpleClientset(tt.pod))
+
+			if tt.expectedErr {
+				var userErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &userErr)
+				assert.Contains(t, userErr.Error(), "bare pods are not supported")
+				return
+			}
+			assert.ErrorIs(t, err, ErrApplicationNotFound{Name: tt.dev.Name})
+		})
+	}
+}
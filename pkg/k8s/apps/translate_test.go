@@ -2148,3 +2148,70 @@ func Test_getDevName(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateWithContainers(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+container: dev
+image: web:latest
+command: ["./run_web.sh"]
+sync:
+  - .:/app
+containers:
+  worker:
+    command: ["./run_worker.sh"]
+    workdir: /app
+    environment:
+      QUEUE: jobs
+    sync:
+      - .:/app`)
+
+	m, err := model.Read(manifest)
+	require.NoError(t, err)
+	dev := m.Dev["web"]
+	require.NoError(t, dev.Validate())
+
+	d := deployments.Sandbox(dev)
+	d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, apiv1.Container{
+		Name:    "worker",
+		Image:   "worker:latest",
+		Command: []string{"./worker.sh"},
+	})
+
+	trMap, err := GetTranslations(context.Background(), dev, NewDeploymentApp(d), false, fake.NewSimpleClientset())
+	require.NoError(t, err)
+	tr := trMap[d.Name]
+	require.Len(t, tr.Rules, 2)
+	require.NoError(t, tr.translate())
+
+	worker := GetDevContainer(tr.DevApp.PodSpec(), "worker")
+	require.NotNil(t, worker)
+	assert.Equal(t, "worker:latest", worker.Image)
+	assert.Equal(t, []string{"./run_worker.sh"}, worker.Command)
+	assert.Equal(t, "/app", worker.WorkingDir)
+	assert.Contains(t, worker.Env, apiv1.EnvVar{Name: "QUEUE", Value: "jobs"})
+	assert.Contains(t, worker.VolumeMounts, apiv1.VolumeMount{Name: dev.GetVolumeName(), MountPath: "/app", SubPath: model.SourceCodeSubPath})
+
+	main := GetDevContainer(tr.DevApp.PodSpec(), "dev")
+	require.NotNil(t, main)
+	assert.Equal(t, []string{"/var/okteto/bin/start.sh"}, main.Command)
+}
+
+func Test_translateWithMissingContainer(t *testing.T) {
+	manifest := []byte(`name: web
+namespace: n
+container: dev
+image: web:latest
+sync:
+  - .:/app
+containers:
+  worker:
+    command: ["./run_worker.sh"]`)
+
+	m, err := model.Read(manifest)
+	require.NoError(t, err)
+	dev := m.Dev["web"]
+
+	_, err = GetTranslations(context.Background(), dev, NewDeploymentApp(deployments.Sandbox(dev)), false, fake.NewSimpleClientset())
+	require.ErrorContains(t, err, "container 'worker' not found")
+}
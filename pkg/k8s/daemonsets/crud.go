@@ -0,0 +1,131 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+type patchAnnotations struct {
+	Value map[string]string `json:"value"`
+	Op    string            `json:"op"`
+	Path  string            `json:"path"`
+}
+
+// Deploy creates or updates a daemonset
+func Deploy(ctx context.Context, ds *appsv1.DaemonSet, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	ds.ResourceVersion = ""
+	result, err := c.AppsV1().DaemonSets(ds.Namespace).Update(ctx, ds, metav1.UpdateOptions{})
+	if err == nil {
+		return result, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return c.AppsV1().DaemonSets(ds.Namespace).Create(ctx, ds, metav1.CreateOptions{})
+}
+
+// Get returns a daemonset object by name
+func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	return c.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetByDev returns a daemonset object given a dev struct (by name or by labels)
+func GetByDev(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) (*appsv1.DaemonSet, error) {
+	if len(dev.Selector) == 0 {
+		return Get(ctx, dev.Name, namespace, c)
+	}
+
+	dsList, err := c.AppsV1().DaemonSets(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: dev.LabelsSelector(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	validDaemonSets := []*appsv1.DaemonSet{}
+	for i := range dsList.Items {
+		if dsList.Items[i].Labels[model.DevCloneLabel] == "" {
+			validDaemonSets = append(validDaemonSets, &dsList.Items[i])
+		}
+	}
+	if len(validDaemonSets) == 0 {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	if len(validDaemonSets) > 1 {
+		return nil, fmt.Errorf("found '%d' daemonsets for labels '%s' instead of 1", len(validDaemonSets), dev.LabelsSelector())
+	}
+	return validDaemonSets[0], nil
+}
+
+// Destroy removes a daemonset object given its name and namespace
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	if err := c.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting kubernetes daemonset: %w", err)
+	}
+	oktetoLog.Infof("daemonset '%s' deleted", name)
+	return nil
+}
+
+// CheckConditionErrors checks errors in conditions
+func CheckConditionErrors(ds *appsv1.DaemonSet) error {
+	for _, c := range ds.Status.Conditions {
+		if c.Reason == "FailedCreate" && c.Status == apiv1.ConditionTrue {
+			if strings.Contains(c.Message, "exceeded quota") {
+				oktetoLog.Infof("%s: %s", oktetoErrors.ErrQuota, c.Message)
+				return oktetoErrors.ErrQuota
+			}
+			return fmt.Errorf(c.Message)
+		}
+	}
+	return nil
+}
+
+// PatchAnnotations patches the daemonset annotations
+func PatchAnnotations(ctx context.Context, ds *appsv1.DaemonSet, c kubernetes.Interface) error {
+	payload := []patchAnnotations{
+		{
+			Op:    "replace",
+			Path:  "/metadata/annotations",
+			Value: ds.Annotations,
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.AppsV1().DaemonSets(ds.Namespace).Patch(ctx, ds.Name, types.JSONPatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
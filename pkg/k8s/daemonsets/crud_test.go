@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonsets
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDaemonSet(name string, labels map[string]string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    labels,
+		},
+	}
+}
+
+func TestGetByDev(t *testing.T) {
+	var tests = []struct {
+		name         string
+		dev          *model.Dev
+		daemonsets   []*appsv1.DaemonSet
+		expectedName string
+		expectedErr  bool
+		notFound     bool
+	}{
+		{
+			name: "by-name",
+			dev:  &model.Dev{Name: "agent"},
+			daemonsets: []*appsv1.DaemonSet{
+				newDaemonSet("agent", nil),
+			},
+			expectedName: "agent",
+		},
+		{
+			name: "by-selector-ignoring-dev-clones",
+			dev:  &model.Dev{Name: "dev", Selector: model.Selector{"app": "agent"}},
+			daemonsets: []*appsv1.DaemonSet{
+				newDaemonSet("agent", map[string]string{"app": "agent"}),
+				newDaemonSet("agent-okteto", map[string]string{"app": "agent", model.DevCloneLabel: "uid"}),
+			},
+			expectedName: "agent",
+		},
+		{
+			name: "by-selector-several-matches",
+			dev:  &model.Dev{Name: "dev", Selector: model.Selector{"app": "agent"}},
+			daemonsets: []*appsv1.DaemonSet{
+				newDaemonSet("agent", map[string]string{"app": "agent"}),
+				newDaemonSet("agent-2", map[string]string{"app": "agent"}),
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "by-selector-not-found",
+			dev:         &model.Dev{Name: "dev", Selector: model.Selector{"app": "agent"}},
+			expectedErr: true,
+			notFound:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			for _, ds := range tt.daemonsets {
+				_, err := c.AppsV1().DaemonSets("test").Create(context.Background(), ds, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			ds, err := GetByDev(context.Background(), tt.dev, "test", c)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.notFound, oktetoErrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, ds.Name)
+		})
+	}
+}
+
+func TestDeployAndDestroy(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	ds := newDaemonSet("agent", nil)
+
+	_, err := Deploy(ctx, ds, c)
+	require.NoError(t, err)
+
+	ds.Annotations = map[string]string{"key": "value"}
+	require.NoError(t, PatchAnnotations(ctx, ds, c))
+	result, err := Get(ctx, "agent", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, "value", result.Annotations["key"])
+
+	require.NoError(t, Destroy(ctx, "agent", "test", c))
+	require.NoError(t, Destroy(ctx, "agent", "test", c))
+	_, err = Get(ctx, "agent", "test", c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httproutes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayV1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+)
+
+// Update updates a gateway API http route
+func Update(ctx context.Context, route *gatewayV1beta1.HTTPRoute, c gatewayclientset.Interface) error {
+	_, err := c.GatewayV1beta1().HTTPRoutes(route.Namespace).Update(ctx, route, metav1.UpdateOptions{})
+	return err
+}
+
+// Get gets a gateway API http route by name
+func Get(ctx context.Context, name, namespace string, c gatewayclientset.Interface) (*gatewayV1beta1.HTTPRoute, error) {
+	return c.GatewayV1beta1().HTTPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List lists the gateway API http routes of a namespace
+func List(ctx context.Context, namespace string, c gatewayclientset.Interface) ([]gatewayV1beta1.HTTPRoute, error) {
+	routeList, err := c.GatewayV1beta1().HTTPRoutes(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return routeList.Items, nil
+}
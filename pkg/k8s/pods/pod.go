@@ -135,6 +135,31 @@ func GetPodByStatefulSet(ctx context.Context, sfs *appsv1.StatefulSet, c kuberne
 	return nil, oktetoErrors.ErrNotFound
 }
 
+// GetPodByDaemonSet returns a pod of a given daemonset
+func GetPodByDaemonSet(ctx context.Context, ds *appsv1.DaemonSet, c kubernetes.Interface) (*apiv1.Pod, error) {
+	podList, err := c.CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if podList.Items[i].DeletionTimestamp != nil {
+			continue
+		}
+		if podList.Items[i].Status.Phase == apiv1.PodFailed && podList.Items[i].Status.Reason == "Shutdown" {
+			continue
+		}
+		if podList.Items[i].Status.Phase == apiv1.PodFailed && podList.Items[i].Status.Reason == "Evicted" {
+			continue
+		}
+		for _, or := range podList.Items[i].OwnerReferences {
+			if or.UID == ds.UID {
+				return &podList.Items[i], nil
+			}
+		}
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
 // GetUserByPod returns the current user of a running pod
 func GetUserByPod(ctx context.Context, p *apiv1.Pod, container string, config *rest.Config, c *kubernetes.Clientset) (int64, error) {
 	cmd := []string{"sh", "-c", "id -u"}
@@ -0,0 +1,223 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollouts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Deploy creates or updates a rollout.
+// Custom resources don't support unconditional updates, so on conflicts the latest rollout is fetched,
+// the changes made by okteto (pod template, replicas, labels and annotations) are applied on it and the update is retried
+func Deploy(ctx context.Context, r *Rollout, c dynamic.Interface) (*Rollout, error) {
+	var result *Rollout
+	desired := r
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := desired.ToUnstructured()
+		if err != nil {
+			return err
+		}
+		updated, err := c.Resource(GVR).Namespace(desired.Namespace).Update(ctx, u, metav1.UpdateOptions{})
+		if err == nil {
+			result, err = FromUnstructured(updated)
+			return err
+		}
+		if k8sErrors.IsConflict(err) {
+			current, getErr := Get(ctx, r.Name, r.Namespace, c)
+			if getErr != nil {
+				return getErr
+			}
+			desired = applyChanges(current, r)
+		}
+		return err
+	})
+	if err == nil {
+		return result, nil
+	}
+
+	if !oktetoErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	r.ResourceVersion = ""
+	u, err := r.ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+	created, err := c.Resource(GVR).Namespace(r.Namespace).Create(ctx, u, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return FromUnstructured(created)
+}
+
+// applyChanges sets the fields of a rollout modified by okteto on the latest version of the rollout,
+// keeping the changes made by others in the rest of fields
+func applyChanges(current, r *Rollout) *Rollout {
+	current.Labels = r.Labels
+	current.Annotations = r.Annotations
+	current.Spec.Replicas = r.Spec.Replicas
+	current.Spec.Template = r.Spec.Template
+	return current
+}
+
+// Get returns a rollout object by name
+func Get(ctx context.Context, name, namespace string, c dynamic.Interface) (*Rollout, error) {
+	u, err := c.Resource(GVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return FromUnstructured(u)
+}
+
+// List returns the rollouts of a namespace filtered by labels
+func List(ctx context.Context, namespace, labels string, c dynamic.Interface) ([]*Rollout, error) {
+	rList, err := c.Resource(GVR).Namespace(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: labels,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Rollout, 0, len(rList.Items))
+	for i := range rList.Items {
+		r, err := FromUnstructured(&rList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// GetByDev returns a rollout object given a dev struct (by name or by labels)
+func GetByDev(ctx context.Context, dev *model.Dev, namespace string, c dynamic.Interface) (*Rollout, error) {
+	if len(dev.Selector) == 0 {
+		return Get(ctx, dev.Name, namespace, c)
+	}
+
+	rList, err := c.Resource(GVR).Namespace(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: dev.LabelsSelector(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	validRollouts := []*Rollout{}
+	for i := range rList.Items {
+		if rList.Items[i].GetLabels()[model.DevCloneLabel] != "" {
+			continue
+		}
+		r, err := FromUnstructured(&rList.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		validRollouts = append(validRollouts, r)
+	}
+	if len(validRollouts) == 0 {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	if len(validRollouts) > 1 {
+		return nil, fmt.Errorf("found '%d' rollouts for labels '%s' instead of 1", len(validRollouts), dev.LabelsSelector())
+	}
+	return validRollouts[0], nil
+}
+
+// GetCurrentReplicaSet returns the replicaset of the current revision of a rollout
+func GetCurrentReplicaSet(ctx context.Context, r *Rollout, c kubernetes.Interface) (*appsv1.ReplicaSet, error) {
+	if r.Status.CurrentPodHash == "" {
+		return nil, oktetoErrors.ErrNotFound
+	}
+	rsList, err := c.AppsV1().ReplicaSets(r.Namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", PodTemplateHashLabel, r.Status.CurrentPodHash),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+	for i := range rsList.Items {
+		for _, or := range rsList.Items[i].OwnerReferences {
+			if or.UID == r.UID {
+				return &rsList.Items[i], nil
+			}
+		}
+	}
+	return nil, oktetoErrors.ErrNotFound
+}
+
+// Destroy removes a rollout object given its name and namespace
+func Destroy(ctx context.Context, name, namespace string, c dynamic.Interface) error {
+	if err := c.Resource(GVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting argo rollout: %w", err)
+	}
+	oktetoLog.Infof("rollout '%s' deleted", name)
+	return nil
+}
+
+// CheckConditionErrors checks errors in conditions
+func CheckConditionErrors(r *Rollout) error {
+	for _, c := range r.Status.Conditions {
+		if c.Type == "ReplicaFailure" && c.Status == apiv1.ConditionTrue {
+			if strings.Contains(c.Message, "exceeded quota") {
+				oktetoLog.Infof("%s: %s", oktetoErrors.ErrQuota, c.Message)
+				return oktetoErrors.ErrQuota
+			}
+			return fmt.Errorf(c.Message)
+		}
+	}
+	return nil
+}
+
+// PatchAnnotations patches the rollout annotations
+func PatchAnnotations(ctx context.Context, r *Rollout, c dynamic.Interface) error {
+	payload := []map[string]interface{}{
+		{
+			"op":    "replace",
+			"path":  "/metadata/annotations",
+			"value": r.Annotations,
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.Resource(GVR).Namespace(r.Namespace).Patch(ctx, r.Name, types.JSONPatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollouts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newUnstructuredRollout(name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": name},
+				},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"app": name},
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "api", "image": "okteto/api"},
+						},
+					},
+				},
+				"strategy": map[string]interface{}{
+					"canary": map[string]interface{}{
+						"steps": []interface{}{
+							map[string]interface{}{"setWeight": int64(20)},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"currentPodHash": "abc123",
+			},
+		},
+	}
+}
+
+func newFakeDynamicClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{GVR: "RolloutList"},
+		objects...,
+	)
+}
+
+func TestFromUnstructured(t *testing.T) {
+	r, err := FromUnstructured(newUnstructuredRollout("api", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", r.Name)
+	assert.Equal(t, int32(3), *r.Spec.Replicas)
+	assert.Equal(t, "okteto/api", r.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "abc123", r.Status.CurrentPodHash)
+}
+
+func TestToUnstructuredKeepsUnknownFields(t *testing.T) {
+	r, err := FromUnstructured(newUnstructuredRollout("api", nil))
+	require.NoError(t, err)
+	zero := int32(0)
+	r.Spec.Replicas = &zero
+	r.Labels = map[string]string{"dev.okteto.com": "true"}
+
+	u, err := r.ToUnstructured()
+	require.NoError(t, err)
+
+	replicas, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), replicas)
+	assert.Equal(t, "true", u.GetLabels()["dev.okteto.com"])
+	steps, found, err := unstructured.NestedSlice(u.Object, "spec", "strategy", "canary", "steps")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Len(t, steps, 1)
+	_, found = u.Object["status"]
+	assert.False(t, found)
+}
+
+func TestGetByDev(t *testing.T) {
+	var tests = []struct {
+		name         string
+		dev          *model.Dev
+		expectedName string
+		expectedErr  bool
+		notFound     bool
+	}{
+		{
+			name:         "by-name",
+			dev:          &model.Dev{Name: "api"},
+			expectedName: "api",
+		},
+		{
+			name:         "by-selector-ignoring-dev-clones",
+			dev:          &model.Dev{Name: "dev", Selector: model.Selector{"app.kubernetes.io/name": "api"}},
+			expectedName: "api",
+		},
+		{
+			name:        "by-selector-not-found",
+			dev:         &model.Dev{Name: "dev", Selector: model.Selector{"app.kubernetes.io/name": "frontend"}},
+			expectedErr: true,
+			notFound:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeDynamicClient(
+				newUnstructuredRollout("api", map[string]interface{}{"app.kubernetes.io/name": "api"}),
+				newUnstructuredRollout("api-okteto", map[string]interface{}{"app.kubernetes.io/name": "api", model.DevCloneLabel: "uid"}),
+			)
+
+			r, err := GetByDev(context.Background(), tt.dev, "test", c)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.notFound, oktetoErrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, r.Name)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	c := newFakeDynamicClient(
+		newUnstructuredRollout("api", map[string]interface{}{"app.kubernetes.io/name": "api"}),
+		newUnstructuredRollout("frontend", map[string]interface{}{"app.kubernetes.io/name": "frontend"}),
+	)
+
+	result, err := List(context.Background(), "test", "", c)
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	result, err = List(context.Background(), "test", "app.kubernetes.io/name=frontend", c)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "frontend", result[0].Name)
+	assert.Equal(t, "okteto/api", result[0].Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeDynamicClient(newUnstructuredRollout("api", nil))
+
+	r, err := Get(ctx, "api", "test", c)
+	require.NoError(t, err)
+	zero := int32(0)
+	r.Spec.Replicas = &zero
+
+	_, err = Deploy(ctx, r, c)
+	require.NoError(t, err)
+
+	u, err := c.Resource(GVR).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	replicas, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), replicas)
+	_, found, err := unstructured.NestedMap(u.Object, "spec", "strategy", "canary")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	require.NoError(t, Destroy(ctx, "api", "test", c))
+	_, err = Deploy(ctx, r, c)
+	require.NoError(t, err)
+	_, err = Get(ctx, "api", "test", c)
+	require.NoError(t, err)
+}
+
+func TestDeployOnConflict(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeDynamicClient(newUnstructuredRollout("api", nil))
+
+	r, err := Get(ctx, "api", "test", c)
+	require.NoError(t, err)
+	zero := int32(0)
+	r.Spec.Replicas = &zero
+	r.Annotations = map[string]string{"dev.okteto.com/auto-ingress": "true"}
+
+	// the rollout is modified by argo rollouts before okteto updates it
+	conflicts := 0
+	c.PrependReactor("update", "rollouts", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		updated := action.(k8sTesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+		if updated.GetResourceVersion() != "" {
+			return false, nil, nil
+		}
+		conflicts++
+		current, err := c.Tracker().Get(GVR, "test", "api")
+		if err != nil {
+			return true, nil, err
+		}
+		modified := current.(*unstructured.Unstructured).DeepCopy()
+		modified.SetResourceVersion("2")
+		if err := unstructured.SetNestedField(modified.Object, "Paused", "status", "phase"); err != nil {
+			return true, nil, err
+		}
+		if err := unstructured.SetNestedField(modified.Object, true, "spec", "paused"); err != nil {
+			return true, nil, err
+		}
+		if err := c.Tracker().Update(GVR, modified, "test"); err != nil {
+			return true, nil, err
+		}
+		return true, nil, k8sErrors.NewConflict(GVR.GroupResource(), "api", errors.New("the object has been modified"))
+	})
+
+	_, err = Deploy(ctx, r, c)
+	require.NoError(t, err)
+	assert.Equal(t, 1, conflicts)
+
+	u, err := c.Resource(GVR).Namespace("test").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	replicas, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), replicas)
+	assert.Equal(t, "true", u.GetAnnotations()["dev.okteto.com/auto-ingress"])
+	paused, _, err := unstructured.NestedBool(u.Object, "spec", "paused")
+	require.NoError(t, err)
+	assert.True(t, paused)
+}
+
+func TestGetCurrentReplicaSet(t *testing.T) {
+	r, err := FromUnstructured(newUnstructuredRollout("api", nil))
+	require.NoError(t, err)
+	r.UID = "rollout-uid"
+
+	c := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "api-old",
+				Namespace:       "test",
+				Labels:          map[string]string{PodTemplateHashLabel: "old"},
+				OwnerReferences: []metav1.OwnerReference{{UID: "rollout-uid"}},
+			},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "api-abc123",
+				Namespace:       "test",
+				Labels:          map[string]string{PodTemplateHashLabel: "abc123"},
+				OwnerReferences: []metav1.OwnerReference{{UID: "rollout-uid"}},
+			},
+		},
+	)
+
+	rs, err := GetCurrentReplicaSet(context.Background(), r, c)
+	require.NoError(t, err)
+	assert.Equal(t, "api-abc123", rs.Name)
+
+	r.Status.CurrentPodHash = ""
+	_, err = GetCurrentReplicaSet(context.Background(), r, c)
+	assert.True(t, oktetoErrors.IsNotFound(err))
+}
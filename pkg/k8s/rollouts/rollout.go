@@ -0,0 +1,139 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollouts
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// PodTemplateHashLabel is the label added by argo rollouts to the pods of each revision
+	PodTemplateHashLabel = "rollouts-pod-template-hash"
+)
+
+// GVR is the group version resource of argo rollouts
+var GVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+// Rollout is the subset of an argo rollout needed to run it in dev mode.
+// The fields not modeled here (strategy, analysis...) are kept untouched on updates
+type Rollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Spec   `json:"spec"`
+	Status Status `json:"status,omitempty"`
+
+	raw map[string]interface{}
+}
+
+// rolloutObject holds the fields of a rollout converted from and to unstructured objects
+type rolloutObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Spec   `json:"spec"`
+	Status Status `json:"status,omitempty"`
+}
+
+// Spec is the specification of an argo rollout
+type Spec struct {
+	Replicas    *int32                `json:"replicas,omitempty"`
+	Selector    *metav1.LabelSelector `json:"selector,omitempty"`
+	Template    apiv1.PodTemplateSpec `json:"template,omitempty"`
+	WorkloadRef *WorkloadRef          `json:"workloadRef,omitempty"`
+}
+
+// WorkloadRef is a reference to the workload that holds the pod template of an argo rollout
+type WorkloadRef struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+// Status is the status of an argo rollout
+type Status struct {
+	CurrentPodHash string      `json:"currentPodHash,omitempty"`
+	Phase          string      `json:"phase,omitempty"`
+	Message        string      `json:"message,omitempty"`
+	Conditions     []Condition `json:"conditions,omitempty"`
+}
+
+// Condition is a condition of an argo rollout
+type Condition struct {
+	Type    string                `json:"type"`
+	Status  apiv1.ConditionStatus `json:"status"`
+	Reason  string                `json:"reason,omitempty"`
+	Message string                `json:"message,omitempty"`
+}
+
+// FromUnstructured converts an unstructured argo rollout into a rollout
+func FromUnstructured(u *unstructured.Unstructured) (*Rollout, error) {
+	obj := &rolloutObject{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return nil, fmt.Errorf("error parsing rollout '%s': %w", u.GetName(), err)
+	}
+	return &Rollout{
+		TypeMeta:   obj.TypeMeta,
+		ObjectMeta: obj.ObjectMeta,
+		Spec:       obj.Spec,
+		Status:     obj.Status,
+		raw:        runtime.DeepCopyJSON(u.Object),
+	}, nil
+}
+
+// ToUnstructured converts the rollout into an unstructured object, keeping the fields not modeled by the rollout
+func (r *Rollout) ToUnstructured() (*unstructured.Unstructured, error) {
+	typed, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&rolloutObject{
+		TypeMeta:   r.TypeMeta,
+		ObjectMeta: r.ObjectMeta,
+		Spec:       r.Spec,
+		Status:     r.Status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error converting rollout '%s': %w", r.Name, err)
+	}
+	obj := map[string]interface{}{}
+	if r.raw != nil {
+		obj = runtime.DeepCopyJSON(r.raw)
+	}
+	obj["apiVersion"] = GVR.GroupVersion().String()
+	obj["kind"] = "Rollout"
+	obj["metadata"] = typed["metadata"]
+
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+	}
+	typedSpec, _ := typed["spec"].(map[string]interface{})
+	for _, field := range []string{"replicas", "selector", "template", "workloadRef"} {
+		value, ok := typedSpec[field]
+		if !ok {
+			delete(spec, field)
+			continue
+		}
+		spec[field] = value
+	}
+	if r.Spec.WorkloadRef != nil {
+		delete(spec, "template")
+	}
+	obj["spec"] = spec
+	delete(obj, "status")
+	return &unstructured.Unstructured{Object: obj}, nil
+}
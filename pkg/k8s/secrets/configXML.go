@@ -22,7 +22,7 @@ import (
 
 const configXML = `<configuration version="32">
 {{ range .Folders }}
-<folder id="okteto-{{ .Name }}" label="{{ .Name }}" path="{{ .RemotePath }}" type="sendreceive" rescanIntervalS="{{ $.RescanInterval }}" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="false" autoNormalize="true">
+<folder id="okteto-{{ .Name }}" label="{{ .Name }}" path="{{ .RemotePath }}" type="{{ .RemoteType }}" rescanIntervalS="{{ $.RescanInterval }}" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="false" autoNormalize="true">
     <filesystemType>basic</filesystemType>
     <device id="ABKAVQF-RUO4CYO-FSC2VIP-VRX4QDA-TQQRN2J-MRDXJUC-FXNWP6N-S6ZSAAR" introducedBy=""></device>
     <device id="ATOPHFJ-VPVLDFY-QVZDCF2-OQQ7IOW-OG4DIXF-OA7RWU3-ZYA4S22-SI4XVAU" introducedBy=""></device>
@@ -36,7 +36,7 @@ const configXML = `<configuration version="32">
     <scanProgressIntervalS>1</scanProgressIntervalS>
     <disableFsync>true</disableFsync>
     <pullerPauseS>0</pullerPauseS>
-    <maxConflicts>0</maxConflicts>
+    <maxConflicts>{{ $.MaxConflicts }}</maxConflicts>
     <disableSparseFiles>false</disableSparseFiles>
     <disableTempIndexes>false</disableTempIndexes>
     <paused>false</paused>
@@ -0,0 +1,217 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// debugger defines how the programs of a language are started with their remote debugger
+type debugger struct {
+	// wrap returns the command that starts cmd with the debugger listening on port, or nil if cmd can't be wrapped
+	wrap func(cmd []string, port int) []string
+
+	// vscode returns the VS Code launch configuration that attaches to the debugger
+	vscode func(name string, port int, mappings []pathMapping) map[string]interface{}
+
+	// jetbrains returns the JetBrains run configuration that attaches to the debugger
+	jetbrains func(name string, port int, mappings []pathMapping) *jetbrainsConfiguration
+
+	// launcher is the command users run to start a program with the debugger
+	launcher string
+
+	port         int
+	capabilities []apiv1.Capability
+}
+
+var debuggers = map[string]debugger{
+	golang: {
+		port:         2345,
+		capabilities: []apiv1.Capability{"SYS_PTRACE"},
+		wrap:         wrapWithDelve,
+		launcher:     "dlv debug --headless --listen=:%d --api-version=2 --accept-multiclient",
+		vscode:       delveVSCodeConfig,
+		jetbrains:    delveJetbrainsConfig,
+	},
+	Python: {
+		port:     5678,
+		wrap:     wrapWithDebugpy,
+		launcher: "python -m debugpy --listen 0.0.0.0:%d <script>",
+		vscode:   debugpyVSCodeConfig,
+	},
+	Javascript: {
+		port: 9229,
+		// the inspector is only added to the command: with NODE_OPTIONS every node process of the container,
+		// like npm or yarn and the scripts they run, would try to listen on the debug port
+		wrap:      wrapWithNodeInspector,
+		launcher:  "node --inspect=0.0.0.0:%d <script>",
+		vscode:    nodeVSCodeConfig,
+		jetbrains: nodeJetbrainsConfig,
+	},
+}
+
+// SetDebugDefaults configures the dev container to run its command with the remote debugger defined in its 'debug' section:
+// the command is started by the debugger launcher and the debug port is forwarded to the local machine
+func SetDebugDefaults(dev *model.Dev) error {
+	if dev.Debug == nil {
+		return nil
+	}
+
+	d, err := getDebugger(dev.Debug.Language)
+	if err != nil {
+		return err
+	}
+	dev.Debug.Language = NormalizeLanguage(dev.Debug.Language)
+	if dev.Debug.Port == 0 {
+		dev.Debug.Port = d.port
+	}
+	port := dev.Debug.Port
+
+	wrapped, err := wrapCommand(d, dev.Command.Values, port)
+	if err != nil {
+		oktetoLog.Warning("The command of '%s' is not started with the debugger: %s", dev.Name, err)
+	}
+	if wrapped != nil {
+		dev.Command.Values = wrapped
+	} else {
+		oktetoLog.Information("Start your program with '%s' to debug it", fmt.Sprintf(d.launcher, port))
+	}
+
+	if err := addDebugForward(dev, port); err != nil {
+		return err
+	}
+
+	for _, c := range d.capabilities {
+		addCapability(dev, c)
+	}
+	return nil
+}
+
+func getDebugger(language string) (debugger, error) {
+	d, ok := debuggers[NormalizeLanguage(language)]
+	if !ok {
+		return debugger{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("the language '%s' is not supported by 'debug'", language),
+			Hint: "Set 'debug.language' to one of: go, node, python",
+		}
+	}
+	return d, nil
+}
+
+// shellMetacharacters are the characters of a shell script that are lost when the script is split in words
+const shellMetacharacters = "&|;<>()$`\\\"'\n"
+
+// wrapCommand wraps cmd with the debugger launcher. Commands defined as a single string are run with 'sh -c'.
+// Scripts with shell syntax (operators, quotes, variables...) can't be wrapped, since they are split in words
+func wrapCommand(d debugger, cmd []string, port int) ([]string, error) {
+	if len(cmd) == 3 && cmd[0] == "sh" && cmd[1] == "-c" {
+		if strings.ContainsAny(cmd[2], shellMetacharacters) {
+			return nil, fmt.Errorf("the command '%s' uses shell syntax", cmd[2])
+		}
+		wrapped := d.wrap(strings.Fields(cmd[2]), port)
+		if wrapped == nil {
+			return nil, nil
+		}
+		return []string{"sh", "-c", strings.Join(wrapped, " ")}, nil
+	}
+	return d.wrap(cmd, port), nil
+}
+
+func wrapWithDelve(cmd []string, port int) []string {
+	delve := func(subcommand, target string, args []string) []string {
+		result := []string{"dlv", subcommand, target, "--headless", fmt.Sprintf("--listen=:%d", port), "--api-version=2", "--accept-multiclient", "--continue"}
+		if len(args) > 0 {
+			result = append(result, "--")
+			result = append(result, args...)
+		}
+		return result
+	}
+
+	switch {
+	case len(cmd) >= 2 && cmd[0] == "go" && cmd[1] == "run":
+		if len(cmd) == 2 {
+			return delve("debug", ".", nil)
+		}
+		if strings.HasPrefix(cmd[2], "-") {
+			// build flags can't be translated reliably to delve
+			return nil
+		}
+		return delve("debug", cmd[2], cmd[3:])
+	case len(cmd) >= 1 && (strings.HasPrefix(cmd[0], "./") || strings.HasPrefix(cmd[0], "/")):
+		return delve("exec", cmd[0], cmd[1:])
+	default:
+		return nil
+	}
+}
+
+func wrapWithDebugpy(cmd []string, port int) []string {
+	if len(cmd) < 2 || (cmd[0] != "python" && cmd[0] != "python3") {
+		return nil
+	}
+	if cmd[1] == "-m" && len(cmd) > 2 && cmd[2] == "debugpy" {
+		return cmd
+	}
+	result := []string{cmd[0], "-m", "debugpy", "--listen", fmt.Sprintf("0.0.0.0:%d", port)}
+	return append(result, cmd[1:]...)
+}
+
+func wrapWithNodeInspector(cmd []string, port int) []string {
+	if len(cmd) < 2 || cmd[0] != "node" {
+		return nil
+	}
+	if strings.HasPrefix(cmd[1], "--inspect") {
+		return cmd
+	}
+	result := []string{cmd[0], fmt.Sprintf("--inspect=0.0.0.0:%d", port)}
+	return append(result, cmd[1:]...)
+}
+
+func addDebugForward(dev *model.Dev, port int) error {
+	for _, f := range dev.Forward {
+		if f.Local != port {
+			continue
+		}
+		if f.Remote == port && !f.Service {
+			return nil
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the debug port %d is already used by the forward '%s'", port, f.String()),
+			Hint: "Set a different port in 'debug.port'",
+		}
+	}
+	dev.Forward = append(dev.Forward, forward.Forward{Local: port, Remote: port})
+	return nil
+}
+
+func addCapability(dev *model.Dev, capability apiv1.Capability) {
+	if dev.SecurityContext == nil {
+		dev.SecurityContext = &model.SecurityContext{}
+	}
+	if dev.SecurityContext.Capabilities == nil {
+		dev.SecurityContext.Capabilities = &model.Capabilities{}
+	}
+	for _, c := range dev.SecurityContext.Capabilities.Add {
+		if c == capability {
+			return
+		}
+	}
+	dev.SecurityContext.Capabilities.Add = append(dev.SecurityContext.Capabilities.Add, capability)
+}
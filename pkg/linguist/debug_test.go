@@ -0,0 +1,218 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func Test_wrapCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    string
+		cmd         []string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "go-run-package",
+			language: golang,
+			cmd:      []string{"go", "run", "./cmd/api", "--verbose"},
+			expected: []string{"dlv", "debug", "./cmd/api", "--headless", "--listen=:2345", "--api-version=2", "--accept-multiclient", "--continue", "--", "--verbose"},
+		},
+		{
+			name:     "go-run-without-package",
+			language: golang,
+			cmd:      []string{"go", "run"},
+			expected: []string{"dlv", "debug", ".", "--headless", "--listen=:2345", "--api-version=2", "--accept-multiclient", "--continue"},
+		},
+		{
+			name:     "go-run-with-build-flags",
+			language: golang,
+			cmd:      []string{"go", "run", "-race", "."},
+		},
+		{
+			name:     "go-binary",
+			language: golang,
+			cmd:      []string{"./bin/api"},
+			expected: []string{"dlv", "exec", "./bin/api", "--headless", "--listen=:2345", "--api-version=2", "--accept-multiclient", "--continue"},
+		},
+		{
+			name:     "go-shell",
+			language: golang,
+			cmd:      []string{"bash"},
+		},
+		{
+			name:     "python-script",
+			language: Python,
+			cmd:      []string{"python", "app.py"},
+			expected: []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:2345", "app.py"},
+		},
+		{
+			name:     "python-module-as-string",
+			language: Python,
+			cmd:      []string{"sh", "-c", "python3 -m flask run"},
+			expected: []string{"sh", "-c", "python3 -m debugpy --listen 0.0.0.0:2345 -m flask run"},
+		},
+		{
+			name:        "python-script-with-operators",
+			language:    Python,
+			cmd:         []string{"sh", "-c", "pip install -r requirements.txt && python app.py"},
+			expectedErr: true,
+		},
+		{
+			name:        "python-script-with-quotes",
+			language:    Python,
+			cmd:         []string{"sh", "-c", "python app.py --name 'my app'"},
+			expectedErr: true,
+		},
+		{
+			name:        "node-script-with-variables",
+			language:    Javascript,
+			cmd:         []string{"sh", "-c", "node index.js --port $PORT"},
+			expectedErr: true,
+		},
+		{
+			name:        "go-script-with-pipes",
+			language:    golang,
+			cmd:         []string{"sh", "-c", "./bin/api | tee api.log"},
+			expectedErr: true,
+		},
+		{
+			name:     "python-already-wrapped",
+			language: Python,
+			cmd:      []string{"python", "-m", "debugpy", "--listen", "5678", "app.py"},
+			expected: []string{"python", "-m", "debugpy", "--listen", "5678", "app.py"},
+		},
+		{
+			name:     "node-script",
+			language: Javascript,
+			cmd:      []string{"node", "index.js"},
+			expected: []string{"node", "--inspect=0.0.0.0:2345", "index.js"},
+		},
+		{
+			name:     "npm",
+			language: Javascript,
+			cmd:      []string{"npm", "start"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped, err := wrapCommand(debuggers[tt.language], tt.cmd, 2345)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, wrapped)
+		})
+	}
+}
+
+func TestSetDebugDefaults(t *testing.T) {
+	tests := []struct {
+		dev      *model.Dev
+		expected *model.Dev
+		name     string
+	}{
+		{
+			name: "go",
+			dev: &model.Dev{
+				Command: model.Command{Values: []string{"go", "run", "."}},
+				Debug:   &model.Debug{Language: "golang"},
+			},
+			expected: &model.Dev{
+				Command: model.Command{Values: []string{"dlv", "debug", ".", "--headless", "--listen=:2345", "--api-version=2", "--accept-multiclient", "--continue"}},
+				Debug:   &model.Debug{Language: golang, Port: 2345},
+				Forward: []forward.Forward{{Local: 2345, Remote: 2345}},
+				SecurityContext: &model.SecurityContext{
+					Capabilities: &model.Capabilities{Add: []apiv1.Capability{"SYS_PTRACE"}},
+				},
+			},
+		},
+		{
+			name: "node-with-custom-port-and-forward",
+			dev: &model.Dev{
+				Command: model.Command{Values: []string{"npm", "start"}},
+				Debug:   &model.Debug{Language: "typescript", Port: 9230},
+				Forward: []forward.Forward{{Local: 9230, Remote: 9230}},
+			},
+			expected: &model.Dev{
+				Command: model.Command{Values: []string{"npm", "start"}},
+				Debug:   &model.Debug{Language: Javascript, Port: 9230},
+				Forward: []forward.Forward{{Local: 9230, Remote: 9230}},
+			},
+		},
+		{
+			name: "node-script",
+			dev: &model.Dev{
+				Command:     model.Command{Values: []string{"node", "server.js"}},
+				Debug:       &model.Debug{Language: "javascript"},
+				Environment: env.Environment{{Name: "PORT", Value: "8080"}},
+			},
+			expected: &model.Dev{
+				Command:     model.Command{Values: []string{"node", "--inspect=0.0.0.0:9229", "server.js"}},
+				Debug:       &model.Debug{Language: Javascript, Port: 9229},
+				Forward:     []forward.Forward{{Local: 9229, Remote: 9229}},
+				Environment: env.Environment{{Name: "PORT", Value: "8080"}},
+			},
+		},
+		{
+			name: "python-shell",
+			dev: &model.Dev{
+				Command: model.Command{Values: []string{"bash"}},
+				Debug:   &model.Debug{Language: "python"},
+			},
+			expected: &model.Dev{
+				Command: model.Command{Values: []string{"bash"}},
+				Debug:   &model.Debug{Language: Python, Port: 5678},
+				Forward: []forward.Forward{{Local: 5678, Remote: 5678}},
+			},
+		},
+		{
+			name:     "no-debug",
+			dev:      &model.Dev{Command: model.Command{Values: []string{"bash"}}},
+			expected: &model.Dev{Command: model.Command{Values: []string{"bash"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetDebugDefaults(tt.dev))
+			assert.Equal(t, tt.expected, tt.dev)
+		})
+	}
+}
+
+func TestSetDebugDefaultsErrors(t *testing.T) {
+	dev := &model.Dev{Debug: &model.Debug{Language: "ruby"}}
+	err := SetDebugDefaults(dev)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	dev = &model.Dev{
+		Debug:   &model.Debug{Language: "go"},
+		Forward: []forward.Forward{{Local: 2345, Remote: 8080}},
+	}
+	err = SetDebugDefaults(dev)
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	vscodeWorkspaceFolder = "${workspaceFolder}"
+	jetbrainsProjectDir   = "$PROJECT_DIR$"
+)
+
+// pathMapping maps a local folder of the project to its remote path in the dev container
+type pathMapping struct {
+	local  string
+	remote string
+}
+
+type jetbrainsRunConfig struct {
+	XMLName       xml.Name                `xml:"component"`
+	Name          string                  `xml:"name,attr"`
+	Configuration *jetbrainsConfiguration `xml:"configuration"`
+}
+
+type jetbrainsConfiguration struct {
+	Name                string             `xml:"name,attr"`
+	Type                string             `xml:"type,attr"`
+	FactoryName         string             `xml:"factoryName,attr"`
+	Port                int                `xml:"port,attr"`
+	RestartOnDisconnect bool               `xml:"restartOnDisconnect,attr,omitempty"`
+	Default             bool               `xml:"default,attr"`
+	Options             []jetbrainsOption  `xml:"option"`
+	Mappings            []jetbrainsMapping `xml:"mapping"`
+	Method              jetbrainsMethod    `xml:"method"`
+}
+
+type jetbrainsOption struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type jetbrainsMapping struct {
+	URL       string `xml:"url,attr"`
+	LocalFile string `xml:"local-file,attr"`
+}
+
+type jetbrainsMethod struct {
+	V int `xml:"v,attr"`
+}
+
+// WriteDebugLaunchConfigs writes the VS Code and JetBrains configurations to attach to the debugger of the dev container.
+// dir is the root folder of the project, usually the folder of the okteto manifest
+func WriteDebugLaunchConfigs(dev *model.Dev, dir string) error {
+	if dev.Debug == nil {
+		return nil
+	}
+	d, err := getDebugger(dev.Debug.Language)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("Okteto: %s", dev.Name)
+	if err := writeVSCodeLaunchConfig(dir, d.vscode(name, dev.Debug.Port, getPathMappings(dev, dir, vscodeWorkspaceFolder))); err != nil {
+		return err
+	}
+
+	if d.jetbrains == nil {
+		oktetoLog.Infof("there is no JetBrains run configuration for '%s'", dev.Debug.Language)
+		return nil
+	}
+	config := d.jetbrains(name, dev.Debug.Port, getPathMappings(dev, dir, jetbrainsProjectDir))
+	return writeJetbrainsRunConfig(filepath.Join(dir, ".run", fmt.Sprintf("okteto-%s.run.xml", dev.Name)), config)
+}
+
+// getPathMappings returns the sync folders of the dev container relative to the project root
+func getPathMappings(dev *model.Dev, dir, root string) []pathMapping {
+	result := []pathMapping{}
+	for _, folder := range dev.Sync.Folders {
+		local := folder.LocalPath
+		if rel, err := filepath.Rel(dir, folder.LocalPath); err == nil && !strings.HasPrefix(rel, "..") {
+			local = path.Join(root, filepath.ToSlash(rel))
+		}
+		result = append(result, pathMapping{local: local, remote: folder.RemotePath})
+	}
+	return result
+}
+
+// writeVSCodeLaunchConfig adds config to the launch.json file of the project, replacing the configuration with the same name.
+// VS Code allows comments and trailing commas in launch.json: they are removed, since the file is written back as JSON
+func writeVSCodeLaunchConfig(dir string, config map[string]interface{}) error {
+	launchPath := filepath.Join(dir, ".vscode", "launch.json")
+	launch := map[string]interface{}{
+		"version": "0.2.0",
+	}
+
+	b, err := os.ReadFile(launchPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		stripped, hasComments := stripJSONComments(b)
+		if err := json.Unmarshal(stripped, &launch); err != nil {
+			return fmt.Errorf("'%s' is not a valid JSON file", launchPath)
+		}
+		if hasComments {
+			oktetoLog.Warning("The comments of '%s' are removed to add the debug configuration", launchPath)
+		}
+	}
+
+	configurations, _ := launch["configurations"].([]interface{})
+	replaced := false
+	for i, c := range configurations {
+		if existing, ok := c.(map[string]interface{}); ok && existing["name"] == config["name"] {
+			configurations[i] = config
+			replaced = true
+		}
+	}
+	if !replaced {
+		configurations = append(configurations, config)
+	}
+	launch["configurations"] = configurations
+
+	b, err = json.MarshalIndent(launch, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(launchPath, b)
+}
+
+func writeJetbrainsRunConfig(runConfigPath string, config *jetbrainsConfiguration) error {
+	b, err := xml.MarshalIndent(jetbrainsRunConfig{Name: "ProjectRunConfigurationManager", Configuration: config}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(runConfigPath, b)
+}
+
+// writeConfigFile writes a configuration file of the project, keeping the permissions of the file if it already exists
+func writeConfigFile(filePath string, content []byte) error {
+	perm := os.FileMode(0600)
+	if info, err := os.Stat(filePath); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, content, perm); err != nil {
+		return err
+	}
+	// the permissions are only applied by os.WriteFile to new files
+	return os.Chmod(filePath, perm)
+}
+
+// stripJSONComments removes the comments and the trailing commas of a JSON with comments document.
+// It also returns if the document has comments
+func stripJSONComments(b []byte) ([]byte, bool) {
+	result := make([]byte, 0, len(b))
+	hasComments := false
+	// lastComma is the position in result of a comma that is removed if it is followed by '}' or ']'
+	lastComma := -1
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case c == '"':
+			start := i
+			for i++; i < len(b) && b[i] != '"'; i++ {
+				if b[i] == '\\' {
+					i++
+				}
+			}
+			end := i + 1
+			if end > len(b) {
+				end = len(b)
+			}
+			result = append(result, b[start:end]...)
+			lastComma = -1
+		case c == '/' && i+1 < len(b) && b[i+1] == '/':
+			hasComments = true
+			for i < len(b) && b[i] != '\n' {
+				i++
+			}
+			if i < len(b) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(b) && b[i+1] == '*':
+			hasComments = true
+			i += 2
+			for i+1 < len(b) && (b[i] != '*' || b[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				result = append(result[:lastComma], result[lastComma+1:]...)
+				lastComma = -1
+			}
+			result = append(result, c)
+		case c == ',':
+			lastComma = len(result)
+			result = append(result, c)
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			result = append(result, c)
+		default:
+			lastComma = -1
+			result = append(result, c)
+		}
+	}
+	return result, hasComments
+}
+
+func delveVSCodeConfig(name string, port int, mappings []pathMapping) map[string]interface{} {
+	substitutePath := []interface{}{}
+	for _, m := range mappings {
+		substitutePath = append(substitutePath, map[string]interface{}{"from": m.local, "to": m.remote})
+	}
+	return map[string]interface{}{
+		"name":           name,
+		"type":           "go",
+		"request":        "attach",
+		"mode":           "remote",
+		"host":           "127.0.0.1",
+		"port":           port,
+		"substitutePath": substitutePath,
+	}
+}
+
+func debugpyVSCodeConfig(name string, port int, mappings []pathMapping) map[string]interface{} {
+	pathMappings := []interface{}{}
+	for _, m := range mappings {
+		pathMappings = append(pathMappings, map[string]interface{}{"localRoot": m.local, "remoteRoot": m.remote})
+	}
+	return map[string]interface{}{
+		"name":    name,
+		"type":    "debugpy",
+		"request": "attach",
+		"connect": map[string]interface{}{
+			"host": "127.0.0.1",
+			"port": port,
+		},
+		"pathMappings": pathMappings,
+	}
+}
+
+func nodeVSCodeConfig(name string, port int, mappings []pathMapping) map[string]interface{} {
+	config := map[string]interface{}{
+		"name":    name,
+		"type":    "node",
+		"request": "attach",
+		"address": "127.0.0.1",
+		"port":    port,
+	}
+	// the node debugger only supports one path mapping: the main sync folder
+	if len(mappings) > 0 {
+		config["localRoot"] = mappings[0].local
+		config["remoteRoot"] = mappings[0].remote
+	}
+	return config
+}
+
+func delveJetbrainsConfig(name string, port int, _ []pathMapping) *jetbrainsConfiguration {
+	// GoLand resolves the remote paths from the sources of the project
+	return &jetbrainsConfiguration{
+		Name:        name,
+		Type:        "GoRemoteDebugConfigurationType",
+		FactoryName: "Go Remote",
+		Port:        port,
+		Options: []jetbrainsOption{
+			{Name: "disconnectOption", Value: "LEAVE"},
+		},
+		Method: jetbrainsMethod{V: 2},
+	}
+}
+
+func nodeJetbrainsConfig(name string, port int, mappings []pathMapping) *jetbrainsConfiguration {
+	config := &jetbrainsConfiguration{
+		Name:                name,
+		Type:                "ChromiumRemoteDebugType",
+		FactoryName:         "Chromium Remote",
+		Port:                port,
+		RestartOnDisconnect: true,
+		Method:              jetbrainsMethod{V: 2},
+	}
+	for _, m := range mappings {
+		config.Mappings = append(config.Mappings, jetbrainsMapping{URL: fmt.Sprintf("file://%s", m.remote), LocalFile: m.local})
+	}
+	return config
+}
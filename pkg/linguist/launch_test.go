@@ -0,0 +1,186 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDebugLaunchConfigs(t *testing.T) {
+	dir := t.TempDir()
+	existing := `{"version": "0.2.0", "configurations": [{"name": "local", "type": "go"}, {"name": "Okteto: api", "type": "node"}]}`
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".vscode"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".vscode", "launch.json"), []byte(existing), 0600))
+
+	dev := &model.Dev{
+		Name:  "api",
+		Debug: &model.Debug{Language: "go", Port: 2345},
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: dir, RemotePath: "/usr/src/app"},
+				{LocalPath: filepath.Join(dir, "lib"), RemotePath: "/usr/src/lib"},
+			},
+		},
+	}
+	require.NoError(t, WriteDebugLaunchConfigs(dev, dir))
+
+	b, err := os.ReadFile(filepath.Join(dir, ".vscode", "launch.json"))
+	require.NoError(t, err)
+	launch := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &launch))
+	expected := map[string]interface{}{
+		"version": "0.2.0",
+		"configurations": []interface{}{
+			map[string]interface{}{"name": "local", "type": "go"},
+			map[string]interface{}{
+				"name":    "Okteto: api",
+				"type":    "go",
+				"request": "attach",
+				"mode":    "remote",
+				"host":    "127.0.0.1",
+				"port":    float64(2345),
+				"substitutePath": []interface{}{
+					map[string]interface{}{"from": "${workspaceFolder}", "to": "/usr/src/app"},
+					map[string]interface{}{"from": "${workspaceFolder}/lib", "to": "/usr/src/lib"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, launch)
+
+	b, err = os.ReadFile(filepath.Join(dir, ".run", "okteto-api.run.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `<configuration name="Okteto: api" type="GoRemoteDebugConfigurationType" factoryName="Go Remote" port="2345" default="false">`)
+}
+
+func TestWriteDebugLaunchConfigsNode(t *testing.T) {
+	dir := t.TempDir()
+	dev := &model.Dev{
+		Name:  "web",
+		Debug: &model.Debug{Language: "node", Port: 9229},
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: filepath.Join(dir, "web"), RemotePath: "/usr/src/app"},
+			},
+		},
+	}
+	require.NoError(t, WriteDebugLaunchConfigs(dev, dir))
+
+	b, err := os.ReadFile(filepath.Join(dir, ".vscode", "launch.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"localRoot": "${workspaceFolder}/web"`)
+	assert.Contains(t, string(b), `"remoteRoot": "/usr/src/app"`)
+
+	b, err = os.ReadFile(filepath.Join(dir, ".run", "okteto-web.run.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `<mapping url="file:///usr/src/app" local-file="$PROJECT_DIR$/web"></mapping>`)
+}
+
+func TestWriteDebugLaunchConfigsInvalidLaunchFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".vscode"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".vscode", "launch.json"), []byte("{\"configurations\": ["), 0600))
+
+	dev := &model.Dev{Name: "api", Debug: &model.Debug{Language: "python", Port: 5678}}
+	assert.Error(t, WriteDebugLaunchConfigs(dev, dir))
+}
+
+func TestWriteDebugLaunchConfigsWithComments(t *testing.T) {
+	dir := t.TempDir()
+	existing := `// Use IntelliSense to learn about possible attributes.
+{
+	"version": "0.2.0",
+	/* local debugging */
+	"configurations": [
+		{"name": "local // debug", "type": "python", "program": "${file}",},
+	],
+}`
+	launchPath := filepath.Join(dir, ".vscode", "launch.json")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".vscode"), 0755))
+	require.NoError(t, os.WriteFile(launchPath, []byte(existing), 0644))
+	require.NoError(t, os.Chmod(launchPath, 0644))
+
+	dev := &model.Dev{Name: "api", Debug: &model.Debug{Language: "python", Port: 5678}}
+	require.NoError(t, WriteDebugLaunchConfigs(dev, dir))
+
+	b, err := os.ReadFile(launchPath)
+	require.NoError(t, err)
+	launch := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &launch))
+	configurations, ok := launch["configurations"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, configurations, 2)
+	assert.Equal(t, map[string]interface{}{"name": "local // debug", "type": "python", "program": "${file}"}, configurations[0])
+
+	info, err := os.Stat(launchPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func TestStripJSONComments(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               string
+		expected            string
+		expectedHasComments bool
+	}{
+		{
+			name:     "json",
+			input:    `{"a": [1, 2], "b": "c"}`,
+			expected: `{"a": [1, 2], "b": "c"}`,
+		},
+		{
+			name:                "line comment",
+			input:               "{\n// comment\n\"a\": 1 // trailing\n}",
+			expected:            "{\n\n\"a\": 1 \n}",
+			expectedHasComments: true,
+		},
+		{
+			name:                "block comment",
+			input:               `{/* "a": 1, */"b": 2}`,
+			expected:            `{"b": 2}`,
+			expectedHasComments: true,
+		},
+		{
+			name:     "comment markers in strings",
+			input:    `{"url": "http://okteto.com", "glob": "/*", "quote": "\"//"}`,
+			expected: `{"url": "http://okteto.com", "glob": "/*", "quote": "\"//"}`,
+		},
+		{
+			name:     "trailing commas",
+			input:    "{\"a\": [1, 2,\n],\n}",
+			expected: "{\"a\": [1, 2\n]\n}",
+		},
+		{
+			name:                "trailing comma before a comment",
+			input:               "{\"a\": 1, // comment\n}",
+			expected:            "{\"a\": 1 \n}",
+			expectedHasComments: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, hasComments := stripJSONComments([]byte(tt.input))
+			assert.Equal(t, tt.expected, string(result))
+			assert.Equal(t, tt.expectedHasComments, hasComments)
+		})
+	}
+}
@@ -21,6 +21,9 @@ const (
 	// AppReplicasAnnotation indicates the number of replicas before dev mode was activated
 	AppReplicasAnnotation = "dev.okteto.com/replicas"
 
+	// ScaledDownNodeSelector is the node selector that stops scheduling the pods of a daemonset while it is in dev mode
+	ScaledDownNodeSelector = "dev.okteto.com/scaled-down"
+
 	// InteractiveDevLabel indicates the interactive dev pod
 	InteractiveDevLabel = "interactive.dev.okteto.com"
 
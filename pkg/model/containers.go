@@ -0,0 +1,120 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+// DevContainer represents an additional container of the development pod.
+// Its files are synchronized by the main development container through the persistent volume
+type DevContainer struct {
+	Environment env.Environment `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Command     Command         `json:"command,omitempty" yaml:"command,omitempty"`
+	Sync        Sync            `json:"sync,omitempty" yaml:"sync,omitempty"`
+	Workdir     string          `json:"workdir,omitempty" yaml:"workdir,omitempty"`
+}
+
+// DevContainers represents the additional containers of the development pod, indexed by container name
+type DevContainers map[string]*DevContainer
+
+// ContainerNames returns the names of the additional containers of the development pod sorted alphabetically
+func (dev *Dev) ContainerNames() []string {
+	names := make([]string, 0, len(dev.Containers))
+	for name := range dev.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasContainer returns if a container is the main development container or one of its additional containers
+func (dev *Dev) HasContainer(name string) bool {
+	if name == dev.Container {
+		return true
+	}
+	_, ok := dev.Containers[name]
+	return ok
+}
+
+func (dev *Dev) validateContainers() error {
+	for _, name := range dev.ContainerNames() {
+		if ValidKubeNameRegex.MatchString(name) {
+			return fmt.Errorf("container '%s' is not a valid container name", name)
+		}
+		if name == dev.Container {
+			return fmt.Errorf("container '%s' is the main development container: it can't be defined in 'containers'", name)
+		}
+		c := dev.Containers[name]
+		if c == nil {
+			continue
+		}
+		if c.Sync.Backend != "" {
+			return fmt.Errorf("'backend' is not supported in the 'sync' field of 'containers': the files are synchronized by the main development container")
+		}
+		for _, sync := range c.Sync.Folders {
+			if !strings.HasPrefix(sync.RemotePath, "/") {
+				return fmt.Errorf("remote path '%s' in container '%s' must be an absolute path", sync.RemotePath, name)
+			}
+			if _, err := dev.IsSubPathFolder(sync.LocalPath); err != nil {
+				if err == oktetoErrors.ErrNotFound {
+					return fmt.Errorf("LocalPath '%s' in container '%s' not defined in the field 'sync' of the main development container", sync.LocalPath, name)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ToContainerTranslationRule returns the translation rule of one of the additional containers of the development pod
+func (dev *Dev) ToContainerTranslationRule(name string) *TranslationRule {
+	c := dev.Containers[name]
+	if c == nil {
+		c = &DevContainer{}
+	}
+	rule := &TranslationRule{
+		Container:        name,
+		ImagePullPolicy:  dev.ImagePullPolicy,
+		Environment:      c.Environment,
+		WorkDir:          c.Workdir,
+		PersistentVolume: dev.PersistentVolumeEnabled(),
+		Volumes:          []VolumeMount{},
+	}
+
+	if len(c.Command.Values) > 0 {
+		rule.Command = c.Command.Values
+		rule.Args = []string{}
+	}
+
+	if dev.PersistentVolumeEnabled() {
+		for _, sync := range c.Sync.Folders {
+			rule.Volumes = append(
+				rule.Volumes,
+				VolumeMount{
+					Name:      dev.GetVolumeName(),
+					MountPath: sync.RemotePath,
+					SubPath:   dev.getSourceSubPath(sync.LocalPath),
+				},
+			)
+		}
+	}
+
+	return rule
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateContainers(t *testing.T) {
+	mainSync := Sync{
+		Folders: []SyncFolder{
+			{
+				LocalPath:  "/src",
+				RemotePath: "/app",
+			},
+		},
+	}
+	var tests = []struct {
+		containers DevContainers
+		name       string
+		wantErr    bool
+	}{
+		{
+			name: "ok",
+			containers: DevContainers{
+				"worker": {
+					Sync: Sync{Folders: []SyncFolder{{LocalPath: "/src/worker", RemotePath: "/worker"}}},
+				},
+				"sidecar": nil,
+			},
+		},
+		{
+			name: "main-container",
+			containers: DevContainers{
+				"api": {},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-name",
+			containers: DevContainers{
+				"Worker_1": {},
+			},
+			wantErr: true,
+		},
+		{
+			name: "relative-remote-path",
+			containers: DevContainers{
+				"worker": {
+					Sync: Sync{Folders: []SyncFolder{{LocalPath: "/src", RemotePath: "app"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "local-path-not-synchronized",
+			containers: DevContainers{
+				"worker": {
+					Sync: Sync{Folders: []SyncFolder{{LocalPath: "/other", RemotePath: "/app"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync-backend",
+			containers: DevContainers{
+				"worker": {
+					Sync: Sync{Backend: SyncBackendSSH},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{
+				Container:  "api",
+				Sync:       mainSync,
+				Containers: tt.containers,
+			}
+			err := dev.validateContainers()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestContainerNames(t *testing.T) {
+	dev := &Dev{
+		Container: "api",
+		Containers: DevContainers{
+			"worker":  {},
+			"cron":    {},
+			"sidecar": {},
+		},
+	}
+
+	assert.Equal(t, []string{"cron", "sidecar", "worker"}, dev.ContainerNames())
+	assert.True(t, dev.HasContainer("api"))
+	assert.True(t, dev.HasContainer("cron"))
+	assert.False(t, dev.HasContainer("db"))
+}
+
+func TestToContainerTranslationRule(t *testing.T) {
+	dev := &Dev{
+		Name:            "api",
+		ImagePullPolicy: "Always",
+		Sync: Sync{
+			Folders: []SyncFolder{
+				{
+					LocalPath:  "/src",
+					RemotePath: "/app",
+				},
+			},
+		},
+		parentSyncFolder: "/src",
+		Containers: DevContainers{
+			"worker": {
+				Command:     Command{Values: []string{"python", "worker.py"}},
+				Environment: env.Environment{{Name: "QUEUE", Value: "jobs"}},
+				Workdir:     "/worker",
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src/worker",
+							RemotePath: "/worker",
+						},
+					},
+				},
+			},
+			"sidecar": nil,
+		},
+	}
+
+	rule := dev.ToContainerTranslationRule("worker")
+	expected := &TranslationRule{
+		Container:        "worker",
+		ImagePullPolicy:  "Always",
+		Environment:      env.Environment{{Name: "QUEUE", Value: "jobs"}},
+		WorkDir:          "/worker",
+		Command:          []string{"python", "worker.py"},
+		Args:             []string{},
+		PersistentVolume: true,
+		Volumes: []VolumeMount{
+			{
+				Name:      dev.GetVolumeName(),
+				MountPath: "/worker",
+				SubPath:   "src/worker",
+			},
+		},
+	}
+	assert.Equal(t, expected, rule)
+
+	rule = dev.ToContainerTranslationRule("sidecar")
+	assert.Equal(t, "sidecar", rule.Container)
+	assert.Empty(t, rule.Command)
+	assert.Empty(t, rule.Volumes)
+}
+
+func TestReadManifestWithContainers(t *testing.T) {
+	manifest := []byte(`dev:
+  api:
+    container: api
+    command: bash
+    sync:
+      - .:/app
+    containers:
+      worker:
+        command: python worker.py
+        environment:
+          QUEUE: jobs
+        sync:
+          - .:/app`)
+
+	m, err := Read(manifest)
+	require.NoError(t, err)
+
+	dev := m.Dev["api"]
+	require.Contains(t, dev.Containers, "worker")
+	worker := dev.Containers["worker"]
+	assert.Equal(t, []string{"sh", "-c", "python worker.py"}, worker.Command.Values)
+	assert.Equal(t, env.Environment{{Name: "QUEUE", Value: "jobs"}}, worker.Environment)
+	assert.Equal(t, []SyncFolder{{LocalPath: ".", RemotePath: "/app"}}, worker.Sync.Folders)
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+)
+
+// Debug represents the remote debugger configuration of a development container
+type Debug struct {
+	Language string `json:"language,omitempty" yaml:"language,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+func (d *Debug) validate() error {
+	if d == nil {
+		return nil
+	}
+	if d.Language == "" {
+		return fmt.Errorf("'debug.language' is required")
+	}
+	if d.Port < 0 || d.Port > 65535 {
+		return fmt.Errorf("'debug.port' must be a valid port number")
+	}
+	return nil
+}
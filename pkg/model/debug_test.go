@@ -0,0 +1,61 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugValidate(t *testing.T) {
+	tests := []struct {
+		debug   *Debug
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:  "valid",
+			debug: &Debug{Language: "go", Port: 2345},
+		},
+		{
+			name:  "default-port",
+			debug: &Debug{Language: "python"},
+		},
+		{
+			name:    "missing-language",
+			debug:   &Debug{Port: 2345},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-port",
+			debug:   &Debug{Language: "node", Port: 70000},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.debug.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
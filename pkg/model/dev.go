@@ -14,6 +14,7 @@
 package model
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"net/url"
@@ -47,6 +48,9 @@ var (
 
 	errBadName = fmt.Errorf("Invalid name: must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
 
+	// buildkit only supports one exporter per build
+	errMultipleBuildOutputs = fmt.Errorf("only one build output is supported")
+
 	// ValidKubeNameRegex is the regex to validate a kubernetes resource name
 	ValidKubeNameRegex = regexp.MustCompile(`[^a-z0-9\-]+`)
 )
@@ -61,11 +65,13 @@ type Dev struct {
 	Labels               Labels                `json:"labels,omitempty" yaml:"labels,omitempty"` // Deprecated field
 	Probes               *Probes               `json:"probes,omitempty" yaml:"probes,omitempty"`
 	NodeSelector         map[string]string     `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	Containers           DevContainers         `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Metadata             *Metadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Affinity             *Affinity             `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 	Image                *BuildInfo            `json:"image,omitempty" yaml:"image,omitempty"`
 	Push                 *BuildInfo            `json:"-" yaml:"push,omitempty"`
 	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	Debug                *Debug                `json:"debug,omitempty" yaml:"debug,omitempty"`
 	Replicas             *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	InitContainer        InitContainer         `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	Workdir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
@@ -85,6 +91,7 @@ type Dev struct {
 	Command         Command            `json:"command,omitempty" yaml:"command,omitempty"`
 	Forward         []forward.Forward  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	Reverse         []Reverse          `json:"reverse,omitempty" yaml:"reverse,omitempty"`
+	OnSync          []OnSyncRule       `json:"onSync,omitempty" yaml:"onSync,omitempty"`
 	ExternalVolumes []ExternalVolume   `json:"externalVolumes,omitempty" yaml:"externalVolumes,omitempty"`
 	Secrets         []Secret           `json:"secrets,omitempty" yaml:"secrets,omitempty"`
 	Volumes         []Volume           `json:"volumes,omitempty" yaml:"volumes,omitempty"`
@@ -122,17 +129,28 @@ type Args struct {
 
 // BuildInfo represents the build info to generate an image
 type BuildInfo struct {
-	Secrets          BuildSecrets      `yaml:"secrets,omitempty"`
-	Name             string            `yaml:"name,omitempty"`
-	Context          string            `yaml:"context,omitempty"`
-	Dockerfile       string            `yaml:"dockerfile,omitempty"`
-	Target           string            `yaml:"target,omitempty"`
-	Image            string            `yaml:"image,omitempty"`
-	CacheFrom        cache.CacheFrom   `yaml:"cache_from,omitempty"`
-	Args             BuildArgs         `yaml:"args,omitempty"`
-	VolumesToInclude []StackVolume     `yaml:"-"`
-	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
-	DependsOn        BuildDependsOn    `yaml:"depends_on,omitempty"`
+	Secrets          BuildSecrets       `yaml:"secrets,omitempty"`
+	Name             string             `yaml:"name,omitempty"`
+	Context          string             `yaml:"context,omitempty"`
+	Dockerfile       string             `yaml:"dockerfile,omitempty"`
+	Target           string             `yaml:"target,omitempty"`
+	Image            string             `yaml:"image,omitempty"`
+	CacheFrom        cache.CacheFrom    `yaml:"cache_from,omitempty"`
+	Args             BuildArgs          `yaml:"args,omitempty"`
+	VolumesToInclude []StackVolume      `yaml:"-"`
+	ExportCache      cache.ExportCache  `yaml:"export_cache,omitempty"`
+	DependsOn        BuildDependsOn     `yaml:"depends_on,omitempty"`
+	Platforms        BuildPlatforms     `yaml:"platforms,omitempty"`
+	Attestations     *BuildAttestations `yaml:"attestations,omitempty"`
+	Outputs          BuildOutputs       `yaml:"outputs,omitempty"`
+}
+
+// BuildAttestations represents the attestations attached to the image
+type BuildAttestations struct {
+	// Provenance is the mode of the SLSA provenance attestation: 'mode=min' or 'mode=max'
+	Provenance string `yaml:"provenance,omitempty"`
+	// SBOM enables the SPDX SBOM attestation
+	SBOM bool `yaml:"sbom,omitempty"`
 }
 
 // BuildArg is an argument used on the build step.
@@ -155,8 +173,212 @@ type BuildArgs []BuildArg
 // BuildDependsOn represents the images that needs to be built before
 type BuildDependsOn []string
 
+// BuildPlatforms represents the platforms the image is built for
+type BuildPlatforms []string
+
+// String returns the platforms as a comma separated list, as expected by buildkit
+func (p BuildPlatforms) String() string {
+	return strings.Join(p, ",")
+}
+
+func (p BuildPlatforms) validate() error {
+	for _, platform := range p {
+		parts := strings.Split(platform, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return fmt.Errorf("platform '%s' is not valid: it must follow the syntax 'os/arch[/variant]'", platform)
+		}
+		for _, part := range parts {
+			if part == "" {
+				return fmt.Errorf("platform '%s' is not valid: it must follow the syntax 'os/arch[/variant]'", platform)
+			}
+		}
+	}
+	return nil
+}
+
+// ProvenanceMode returns the buildkit provenance mode for the given value.
+// 'true' enables the minimal provenance and 'false' or an empty value disables it
+func ProvenanceMode(value string) (string, error) {
+	switch value {
+	case "", "false":
+		return "", nil
+	case "true", "mode=min":
+		return "mode=min", nil
+	case "mode=max":
+		return "mode=max", nil
+	default:
+		return "", fmt.Errorf("provenance '%s' is not valid: it must be one of 'true', 'false', 'mode=min' or 'mode=max'", value)
+	}
+}
+
+const (
+	// BuildOutputLocal exports the files of the build result to a local directory
+	BuildOutputLocal = "local"
+	// BuildOutputTar exports the files of the build result to a tarball
+	BuildOutputTar = "tar"
+	// BuildOutputOCI exports the image to an OCI image layout tarball
+	BuildOutputOCI = "oci"
+)
+
+// BuildOutput represents an export of the build result to the local filesystem instead of a push to a registry
+type BuildOutput struct {
+	// Type is the exporter used: 'local', 'tar' or 'oci'
+	Type string `yaml:"type,omitempty"`
+	// Dest is the directory ('local') or the file ('tar' and 'oci') where the build result is exported
+	Dest string `yaml:"dest,omitempty"`
+}
+
+// BuildOutputs represents the exports of the build result
+type BuildOutputs []BuildOutput
+
+// ParseBuildOutput parses an output with the buildkit syntax 'type=local,dest=path'
+func ParseBuildOutput(value string) (BuildOutput, error) {
+	output := BuildOutput{}
+	for _, field := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(field, "=")
+		if !found {
+			return output, fmt.Errorf("output '%s' is not valid: it must follow the syntax 'type=<type>,dest=<path>'", value)
+		}
+		switch strings.TrimSpace(key) {
+		case "type":
+			output.Type = strings.TrimSpace(val)
+		case "dest":
+			output.Dest = strings.TrimSpace(val)
+		default:
+			return output, fmt.Errorf("output '%s' is not valid: unknown field '%s'", value, key)
+		}
+	}
+	if err := output.validate(); err != nil {
+		return output, err
+	}
+	return output, nil
+}
+
+// ValidateBuildOutputs checks the outputs passed with the buildkit syntax 'type=local,dest=path'.
+// The build result can only be exported to one output
+func ValidateBuildOutputs(values []string) error {
+	if len(values) > 1 {
+		return errMultipleBuildOutputs
+	}
+	for _, value := range values {
+		if _, err := ParseBuildOutput(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the output with the buildkit syntax 'type=local,dest=path'
+func (o BuildOutput) String() string {
+	return fmt.Sprintf("type=%s,dest=%s", o.Type, o.Dest)
+}
+
+func (o BuildOutput) validate() error {
+	switch o.Type {
+	case BuildOutputLocal, BuildOutputTar, BuildOutputOCI:
+	default:
+		return fmt.Errorf("output type '%s' is not valid: it must be one of '%s', '%s' or '%s'", o.Type, BuildOutputLocal, BuildOutputTar, BuildOutputOCI)
+	}
+	if o.Dest == "" {
+		return fmt.Errorf("output '%s' is not valid: 'dest' is required", o.Type)
+	}
+	return nil
+}
+
+// Strings returns the outputs with the buildkit syntax 'type=local,dest=path'
+func (o BuildOutputs) Strings() []string {
+	result := make([]string, 0, len(o))
+	for _, output := range o {
+		result = append(result, output.String())
+	}
+	return result
+}
+
+func (o BuildOutputs) validate() error {
+	if len(o) > 1 {
+		return errMultipleBuildOutputs
+	}
+	for _, output := range o {
+		if err := output.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildSecret represents the source of a secret injected to the build of the image.
+// Only the secrets sourced from a file are written to disk
+type BuildSecret struct {
+	// File is the path of a local file. The environment variables in its content are expanded
+	File string `yaml:"file,omitempty"`
+	// Env is the name of the environment variable with the value of the secret
+	Env string `yaml:"env,omitempty"`
+	// OktetoSecret is the name of the Okteto secret with the value of the secret
+	OktetoSecret string `yaml:"oktetoSecret,omitempty"`
+	// Command is a command whose standard output is the value of the secret
+	Command string `yaml:"command,omitempty"`
+}
+
+// String returns the source of the secret. Secrets sourced from a file return the path of the file
+func (s BuildSecret) String() string {
+	switch {
+	case s.Env != "":
+		return fmt.Sprintf("env=%s", s.Env)
+	case s.OktetoSecret != "":
+		return fmt.Sprintf("okteto=%s", s.OktetoSecret)
+	case s.Command != "":
+		return fmt.Sprintf("command=%s", s.Command)
+	default:
+		return s.File
+	}
+}
+
+// BuildkitSecret returns the secret with the syntax of the --secret flag: 'id=mysecret,src=/local/secret'
+func (s BuildSecret) BuildkitSecret(id string) string {
+	fields := []string{fmt.Sprintf("id=%s", id)}
+	switch {
+	case s.Env != "":
+		fields = append(fields, fmt.Sprintf("env=%s", s.Env))
+	case s.OktetoSecret != "":
+		fields = append(fields, fmt.Sprintf("okteto=%s", s.OktetoSecret))
+	case s.Command != "":
+		fields = append(fields, fmt.Sprintf("command=%s", s.Command))
+	default:
+		fields = append(fields, fmt.Sprintf("src=%s", s.File))
+	}
+
+	b := &strings.Builder{}
+	w := csv.NewWriter(b)
+	// the error is always nil when writing to a strings.Builder
+	_ = w.Write(fields)
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (s BuildSecret) validate() error {
+	sources := 0
+	for _, source := range []string{s.File, s.Env, s.OktetoSecret, s.Command} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of 'file', 'env', 'oktetoSecret' or 'command' must be defined")
+	}
+	return nil
+}
+
 // BuildSecrets represents the secrets to be injected to the build of the image
-type BuildSecrets map[string]string
+type BuildSecrets map[string]BuildSecret
+
+func (bs BuildSecrets) validate() error {
+	for id, secret := range bs {
+		if err := secret.validate(); err != nil {
+			return fmt.Errorf("secret '%s' is not valid: %w", id, err)
+		}
+	}
+	return nil
+}
 
 // GetDockerfilePath returns the path to the Dockerfile
 func (b *BuildInfo) GetDockerfilePath() string {
@@ -236,12 +458,40 @@ type Sync struct {
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
+	KeepConflicts  bool         `json:"keepConflicts,omitempty" yaml:"keepConflicts,omitempty"`
+	Backend        string       `json:"backend,omitempty" yaml:"backend,omitempty"`
 }
 
 // SyncFolder represents a sync folder in the development container
 type SyncFolder struct {
 	LocalPath  string
 	RemotePath string
+	Mode       string
+}
+
+const (
+	// SyncModeSend only synchronizes the changes of the local folder to the development container
+	SyncModeSend = "send"
+
+	// SyncModeReceive only synchronizes the changes of the development container to the local folder
+	SyncModeReceive = "receive"
+
+	// SyncModeBoth synchronizes the changes in both directions
+	SyncModeBoth = "both"
+
+	// SyncBackendSyncthing synchronizes the files with syncthing
+	SyncBackendSyncthing = "syncthing"
+
+	// SyncBackendSSH synchronizes the files over the SSH connection with the development container
+	SyncBackendSSH = "ssh"
+)
+
+// GetMode returns the synchronization mode of the folder
+func (s SyncFolder) GetMode() string {
+	if s.Mode == "" {
+		return SyncModeBoth
+	}
+	return s.Mode
 }
 
 // ExternalVolume represents a external volume in the development container
@@ -407,6 +657,14 @@ func (dev *Dev) loadAbsPaths(devPath string) error {
 	}
 
 	dev.loadVolumeAbsPaths(devDir)
+	for _, c := range dev.Containers {
+		if c == nil {
+			continue
+		}
+		for i := range c.Sync.Folders {
+			c.Sync.Folders[i].LocalPath = loadAbsPath(devDir, c.Sync.Folders[i].LocalPath)
+		}
+	}
 	for _, s := range dev.Services {
 		s.loadVolumeAbsPaths(devDir)
 	}
@@ -567,6 +825,11 @@ func (dev *Dev) SetDefaults() error {
 	if dev.Lifecycle == nil {
 		dev.Lifecycle = &Lifecycle{}
 	}
+	for i := range dev.OnSync {
+		if dev.OnSync[i].Debounce == 0 {
+			dev.OnSync[i].Debounce = defaultOnSyncDebounce
+		}
+	}
 	if dev.Interface == "" {
 		dev.Interface = Localhost
 	}
@@ -783,6 +1046,18 @@ func (dev *Dev) Validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if err := dev.validateContainers(); err != nil {
+		return err
+	}
+
+	if err := dev.Debug.validate(); err != nil {
+		return err
+	}
+
+	if err := dev.validateOnSync(); err != nil {
+		return err
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
@@ -795,6 +1070,34 @@ func (dev *Dev) Validate() error {
 	return nil
 }
 
+func (dev *Dev) validateSyncBackend() error {
+	switch dev.Sync.Backend {
+	case "", SyncBackendSyncthing:
+		return nil
+	case SyncBackendSSH:
+		if !dev.RemoteModeEnabled() {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the '%s' sync backend requires the SSH server of your development container", SyncBackendSSH),
+				Hint: fmt.Sprintf("Unset the environment variable '%s' or use the '%s' sync backend", OktetoExecuteSSHEnvVar, SyncBackendSyncthing),
+			}
+		}
+		if dev.Sync.KeepConflicts {
+			return fmt.Errorf("'sync.keepConflicts' is only supported by the '%s' sync backend", SyncBackendSyncthing)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid sync backend '%s': must be '%s' or '%s'", dev.Sync.Backend, SyncBackendSyncthing, SyncBackendSSH)
+	}
+}
+
+// GetSyncBackend returns the backend used to synchronize the files of the development container
+func (dev *Dev) GetSyncBackend() string {
+	if dev.Sync.Backend == "" {
+		return SyncBackendSyncthing
+	}
+	return dev.Sync.Backend
+}
+
 // PreparePathsAndExpandEnvFiles calls other methods required to have the dev ready to use
 func (dev *Dev) PreparePathsAndExpandEnvFiles(manifestPath string) error {
 	if err := dev.loadAbsPaths(manifestPath); err != nil {
@@ -811,6 +1114,10 @@ func (dev *Dev) PreparePathsAndExpandEnvFiles(manifestPath string) error {
 }
 
 func (dev *Dev) validateSync() error {
+	if err := dev.validateSyncBackend(); err != nil {
+		return err
+	}
+
 	for _, folder := range dev.Sync.Folders {
 		validPath, err := os.Stat(folder.LocalPath)
 
@@ -1310,6 +1617,12 @@ func (service *Dev) validateForExtraFields() error {
 	if service.Lifecycle != nil {
 		return fmt.Errorf(errorMessage, "lifecycle")
 	}
+	if service.Debug != nil {
+		return fmt.Errorf(errorMessage, "debug")
+	}
+	if service.OnSync != nil {
+		return fmt.Errorf(errorMessage, "onSync")
+	}
 	if service.SecurityContext != nil {
 		return fmt.Errorf(errorMessage, "securityContext")
 	}
@@ -1394,6 +1707,23 @@ func (b *BuildInfo) Copy() *BuildInfo {
 	dependsOn = append(dependsOn, b.DependsOn...)
 	result.DependsOn = dependsOn
 
+	if b.Platforms != nil {
+		platforms := BuildPlatforms{}
+		platforms = append(platforms, b.Platforms...)
+		result.Platforms = platforms
+	}
+
+	if b.Attestations != nil {
+		attestations := *b.Attestations
+		result.Attestations = &attestations
+	}
+
+	if b.Outputs != nil {
+		outputs := BuildOutputs{}
+		outputs = append(outputs, b.Outputs...)
+		result.Outputs = outputs
+	}
+
 	return result
 }
 
@@ -1587,7 +1587,7 @@ func Test_BuildInfoCopy(t *testing.T) {
 			},
 		},
 		Secrets: BuildSecrets{
-			"sec": "test",
+			"sec": {File: "test"},
 		},
 		VolumesToInclude: []StackVolume{
 			{
@@ -1596,6 +1596,8 @@ func Test_BuildInfoCopy(t *testing.T) {
 			},
 		},
 		DependsOn: BuildDependsOn{"other"},
+		Platforms: BuildPlatforms{"linux/amd64", "linux/arm64"},
+		Outputs:   BuildOutputs{{Type: BuildOutputLocal, Dest: "bin"}},
 	}
 
 	copyB := b.Copy()
@@ -1800,3 +1802,150 @@ func TestPrepare(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSyncBackend(t *testing.T) {
+	tests := []struct {
+		name      string
+		sync      Sync
+		sshEnv    string
+		expectErr bool
+	}{
+		{
+			name: "default backend",
+		},
+		{
+			name: "syncthing backend",
+			sync: Sync{Backend: SyncBackendSyncthing, KeepConflicts: true},
+		},
+		{
+			name: "ssh backend",
+			sync: Sync{Backend: SyncBackendSSH},
+		},
+		{
+			name:      "ssh backend without ssh server",
+			sync:      Sync{Backend: SyncBackendSSH},
+			sshEnv:    "false",
+			expectErr: true,
+		},
+		{
+			name:      "ssh backend keeping conflicts",
+			sync:      Sync{Backend: SyncBackendSSH, KeepConflicts: true},
+			expectErr: true,
+		},
+		{
+			name:      "unknown backend",
+			sync:      Sync{Backend: "rsync"},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(OktetoExecuteSSHEnvVar, tt.sshEnv)
+			dev := &Dev{Sync: tt.sync}
+			err := dev.validateSyncBackend()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProvenanceMode(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    string
+		expectedErr bool
+	}{
+		{value: "", expected: ""},
+		{value: "false", expected: ""},
+		{value: "true", expected: "mode=min"},
+		{value: "mode=min", expected: "mode=min"},
+		{value: "mode=max", expected: "mode=max"},
+		{value: "max", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ProvenanceMode(tt.value)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedErr, err != nil)
+		})
+	}
+}
+
+func TestParseBuildOutput(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    BuildOutput
+		expectedErr bool
+	}{
+		{value: "type=local,dest=bin", expected: BuildOutput{Type: BuildOutputLocal, Dest: "bin"}},
+		{value: "type=tar,dest=out.tar", expected: BuildOutput{Type: BuildOutputTar, Dest: "out.tar"}},
+		{value: "dest=image.tar, type=oci", expected: BuildOutput{Type: BuildOutputOCI, Dest: "image.tar"}},
+		{value: "type=image,dest=bin", expectedErr: true},
+		{value: "type=local", expectedErr: true},
+		{value: "type=local,dest=bin,push=true", expectedErr: true},
+		{value: "local", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseBuildOutput(tt.value)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, fmt.Sprintf("type=%s,dest=%s", tt.expected.Type, tt.expected.Dest), result.String())
+		})
+	}
+}
+
+func TestValidateBuildOutputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expectedErr bool
+	}{
+		{name: "no outputs", values: nil},
+		{name: "one output", values: []string{"type=local,dest=bin"}},
+		{name: "invalid output", values: []string{"type=image,dest=bin"}, expectedErr: true},
+		{name: "several outputs", values: []string{"type=local,dest=bin", "type=tar,dest=out.tar"}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBuildOutputs(tt.values)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBuildSecretBuildkitSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   BuildSecret
+		expected string
+	}{
+		{name: "file", secret: BuildSecret{File: ".npmrc"}, expected: "id=mysecret,src=.npmrc"},
+		{name: "env", secret: BuildSecret{Env: "NPM_TOKEN"}, expected: "id=mysecret,env=NPM_TOKEN"},
+		{name: "okteto secret", secret: BuildSecret{OktetoSecret: "NPM_TOKEN"}, expected: "id=mysecret,okteto=NPM_TOKEN"},
+		{name: "command", secret: BuildSecret{Command: "gh auth token"}, expected: "id=mysecret,command=gh auth token"},
+		{name: "command with commas", secret: BuildSecret{Command: `printf "a,b"`}, expected: `id=mysecret,"command=printf ""a,b"""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.secret.BuildkitSecret("mysecret"))
+		})
+	}
+}
+
+func TestBuildSecretsValidate(t *testing.T) {
+	assert.NoError(t, BuildSecrets{"npmrc": {File: ".npmrc"}, "npm": {OktetoSecret: "NPM_TOKEN"}}.validate())
+	assert.Error(t, BuildSecrets{"npm": {}}.validate())
+	assert.Error(t, BuildSecrets{"npm": {Env: "NPM_TOKEN", Command: "echo token"}}.validate())
+}
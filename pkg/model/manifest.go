@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/a8m/envsubst"
 	"github.com/okteto/okteto/pkg/constants"
@@ -105,6 +106,8 @@ type Manifest struct {
 	Dependencies  deps.ManifestSection                     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
+	// BuildParallelism is the maximum number of services of the build section built at the same time
+	BuildParallelism int `json:"buildParallelism,omitempty" yaml:"buildParallelism,omitempty"`
 
 	Type     Archetype `json:"-" yaml:"-"`
 	Manifest []byte    `json:"-" yaml:"-"`
@@ -201,6 +204,7 @@ type DivertDeploy struct {
 	DeprecatedDeployment string                 `json:"deployment,omitempty" yaml:"deployment,omitempty"`
 	VirtualServices      []DivertVirtualService `json:"virtualServices,omitempty" yaml:"virtualServices,omitempty"`
 	Hosts                []DivertHost           `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+	HTTPRoutes           []DivertHTTPRoute      `json:"httpRoutes,omitempty" yaml:"httpRoutes,omitempty"`
 	DeprecatedPort       int                    `json:"port,omitempty" yaml:"port,omitempty"`
 }
 
@@ -217,6 +221,12 @@ type DivertHost struct {
 	Namespace      string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
+// DivertHTTPRoute represents a gateway API http route in a namespace to be diverted
+type DivertHTTPRoute struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
 // ComposeSectionInfo represents information about compose file
 type ComposeSectionInfo struct {
 	Stack        *Stack          `json:"-" yaml:"-"`
@@ -234,8 +244,33 @@ type ServicesToDeploy []string
 
 // DeployCommand represents a command to be executed
 type DeployCommand struct {
-	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
-	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	Name      string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Command   string        `json:"command,omitempty" yaml:"command,omitempty"`
+	When      string        `json:"when,omitempty" yaml:"when,omitempty"`
+	DependsOn []string      `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Timeout   time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries   int           `json:"retries,omitempty" yaml:"retries,omitempty"`
+	Parallel  bool          `json:"parallel,omitempty" yaml:"parallel,omitempty"`
+}
+
+// isSequential returns true if the command keeps the default behaviour of waiting for every previous command
+func (d DeployCommand) isSequential() bool {
+	return len(d.DependsOn) == 0 && !d.Parallel
+}
+
+// isExtended returns true if the command can't be represented only by its command line
+func (d DeployCommand) isExtended() bool {
+	return d.Command != d.Name || !d.isSequential() || d.When != "" || d.Timeout != 0 || d.Retries != 0
+}
+
+func (d DeployCommand) validate() error {
+	if d.Retries < 0 {
+		return fmt.Errorf("command '%s' has an invalid value for 'retries': it must be greater or equal than 0", d.Name)
+	}
+	if d.Timeout < 0 {
+		return fmt.Errorf("command '%s' has an invalid value for 'timeout': it must be greater or equal than 0", d.Name)
+	}
+	return nil
 }
 
 // NewDeployInfo creates a deploy Info
@@ -685,6 +720,19 @@ func (m *Manifest) validate() error {
 	if err := m.Build.validate(); err != nil {
 		return err
 	}
+	if m.BuildParallelism < 0 {
+		return fmt.Errorf("the field 'buildParallelism' must be greater than 0")
+	}
+	if m.Deploy != nil {
+		if err := m.Deploy.validate(); err != nil {
+			return err
+		}
+	}
+	if m.Destroy != nil {
+		if err := m.Destroy.validate(); err != nil {
+			return err
+		}
+	}
 	return m.validateDivert()
 }
 
@@ -696,6 +744,31 @@ func (b *ManifestBuild) validate() error {
 		svcsDependents := fmt.Sprintf("%s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
 		return fmt.Errorf("manifest validation failed: cyclic dependendecy found between %s", svcsDependents)
 	}
+	for name, buildInfo := range *b {
+		if buildInfo == nil {
+			continue
+		}
+		if err := buildInfo.Platforms.validate(); err != nil {
+			return fmt.Errorf("manifest build validation failed: image '%s': %w", name, err)
+		}
+		if buildInfo.Attestations != nil {
+			if _, err := ProvenanceMode(buildInfo.Attestations.Provenance); err != nil {
+				return fmt.Errorf("manifest build validation failed: image '%s': %w", name, err)
+			}
+		}
+		if err := buildInfo.Outputs.validate(); err != nil {
+			return fmt.Errorf("manifest build validation failed: image '%s': %w", name, err)
+		}
+		for _, dependency := range buildInfo.DependsOn {
+			// the images exported to outputs are not pushed, so they can't be used by other images
+			if dependencyInfo, ok := (*b)[dependency]; ok && dependencyInfo != nil && len(dependencyInfo.Outputs) > 0 {
+				return fmt.Errorf("manifest build validation failed: image '%s' depends on '%s', which is exported to build outputs", name, dependency)
+			}
+		}
+		if err := buildInfo.Secrets.validate(); err != nil {
+			return fmt.Errorf("manifest build validation failed: image '%s': %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -715,6 +788,99 @@ func (s *Secret) validate() error {
 	return nil
 }
 
+func (d *DeployInfo) validate() error {
+	for _, cmd := range d.Commands {
+		if err := cmd.validate(); err != nil {
+			return fmt.Errorf("manifest deploy validation failed: %w", err)
+		}
+	}
+
+	if !d.HasCommandDependencies() {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, cmd := range d.Commands {
+		if names[cmd.Name] {
+			return fmt.Errorf("manifest deploy validation failed: command name '%s' is duplicated. Command names must be unique when using 'depends_on' or 'parallel'", cmd.Name)
+		}
+		names[cmd.Name] = true
+	}
+	for _, cmd := range d.Commands {
+		for _, dependency := range cmd.DependsOn {
+			if !names[dependency] {
+				return fmt.Errorf("manifest deploy validation failed: command '%s' depends on '%s', which is not defined", cmd.Name, dependency)
+			}
+		}
+	}
+
+	cycle := getDependentCyclic(d.toGraph())
+	if len(cycle) == 1 { // depends on the same node
+		return fmt.Errorf("manifest deploy validation failed: command '%s' is referenced on its dependencies", cycle[0])
+	} else if len(cycle) > 1 {
+		cmdsDependents := fmt.Sprintf("%s and %s", strings.Join(cycle[:len(cycle)-1], ", "), cycle[len(cycle)-1])
+		return fmt.Errorf("manifest deploy validation failed: cyclic dependendecy found between %s", cmdsDependents)
+	}
+	return nil
+}
+
+func (d *DestroyInfo) validate() error {
+	for _, cmd := range d.Commands {
+		if err := cmd.validate(); err != nil {
+			return fmt.Errorf("manifest destroy validation failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// HasCommandDependencies returns true if any of the deploy commands uses 'depends_on' or 'parallel'
+func (d *DeployInfo) HasCommandDependencies() bool {
+	for _, cmd := range d.Commands {
+		if !cmd.isSequential() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCommandDependencies returns, for each deploy command, the indexes of the commands that must finish before it starts.
+// Commands without 'depends_on' or 'parallel' wait for every command declared before them, as they always did.
+func (d *DeployInfo) GetCommandDependencies() [][]int {
+	indexByName := make(map[string]int, len(d.Commands))
+	for i, cmd := range d.Commands {
+		indexByName[cmd.Name] = i
+	}
+
+	dependencies := make([][]int, len(d.Commands))
+	for i, cmd := range d.Commands {
+		dependencies[i] = []int{}
+		switch {
+		case len(cmd.DependsOn) > 0:
+			for _, dependency := range cmd.DependsOn {
+				dependencies[i] = append(dependencies[i], indexByName[dependency])
+			}
+		case cmd.Parallel:
+		default:
+			for j := 0; j < i; j++ {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+	return dependencies
+}
+
+func (d *DeployInfo) toGraph() graph {
+	g := graph{}
+	for i, deps := range d.GetCommandDependencies() {
+		names := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			names = append(names, d.Commands[dep].Name)
+		}
+		g[d.Commands[i].Name] = names
+	}
+	return g
+}
+
 // GetSvcsToBuildFromList returns the builds from a list and all its
 func (b *ManifestBuild) GetSvcsToBuildFromList(toBuild []string) []string {
 	initialSvcsToBuild := toBuild
@@ -797,6 +963,9 @@ func (m *Manifest) validateDivert() error {
 		if len(m.Deploy.Divert.Hosts) > 0 {
 			return fmt.Errorf("the field 'deploy.divert.host' is not supported with the weaver driver")
 		}
+		if len(m.Deploy.Divert.HTTPRoutes) > 0 {
+			return fmt.Errorf("the field 'deploy.divert.httpRoutes' is not supported with the weaver driver")
+		}
 	case constants.OktetoDivertIstioDriver:
 		if m.Deploy.Divert.DeprecatedService != "" {
 			return fmt.Errorf("the field 'deploy.divert.service' is not supported with the istio driver")
@@ -807,6 +976,9 @@ func (m *Manifest) validateDivert() error {
 		if len(m.Deploy.Divert.VirtualServices) == 0 {
 			return fmt.Errorf("the field 'deploy.divert.virtualServices' is mandatory")
 		}
+		if len(m.Deploy.Divert.HTTPRoutes) > 0 {
+			return fmt.Errorf("the field 'deploy.divert.httpRoutes' is not supported with the istio driver")
+		}
 		for i := range m.Deploy.Divert.VirtualServices {
 			if m.Deploy.Divert.VirtualServices[i].Name == "" {
 				return fmt.Errorf("the field 'deploy.divert.virtualServices[%d].name' is mandatory", i)
@@ -823,6 +995,30 @@ func (m *Manifest) validateDivert() error {
 				return fmt.Errorf("the field 'deploy.divert.hosts[%d].namespace' is mandatory", i)
 			}
 		}
+	case constants.OktetoDivertGatewayDriver:
+		if m.Deploy.Divert.DeprecatedService != "" {
+			return fmt.Errorf("the field 'deploy.divert.service' is not supported with the gateway driver")
+		}
+		if m.Deploy.Divert.Namespace != "" {
+			return fmt.Errorf("the field 'deploy.divert.namespace' is not supported with the gateway driver")
+		}
+		if len(m.Deploy.Divert.VirtualServices) > 0 {
+			return fmt.Errorf("the field 'deploy.divert.virtualServices' is not supported with the gateway driver")
+		}
+		if len(m.Deploy.Divert.Hosts) > 0 {
+			return fmt.Errorf("the field 'deploy.divert.hosts' is not supported with the gateway driver")
+		}
+		if len(m.Deploy.Divert.HTTPRoutes) == 0 {
+			return fmt.Errorf("the field 'deploy.divert.httpRoutes' is mandatory")
+		}
+		for i := range m.Deploy.Divert.HTTPRoutes {
+			if m.Deploy.Divert.HTTPRoutes[i].Name == "" {
+				return fmt.Errorf("the field 'deploy.divert.httpRoutes[%d].name' is mandatory", i)
+			}
+			if m.Deploy.Divert.HTTPRoutes[i].Namespace == "" {
+				return fmt.Errorf("the field 'deploy.divert.httpRoutes[%d].namespace' is mandatory", i)
+			}
+		}
 	default:
 		return fmt.Errorf("the divert driver '%s' isn't supported", m.Deploy.Divert.Driver)
 	}
@@ -849,6 +1045,16 @@ func (m *Manifest) setDefaults() error {
 				return err
 			}
 		}
+		for i := range m.Deploy.Divert.HTTPRoutes {
+			m.Deploy.Divert.HTTPRoutes[i].Name, err = env.ExpandEnvIfNotEmpty(m.Deploy.Divert.HTTPRoutes[i].Name)
+			if err != nil {
+				return err
+			}
+			m.Deploy.Divert.HTTPRoutes[i].Namespace, err = env.ExpandEnvIfNotEmpty(m.Deploy.Divert.HTTPRoutes[i].Namespace)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	for dName, d := range m.Dev {
 		if d.Name == "" {
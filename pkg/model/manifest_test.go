@@ -316,6 +316,47 @@ func Test_validateDivert(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("the field 'deploy.divert.namespace' is mandatory"),
 		},
+		{
+			name: "divert-gateway-ok",
+			divert: DivertDeploy{
+				Driver:     constants.OktetoDivertGatewayDriver,
+				HTTPRoutes: []DivertHTTPRoute{{Name: "frontend", Namespace: "staging"}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "divert-gateway-ko-without-http-routes",
+			divert: DivertDeploy{
+				Driver: constants.OktetoDivertGatewayDriver,
+			},
+			expectedErr: fmt.Errorf("the field 'deploy.divert.httpRoutes' is mandatory"),
+		},
+		{
+			name: "divert-gateway-ko-without-http-route-namespace",
+			divert: DivertDeploy{
+				Driver:     constants.OktetoDivertGatewayDriver,
+				HTTPRoutes: []DivertHTTPRoute{{Name: "frontend"}},
+			},
+			expectedErr: fmt.Errorf("the field 'deploy.divert.httpRoutes[0].namespace' is mandatory"),
+		},
+		{
+			name: "divert-gateway-ko-with-virtual-services",
+			divert: DivertDeploy{
+				Driver:          constants.OktetoDivertGatewayDriver,
+				HTTPRoutes:      []DivertHTTPRoute{{Name: "frontend", Namespace: "staging"}},
+				VirtualServices: []DivertVirtualService{{Name: "frontend", Namespace: "staging"}},
+			},
+			expectedErr: fmt.Errorf("the field 'deploy.divert.virtualServices' is not supported with the gateway driver"),
+		},
+		{
+			name: "divert-weaver-ko-with-http-routes",
+			divert: DivertDeploy{
+				Driver:     constants.OktetoDivertWeaverDriver,
+				Namespace:  "namespace",
+				HTTPRoutes: []DivertHTTPRoute{{Name: "frontend", Namespace: "staging"}},
+			},
+			expectedErr: fmt.Errorf("the field 'deploy.divert.httpRoutes' is not supported with the weaver driver"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -399,6 +440,90 @@ func Test_validateManifestBuild(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "valid platforms",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Platforms: BuildPlatforms{"linux/amd64", "linux/arm64/v8"},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "platform without arch",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Platforms: BuildPlatforms{"linux"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid provenance",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Attestations: &BuildAttestations{Provenance: "mode=full"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid attestations",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Attestations: &BuildAttestations{SBOM: true, Provenance: "mode=max"},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "valid outputs",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Outputs: BuildOutputs{{Type: BuildOutputOCI, Dest: "image.tar"}},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "several outputs",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Outputs: BuildOutputs{{Type: BuildOutputLocal, Dest: "bin"}, {Type: BuildOutputOCI, Dest: "image.tar"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "depends on an image exported to outputs",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Outputs: BuildOutputs{{Type: BuildOutputLocal, Dest: "bin"}},
+				},
+				"b": &BuildInfo{
+					DependsOn: BuildDependsOn{"a"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "output without dest",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Outputs: BuildOutputs{{Type: BuildOutputTar}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "platform with empty arch",
+			buildSection: ManifestBuild{
+				"a": &BuildInfo{
+					Platforms: BuildPlatforms{"linux/"},
+				},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -411,6 +536,116 @@ func Test_validateManifestBuild(t *testing.T) {
 	}
 }
 
+func Test_validateManifestDeployCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		commands    []DeployCommand
+		expectedErr bool
+	}{
+		{
+			name: "sequential commands with duplicated names",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a"},
+				{Name: "a", Command: "a"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "no cycle",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", Parallel: true},
+				{Name: "b", Command: "b", Parallel: true},
+				{Name: "c", Command: "c", DependsOn: []string{"a", "b"}},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "duplicated names",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", Parallel: true},
+				{Name: "a", Command: "b", Parallel: true},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "undefined dependency",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", DependsOn: []string{"b"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "cycle - same node dependency",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", DependsOn: []string{"a"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "negative retries",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", Retries: -1},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "negative timeout",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", Timeout: -time.Second},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "cycle - direct cycle",
+			commands: []DeployCommand{
+				{Name: "a", Command: "a", DependsOn: []string{"b"}},
+				{Name: "b", Command: "b", DependsOn: []string{"a"}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{
+				Deploy: &DeployInfo{
+					Commands: tt.commands,
+				},
+			}
+			assert.Equal(t, tt.expectedErr, m.validate() != nil)
+		})
+	}
+}
+
+func Test_validateManifestDestroyCommands(t *testing.T) {
+	m := &Manifest{
+		Destroy: &DestroyInfo{
+			Commands: []DeployCommand{
+				{Name: "a", Command: "a", Retries: -1},
+			},
+		},
+	}
+	assert.Error(t, m.validate())
+}
+
+func TestGetCommandDependencies(t *testing.T) {
+	deploy := &DeployInfo{
+		Commands: []DeployCommand{
+			{Name: "a", Command: "a"},
+			{Name: "b", Command: "b", Parallel: true},
+			{Name: "c", Command: "c", DependsOn: []string{"a"}},
+			{Name: "d", Command: "d"},
+		},
+	}
+	expected := [][]int{
+		{},
+		{},
+		{0},
+		{0, 1, 2},
+	}
+	assert.Equal(t, expected, deploy.GetCommandDependencies())
+}
+
 func TestInferFromStack(t *testing.T) {
 	dirtest := filepath.Clean("/stack/dir/")
 	devInterface := Localhost
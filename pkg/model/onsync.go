@@ -0,0 +1,101 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultOnSyncDebounce is the time to wait for more changes before running an 'onSync' command
+	defaultOnSyncDebounce = 1 * time.Second
+)
+
+// OnSyncRule runs a command in the development container when the synchronized files matching a glob change
+type OnSyncRule struct {
+	matcher *regexp.Regexp
+	// Match is a glob relative to the synchronized folder. '*' doesn't match '/' and '**' matches any number of folders
+	Match    string        `json:"match,omitempty" yaml:"match,omitempty"`
+	Command  string        `json:"command,omitempty" yaml:"command,omitempty"`
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+}
+
+// Matches returns if a file, given by its slash separated path relative to the synchronized folder, matches the rule
+func (r *OnSyncRule) Matches(path string) bool {
+	if r.matcher == nil {
+		matcher, err := onSyncGlobToRegexp(r.Match)
+		if err != nil {
+			return false
+		}
+		r.matcher = matcher
+	}
+	return r.matcher.MatchString(path)
+}
+
+func (r *OnSyncRule) validate() error {
+	if r.Match == "" {
+		return fmt.Errorf("'onSync.match' is required")
+	}
+	if _, err := onSyncGlobToRegexp(r.Match); err != nil {
+		return fmt.Errorf("'onSync.match' is not a valid glob '%s': %w", r.Match, err)
+	}
+	if strings.TrimSpace(r.Command) == "" {
+		return fmt.Errorf("'onSync.command' is required for the glob '%s'", r.Match)
+	}
+	if r.Debounce < 0 {
+		return fmt.Errorf("'onSync.debounce' must be a positive duration for the glob '%s'", r.Match)
+	}
+	return nil
+}
+
+func (dev *Dev) validateOnSync() error {
+	for i := range dev.OnSync {
+		if err := dev.OnSync[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onSyncGlobToRegexp translates a glob where '*' and '?' don't match '/', '**/' matches zero or more folders and '**' matches anything
+func onSyncGlobToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				sb.WriteString("(.*/)?")
+				i += 2
+				continue
+			}
+			if strings.HasPrefix(glob[i:], "**") {
+				sb.WriteString(".*")
+				i++
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
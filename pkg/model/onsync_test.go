@@ -0,0 +1,111 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnSyncRuleMatches(t *testing.T) {
+	tests := []struct {
+		match    string
+		path     string
+		expected bool
+	}{
+		{match: "**/*.go", path: "main.go", expected: true},
+		{match: "**/*.go", path: "pkg/api/handler.go", expected: true},
+		{match: "**/*.go", path: "main.go.orig", expected: false},
+		{match: "package.json", path: "package.json", expected: true},
+		{match: "package.json", path: "web/package.json", expected: false},
+		{match: "src/*.js", path: "src/index.js", expected: true},
+		{match: "src/*.js", path: "src/lib/index.js", expected: false},
+		{match: "src/**", path: "src/lib/index.js", expected: true},
+		{match: "config/app.?ml", path: "config/app.yml", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.match+"-"+tt.path, func(t *testing.T) {
+			rule := &OnSyncRule{Match: tt.match, Command: "make"}
+			assert.Equal(t, tt.expected, rule.Matches(tt.path))
+		})
+	}
+}
+
+func TestOnSyncRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    OnSyncRule
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			rule: OnSyncRule{Match: "**/*.go", Command: "go build"},
+		},
+		{
+			name:    "missing-match",
+			rule:    OnSyncRule{Command: "go build"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-command",
+			rule:    OnSyncRule{Match: "**/*.go", Command: " "},
+			wantErr: true,
+		},
+		{
+			name:    "negative-debounce",
+			rule:    OnSyncRule{Match: "**/*.go", Command: "go build", Debounce: -time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadManifestWithOnSync(t *testing.T) {
+	manifest := []byte(`dev:
+  api:
+    command: bash
+    sync:
+      - .:/app
+    onSync:
+      - match: "**/*.go"
+        command: go build && kill -HUP 1
+      - match: package.json
+        command: npm install
+        debounce: 5s`)
+
+	m, err := Read(manifest)
+	require.NoError(t, err)
+
+	dev := m.Dev["api"]
+	require.Len(t, dev.OnSync, 2)
+	assert.Equal(t, "**/*.go", dev.OnSync[0].Match)
+	assert.Equal(t, "go build && kill -HUP 1", dev.OnSync[0].Command)
+	assert.Equal(t, defaultOnSyncDebounce, dev.OnSync[0].Debounce)
+	assert.Equal(t, "npm install", dev.OnSync[1].Command)
+	assert.Equal(t, 5*time.Second, dev.OnSync[1].Debounce)
+}
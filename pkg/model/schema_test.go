@@ -186,22 +186,28 @@ func Test_getStructKeys(t *testing.T) {
 				"env.Var":                    {"name", "value"},
 				"forward.Forward":            {"labels", "name", "localPort", "remotePort"},
 				"forward.GlobalForward":      {"labels", "name", "localPort", "remotePort"},
-				"model.BuildInfo":            {"secrets", "name", "context", "dockerfile", "target", "image", "cache_from", "export_cache", "depends_on"},
+				"model.BuildAttestations":    {"provenance", "sbom"},
+				"model.BuildInfo":            {"secrets", "name", "context", "dockerfile", "target", "image", "cache_from", "export_cache", "depends_on", "platforms"},
+				"model.BuildOutput":          {"type", "dest"},
 				"model.Capabilities":         {"add", "drop"},
 				"model.ComposeInfo":          {"file", "services"},
-				"model.DeployCommand":        {"name", "command"},
+				"model.Debug":                {"language", "port"},
+				"model.DeployCommand":        {"name", "command", "when", "depends_on", "timeout", "retries", "parallel"},
 				"model.DeployInfo":           {"endpoints", "image", "remote"},
 				"model.DestroyInfo":          {"image", "remote"},
-				"model.Dev":                  {"selector", "annotations", "labels", "nodeSelector", "replicas", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "imagePullPolicy", "envFiles", "services", "remote", "sshServerPort", "initFromImage", "autocreate", "healthchecks"},
+				"model.Dev":                  {"selector", "annotations", "labels", "nodeSelector", "containers", "replicas", "workdir", "name", "context", "namespace", "container", "serviceAccount", "interface", "mode", "imagePullPolicy", "envFiles", "services", "remote", "sshServerPort", "initFromImage", "autocreate", "healthchecks"},
+				"model.DevContainer":         {"workdir"},
 				"model.DivertDeploy":         {"driver", "namespace", "service", "deployment", "port"},
+				"model.DivertHTTPRoute":      {"name", "namespace"},
 				"model.DivertHost":           {"virtualService", "namespace"},
 				"model.DivertVirtualService": {"name", "namespace", "routes"},
 				"model.HTTPHealtcheck":       {"path", "port"},
 				"model.HealthCheck":          {"test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
 				"model.InitContainer":        {"image"},
 				"model.Lifecycle":            {"postStart", "postStop"},
-				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external"},
+				"model.Manifest":             {"name", "namespace", "context", "icon", "dev", "build", "dependencies", "external", "buildParallelism"},
 				"model.Metadata":             {"labels", "annotations"},
+				"model.OnSyncRule":           {"match", "command", "debounce"},
 				"model.PersistentVolumeInfo": {"storageClass", "size", "enabled"},
 				"model.Probes":               {"liveness", "readiness", "startup"},
 				"model.ResourceRequirements": {"limits", "requests"},
@@ -210,7 +216,7 @@ func Test_getStructKeys(t *testing.T) {
 				"model.Stack":                {"volumes", "services", "endpoints", "name", "namespace", "context"},
 				"model.StackSecurityContext": {"runAsUser", "runAsGroup"},
 				"model.StorageResource":      {"class"},
-				"model.Sync":                 {"rescanInterval", "compression", "verbose"},
+				"model.Sync":                 {"rescanInterval", "compression", "verbose", "keepConflicts", "backend"},
 				"model.Timeout":              {"default", "resources"},
 				"model.VolumeSpec":           {"labels", "annotations", "class"},
 			},
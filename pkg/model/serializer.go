@@ -53,18 +53,25 @@ var (
 
 // BuildInfoRaw represents the build info for serialization
 type buildInfoRaw struct {
-	Secrets          BuildSecrets      `yaml:"secrets,omitempty"`
-	Name             string            `yaml:"name,omitempty"`
-	Context          string            `yaml:"context,omitempty"`
-	Dockerfile       string            `yaml:"dockerfile,omitempty"`
-	Target           string            `yaml:"target,omitempty"`
-	Image            string            `yaml:"image,omitempty"`
-	CacheFrom        cache.CacheFrom   `yaml:"cache_from,omitempty"`
-	Args             BuildArgs         `yaml:"args,omitempty"`
-	VolumesToInclude []StackVolume     `yaml:"-"`
-	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
-	DependsOn        BuildDependsOn    `yaml:"depends_on,omitempty"`
-}
+	Secrets          BuildSecrets       `yaml:"secrets,omitempty"`
+	Name             string             `yaml:"name,omitempty"`
+	Context          string             `yaml:"context,omitempty"`
+	Dockerfile       string             `yaml:"dockerfile,omitempty"`
+	Target           string             `yaml:"target,omitempty"`
+	Image            string             `yaml:"image,omitempty"`
+	CacheFrom        cache.CacheFrom    `yaml:"cache_from,omitempty"`
+	Args             BuildArgs          `yaml:"args,omitempty"`
+	VolumesToInclude []StackVolume      `yaml:"-"`
+	ExportCache      cache.ExportCache  `yaml:"export_cache,omitempty"`
+	DependsOn        BuildDependsOn     `yaml:"depends_on,omitempty"`
+	Platforms        BuildPlatforms     `yaml:"platforms,omitempty"`
+	Attestations     *BuildAttestations `yaml:"attestations,omitempty"`
+	Outputs          BuildOutputs       `yaml:"outputs,omitempty"`
+}
+
+type buildOutputRaw BuildOutput
+
+type buildSecretRaw BuildSecret
 
 type syncRaw struct {
 	LocalPath      string
@@ -73,6 +80,14 @@ type syncRaw struct {
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
+	KeepConflicts  bool         `json:"keepConflicts,omitempty" yaml:"keepConflicts,omitempty"`
+	Backend        string       `json:"backend,omitempty" yaml:"backend,omitempty"`
+}
+
+type syncFolderRaw struct {
+	LocalPath  string `yaml:"localPath"`
+	RemotePath string `yaml:"remotePath"`
+	Mode       string `yaml:"mode,omitempty"`
 }
 
 type storageResourceRaw struct {
@@ -292,12 +307,14 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
 	sync.Folders = rawSync.Folders
+	sync.KeepConflicts = rawSync.KeepConflicts
+	sync.Backend = rawSync.Backend
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && !sync.KeepConflicts && sync.Backend == "" {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -346,6 +363,9 @@ func (buildInfo *BuildInfo) UnmarshalYAML(unmarshal func(interface{}) error) err
 	buildInfo.ExportCache = rawBuildInfo.ExportCache
 	buildInfo.DependsOn = rawBuildInfo.DependsOn
 	buildInfo.Secrets = rawBuildInfo.Secrets
+	buildInfo.Platforms = rawBuildInfo.Platforms
+	buildInfo.Attestations = rawBuildInfo.Attestations
+	buildInfo.Outputs = rawBuildInfo.Outputs
 	return nil
 }
 
@@ -363,9 +383,64 @@ func (buildInfo *BuildInfo) MarshalYAML() (interface{}, error) {
 	if buildInfo.Args != nil && len(buildInfo.Args) != 0 {
 		return buildInfoRaw(*buildInfo), nil
 	}
+	if len(buildInfo.Platforms) != 0 {
+		return buildInfoRaw(*buildInfo), nil
+	}
+	if buildInfo.Attestations != nil {
+		return buildInfoRaw(*buildInfo), nil
+	}
+	if len(buildInfo.Outputs) != 0 {
+		return buildInfoRaw(*buildInfo), nil
+	}
 	return buildInfo.Name, nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (o *BuildOutput) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawString string
+	err := unmarshal(&rawString)
+	if err == nil {
+		output, err := ParseBuildOutput(rawString)
+		if err != nil {
+			return err
+		}
+		*o = output
+		return nil
+	}
+
+	var rawOutput buildOutputRaw
+	if err := unmarshal(&rawOutput); err != nil {
+		return err
+	}
+	*o = BuildOutput(rawOutput)
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (s *BuildSecret) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawString string
+	err := unmarshal(&rawString)
+	if err == nil {
+		*s = BuildSecret{File: rawString}
+		return nil
+	}
+
+	var rawSecret buildSecretRaw
+	if err := unmarshal(&rawSecret); err != nil {
+		return err
+	}
+	*s = BuildSecret(rawSecret)
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (s BuildSecret) MarshalYAML() (interface{}, error) {
+	if s.Env == "" && s.OktetoSecret == "" && s.Command == "" {
+		return s.File, nil
+	}
+	return buildSecretRaw(s), nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (s *StorageResource) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var rawQuantity Quantity
@@ -567,7 +642,11 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawFolder syncFolderRaw
+		if err := unmarshal(&rawFolder); err != nil {
+			return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath' or define the fields 'localPath', 'remotePath' and 'mode'")
+		}
+		return s.setFromRaw(rawFolder)
 	}
 
 	windowsSyncFolderParts := 3
@@ -600,17 +679,41 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath'")
 }
 
-// MarshalYAML Implements the marshaler interface of the yaml pkg.
-func (s SyncFolder) MarshalYAML() (interface{}, error) {
-	cwd, err := os.Getwd()
+func (s *SyncFolder) setFromRaw(raw syncFolderRaw) error {
+	if raw.LocalPath == "" || raw.RemotePath == "" {
+		return fmt.Errorf("the fields 'localPath' and 'remotePath' are mandatory in each element of the 'sync' field")
+	}
+	switch raw.Mode {
+	case "", SyncModeSend, SyncModeReceive, SyncModeBoth:
+	default:
+		return fmt.Errorf("invalid sync mode '%s': must be one of '%s', '%s' or '%s'", raw.Mode, SyncModeSend, SyncModeReceive, SyncModeBoth)
+	}
+
+	var err error
+	s.LocalPath, err = env.ExpandEnv(raw.LocalPath)
 	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+		return err
 	}
-	relPath, err := filepath.Rel(cwd, s.LocalPath)
+	s.RemotePath, err = env.ExpandEnv(raw.RemotePath)
 	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+		return err
+	}
+	s.Mode = raw.Mode
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (s SyncFolder) MarshalYAML() (interface{}, error) {
+	localPath := s.LocalPath
+	if cwd, err := os.Getwd(); err == nil {
+		if relPath, err := filepath.Rel(cwd, s.LocalPath); err == nil {
+			localPath = relPath
+		}
+	}
+	if s.Mode == "" || s.Mode == SyncModeBoth {
+		return localPath + ":" + s.RemotePath, nil
 	}
-	return relPath + ":" + s.RemotePath, nil
+	return syncFolderRaw{LocalPath: localPath, RemotePath: s.RemotePath, Mode: s.Mode}, nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -861,6 +964,8 @@ type manifestRaw struct {
 	GlobalForward []forward.GlobalForward                  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	External      externalresource.ExternalResourceSection `json:"external,omitempty" yaml:"external,omitempty"`
 
+	BuildParallelism int `json:"buildParallelism,omitempty" yaml:"buildParallelism,omitempty"`
+
 	DeprecatedDevs []string `yaml:"devs"`
 }
 
@@ -897,6 +1002,7 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Name = manifest.Name
 	m.GlobalForward = manifest.GlobalForward
 	m.External = manifest.External
+	m.BuildParallelism = manifest.BuildParallelism
 
 	err = m.SanitizeSvcNames()
 	if err != nil {
@@ -932,7 +1038,7 @@ func (d *DeployInfo) MarshalYAML() (interface{}, error) {
 	}
 	isCommandList := true
 	for _, cmd := range d.Commands {
-		if cmd.Command != cmd.Name {
+		if cmd.isExtended() {
 			isCommandList = false
 		}
 	}
@@ -1102,7 +1208,7 @@ func (d *ManifestDevs) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func isManifestFieldNotFound(err error) bool {
-	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies"}
+	manifestFields := []string{"devs", "dev", "name", "icon", "variables", "deploy", "destroy", "build", "namespace", "context", "dependencies", "buildParallelism"}
 	for _, field := range manifestFields {
 		if strings.Contains(err.Error(), fmt.Sprintf("field %s not found", field)) {
 			return true
@@ -1144,7 +1250,7 @@ func (d *DestroyInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
 func (d *DestroyInfo) MarshalYAML() (interface{}, error) {
 	isCommandList := true
 	for _, cmd := range d.Commands {
-		if cmd.Command != cmd.Name {
+		if cmd.isExtended() {
 			isCommandList = false
 		}
 	}
@@ -16,6 +16,7 @@ package model
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -308,6 +309,26 @@ func TestImageMarshalling(t *testing.T) {
 			image:    &BuildInfo{Name: "image-name", Context: "path"},
 			expected: "name: image-name\ncontext: path\n",
 		},
+		{
+			name:     "attestations",
+			image:    &BuildInfo{Name: "image-name", Attestations: &BuildAttestations{SBOM: true, Provenance: "mode=max"}},
+			expected: "name: image-name\nattestations:\n  provenance: mode=max\n  sbom: true\n",
+		},
+		{
+			name:     "platforms",
+			image:    &BuildInfo{Name: "image-name", Platforms: BuildPlatforms{"linux/amd64", "linux/arm64"}},
+			expected: "name: image-name\nplatforms:\n- linux/amd64\n- linux/arm64\n",
+		},
+		{
+			name:     "outputs",
+			image:    &BuildInfo{Name: "image-name", Outputs: BuildOutputs{{Type: BuildOutputLocal, Dest: "bin"}}},
+			expected: "name: image-name\noutputs:\n- type: local\n  dest: bin\n",
+		},
+		{
+			name:     "secrets",
+			image:    &BuildInfo{Name: "image-name", Context: "path", Secrets: BuildSecrets{"npm": {OktetoSecret: "NPM_TOKEN"}, "npmrc": {File: ".npmrc"}}},
+			expected: "secrets:\n  npm:\n    oktetoSecret: NPM_TOKEN\n  npmrc: .npmrc\nname: image-name\ncontext: path\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -959,6 +980,20 @@ rescanInterval: 10`),
 				RescanInterval: 10,
 			},
 		},
+		{
+			name: "ssh backend",
+			data: []byte(`folders:
+  - .:/usr/src/app
+backend: ssh`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+				Backend: SyncBackendSSH,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1008,6 +1043,19 @@ func TestSyncFoldersUnmarshalling(t *testing.T) {
 			data:     []byte(`C:/Users/src/test:/usr/src/app`),
 			expected: SyncFolder{LocalPath: "C:/Users/src/test", RemotePath: "/usr/src/app"},
 		},
+		{
+			name: "object with mode",
+			data: []byte(`localPath: dist
+remotePath: ${REMOTE_PATH}/dist
+mode: receive`),
+			expected: SyncFolder{LocalPath: "dist", RemotePath: "/usr/src/app/dist", Mode: SyncModeReceive},
+		},
+		{
+			name: "object without mode",
+			data: []byte(`localPath: .
+remotePath: /usr/src/app`),
+			expected: SyncFolder{LocalPath: ".", RemotePath: "/usr/src/app"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1025,6 +1073,70 @@ func TestSyncFoldersUnmarshalling(t *testing.T) {
 	}
 }
 
+func TestSyncFoldersUnmarshallingErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "invalid mode",
+			data: []byte(`localPath: .
+remotePath: /usr/src/app
+mode: push`),
+		},
+		{
+			name: "missing remote path",
+			data: []byte(`localPath: .
+mode: send`),
+		},
+		{
+			name: "invalid syntax",
+			data: []byte(`.:/usr/src/app:/other:/path`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SyncFolder{}
+			assert.Error(t, yaml.UnmarshalStrict(tt.data, &result))
+		})
+	}
+}
+
+func TestSyncFoldersMarshalling(t *testing.T) {
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	tests := []struct {
+		name     string
+		folder   SyncFolder
+		expected string
+	}{
+		{
+			name:     "without mode",
+			folder:   SyncFolder{LocalPath: filepath.Join(cwd, "src"), RemotePath: "/usr/src/app"},
+			expected: "src:/usr/src/app\n",
+		},
+		{
+			name:     "both",
+			folder:   SyncFolder{LocalPath: filepath.Join(cwd, "src"), RemotePath: "/usr/src/app", Mode: SyncModeBoth},
+			expected: "src:/usr/src/app\n",
+		},
+		{
+			name:     "send",
+			folder:   SyncFolder{LocalPath: filepath.Join(cwd, "src"), RemotePath: "/usr/src/app", Mode: SyncModeSend},
+			expected: "localPath: src\nremotePath: /usr/src/app\nmode: send\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := yaml.Marshal(tt.folder)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
 func TestManifestUnmarshalling(t *testing.T) {
 	tests := []struct {
 		expected        *Manifest
@@ -1071,6 +1183,32 @@ deploy:
 			},
 			isErrorExpected: false,
 		},
+		{
+			name: "manifest with build parallelism",
+			manifest: []byte(`
+buildParallelism: 4
+deploy:
+  - okteto stack deploy`),
+			expected: &Manifest{
+				BuildParallelism: 4,
+				Build:            map[string]*BuildInfo{},
+				Deploy: &DeployInfo{
+					Commands: []DeployCommand{
+						{
+							Name:    "okteto stack deploy",
+							Command: "okteto stack deploy",
+						},
+					},
+				},
+				Destroy:      &DestroyInfo{},
+				Dev:          map[string]*Dev{},
+				Dependencies: map[string]*deps.Dependency{},
+				External:     externalresource.ExternalResourceSection{},
+				IsV2:         true,
+				Type:         OktetoManifestType,
+			},
+			isErrorExpected: false,
+		},
 		{
 			name: "dev manifest with dev sanitized and deploy",
 			manifest: []byte(`
@@ -2223,6 +2361,17 @@ func TestDestroyInfoMarshalling(t *testing.T) {
 			}},
 			expected: "commands:\n- name: build\n  command: okteto build\n- name: deploy\n  command: okteto deploy\n",
 		},
+		{
+			name: "same-name-and-cmd-with-timeout",
+			destroyInfo: &DestroyInfo{Commands: []DeployCommand{
+				{
+					Name:    "okteto deploy",
+					Command: "okteto deploy",
+					Timeout: time.Minute,
+				},
+			}},
+			expected: "commands:\n- name: okteto deploy\n  command: okteto deploy\n  timeout: 1m0s\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2371,6 +2520,59 @@ func TestDeployInfoUnmarshalling(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "list of commands with dependencies",
+			deployInfoManifest: []byte(`
+- name: api
+  command: helm upgrade --install api chart
+  parallel: true
+- name: frontend
+  command: helm upgrade --install frontend chart
+  parallel: true
+- name: migrations
+  command: kubectl apply -f job.yml
+  depends_on:
+  - api`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{
+					{
+						Name:     "api",
+						Command:  "helm upgrade --install api chart",
+						Parallel: true,
+					},
+					{
+						Name:     "frontend",
+						Command:  "helm upgrade --install frontend chart",
+						Parallel: true,
+					},
+					{
+						Name:      "migrations",
+						Command:   "kubectl apply -f job.yml",
+						DependsOn: []string{"api"},
+					},
+				},
+			},
+		},
+		{
+			name: "list of commands with retries, timeout and condition",
+			deployInfoManifest: []byte(`
+- name: upgrade
+  command: helm upgrade --install api chart
+  when: $DEPLOY_API == true
+  retries: 3
+  timeout: 5m`),
+			expected: &DeployInfo{
+				Commands: []DeployCommand{
+					{
+						Name:    "upgrade",
+						Command: "helm upgrade --install api chart",
+						When:    "$DEPLOY_API == true",
+						Retries: 3,
+						Timeout: 5 * time.Minute,
+					},
+				},
+			},
+		},
 		{
 			name: "commands",
 			deployInfoManifest: []byte(`commands:
@@ -2469,6 +2671,17 @@ func TestDeployInfoMarshalling(t *testing.T) {
 			}},
 			expected: "commands:\n- name: build\n  command: okteto build\n- name: deploy\n  command: okteto deploy\n",
 		},
+		{
+			name: "same-name-and-cmd-with-retries",
+			deployInfo: &DeployInfo{Commands: []DeployCommand{
+				{
+					Name:    "okteto deploy",
+					Command: "okteto deploy",
+					Retries: 2,
+				},
+			}},
+			expected: "commands:\n- name: okteto deploy\n  command: okteto deploy\n  retries: 2\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2696,8 +2909,8 @@ func TestManifestBuildUnmarshalling(t *testing.T) {
 					},
 					CacheFrom: []string{"cache-image"},
 					Secrets: BuildSecrets{
-						"mysecret":    "source",
-						"othersecret": "othersource",
+						"mysecret":    {File: "source"},
+						"othersecret": {File: "othersource"},
 					},
 				},
 			},
@@ -2767,6 +2980,96 @@ func TestBuildDependsOnUnmarshalling(t *testing.T) {
 	}
 }
 
+func TestBuildOutputsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name          string
+		buildManifest []byte
+		expected      BuildOutputs
+		expectedErr   bool
+	}{
+		{
+			name: "string",
+			buildManifest: []byte(`- type=local,dest=bin
+- type=oci,dest=image.tar`),
+			expected: BuildOutputs{{Type: BuildOutputLocal, Dest: "bin"}, {Type: BuildOutputOCI, Dest: "image.tar"}},
+		},
+		{
+			name: "object",
+			buildManifest: []byte(`- type: tar
+  dest: out.tar`),
+			expected: BuildOutputs{{Type: BuildOutputTar, Dest: "out.tar"}},
+		},
+		{
+			name:          "invalid string",
+			buildManifest: []byte(`- type=image`),
+			expectedErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result BuildOutputs
+			err := yaml.UnmarshalStrict(tt.buildManifest, &result)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildSecretsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name          string
+		buildManifest []byte
+		expected      BuildSecrets
+		expectedErr   bool
+	}{
+		{
+			name:          "file",
+			buildManifest: []byte(`npmrc: .npmrc`),
+			expected:      BuildSecrets{"npmrc": {File: ".npmrc"}},
+		},
+		{
+			name: "sources",
+			buildManifest: []byte(`npmrc:
+  file: .npmrc
+npm:
+  oktetoSecret: NPM_TOKEN
+aws:
+  env: AWS_SECRET_ACCESS_KEY
+gh:
+  command: gh auth token`),
+			expected: BuildSecrets{
+				"npmrc": {File: ".npmrc"},
+				"npm":   {OktetoSecret: "NPM_TOKEN"},
+				"aws":   {Env: "AWS_SECRET_ACCESS_KEY"},
+				"gh":    {Command: "gh auth token"},
+			},
+		},
+		{
+			name:          "unknown source",
+			buildManifest: []byte(`npm: {vault: NPM_TOKEN}`),
+			expectedErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result BuildSecrets
+			err := yaml.UnmarshalStrict(tt.buildManifest, &result)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestBuildArgsUnmarshalling(t *testing.T) {
 	tests := []struct {
 		env      map[string]string
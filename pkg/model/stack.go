@@ -430,7 +430,7 @@ func (svc *Service) ToDev(svcName string) (*Dev, error) {
 	}
 	for _, v := range svc.VolumeMounts {
 		if pathExistsAndDir(v.LocalPath) {
-			d.Sync.Folders = append(d.Sync.Folders, SyncFolder(v))
+			d.Sync.Folders = append(d.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 		}
 	}
 	d.Command = svc.Command
@@ -234,6 +234,7 @@ type composeBuildInfo struct {
 	Image            string            `yaml:"image,omitempty"`
 	VolumesToInclude []StackVolume     `yaml:"-"`
 	ExportCache      cache.ExportCache `yaml:"export_cache,omitempty"`
+	Platforms        BuildPlatforms    `yaml:"platforms,omitempty"`
 }
 
 func (c *composeBuildInfo) toBuildInfo() *BuildInfo {
@@ -250,6 +251,7 @@ func (c *composeBuildInfo) toBuildInfo() *BuildInfo {
 		Image:            c.Image,
 		VolumesToInclude: c.VolumesToInclude,
 		ExportCache:      c.ExportCache,
+		Platforms:        c.Platforms,
 	}
 }
 
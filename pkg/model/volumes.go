@@ -69,7 +69,7 @@ func (dev *Dev) translateDeprecatedVolumes() {
 			volumes = append(volumes, v)
 			continue
 		}
-		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder(v))
+		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 	}
 	dev.Volumes = volumes
 }
@@ -225,6 +225,9 @@ func (dev *Dev) validatePersistentVolume() error {
 	if len(dev.Services) > 0 {
 		return fmt.Errorf("'persistentVolume.enabled' must be set to true to work with services")
 	}
+	if len(dev.Containers) > 0 {
+		return fmt.Errorf("'persistentVolume.enabled' must be set to true to work with containers")
+	}
 	if len(dev.Volumes) > 0 {
 		return fmt.Errorf("'persistentVolume.enabled' must be set to true to use volumes")
 	}
@@ -295,8 +298,30 @@ func (dev *Dev) validateDuplicatedSyncFolders() error {
 	return nil
 }
 
+func (dev *Dev) validateSyncFolderModes() error {
+	for _, sync := range dev.Sync.Folders {
+		if sync.GetMode() == SyncModeBoth {
+			continue
+		}
+		result, err := dev.IsSubPathFolder(sync.LocalPath)
+		if err != nil {
+			return err
+		}
+		if result {
+			return fmt.Errorf("sync folder '%s:%s' can't define 'mode': it is synchronized as part of its parent folder", sync.LocalPath, sync.RemotePath)
+		}
+	}
+	return nil
+}
+
 func (dev *Dev) validateServiceSyncFolders(main *Dev) error {
+	if dev.Sync.Backend != "" {
+		return fmt.Errorf("'backend' is not supported in the 'sync' field of 'services': the files are synchronized by the main development container")
+	}
 	for _, sync := range dev.Sync.Folders {
+		if sync.GetMode() != SyncModeBoth {
+			return fmt.Errorf("'mode' is not supported in the 'sync' field of 'services': sync folder '%s:%s' is synchronized by the main development container", sync.LocalPath, sync.RemotePath)
+		}
 		_, err := main.IsSubPathFolder(sync.LocalPath)
 		if err != nil {
 			if err == oktetoErrors.ErrNotFound {
@@ -325,6 +350,10 @@ func (dev *Dev) validateVolumes(main *Dev) error {
 		return err
 	}
 
+	if err := dev.validateSyncFolderModes(); err != nil {
+		return err
+	}
+
 	if main == nil {
 		return nil
 	}
@@ -797,6 +797,71 @@ func Test_validateVolumes(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sync-mode",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/src",
+						},
+						{
+							LocalPath:  "/vendor",
+							RemotePath: "/vendor",
+							Mode:       SyncModeSend,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sync-mode-in-subpath-folder",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/src",
+						},
+						{
+							LocalPath:  "/src/dist",
+							RemotePath: "/dist",
+							Mode:       SyncModeReceive,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sync-mode-in-service",
+			dev: &Dev{
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{
+							LocalPath:  "/src",
+							RemotePath: "/src",
+						},
+					},
+				},
+				Services: []*Dev{
+					{
+						Sync: Sync{
+							Folders: []SyncFolder{
+								{
+									LocalPath:  "/src",
+									RemotePath: "/src",
+									Mode:       SyncModeSend,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,6 +18,10 @@ const (
 	Deployment = "Deployment"
 	// StatefulSet k8s statefulset kind
 	StatefulSet = "StatefulSet"
+	// DaemonSet k8s daemonset kind
+	DaemonSet = "DaemonSet"
+	// Rollout argo rollout kind
+	Rollout = "Rollout"
 	// Job k8s Job kind
 	Job = "job"
 	// CronJob k8s CronJob kind
@@ -14,6 +14,7 @@
 package registry
 
 import (
+	"context"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -35,6 +36,10 @@ type clientInterface interface {
 	HasPushAccess(image string) (bool, error)
 	GetDescriptor(image string) (*remote.Descriptor, error)
 	Write(ref name.Reference, image v1.Image) error
+	ListTags(repository string) ([]string, error)
+	ListRepositories(registry string) ([]string, error)
+	Delete(image string) error
+	WriteIndex(ref name.Reference, index v1.ImageIndex) error
 }
 
 type ClientConfigInterface interface {
@@ -69,18 +74,26 @@ func (oh oktetoHelper) Get(_ string) (string, string, error) {
 
 // client operates with the registry API
 type client struct {
-	config  ClientConfigInterface
-	get     func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
-	write   func(ref name.Reference, image v1.Image, options ...remote.Option) error
-	tlsDial oktetoHttp.TLSDialFunc
+	config     ClientConfigInterface
+	get        func(ref name.Reference, options ...remote.Option) (*remote.Descriptor, error)
+	write      func(ref name.Reference, image v1.Image, options ...remote.Option) error
+	writeIndex func(ref name.Reference, index v1.ImageIndex, options ...remote.Option) error
+	list       func(repo name.Repository, options ...remote.Option) ([]string, error)
+	catalog    func(ctx context.Context, registry name.Registry, options ...remote.Option) ([]string, error)
+	delete     func(ref name.Reference, options ...remote.Option) error
+	tlsDial    oktetoHttp.TLSDialFunc
 }
 
 func newOktetoRegistryClient(config ClientConfigInterface) client {
 	return client{
-		config:  config,
-		get:     remote.Get,
-		write:   remote.Write,
-		tlsDial: oktetoHttp.DefaultTLSDial,
+		config:     config,
+		get:        remote.Get,
+		write:      remote.Write,
+		writeIndex: remote.WriteIndex,
+		list:       remote.List,
+		catalog:    remote.Catalog,
+		delete:     remote.Delete,
+		tlsDial:    oktetoHttp.DefaultTLSDial,
 	}
 }
 
@@ -109,6 +122,62 @@ func (c client) Write(ref name.Reference, image v1.Image) error {
 	return c.write(ref, image, options...)
 }
 
+// WriteIndex writes an image index and all its images to the registry
+func (c client) WriteIndex(ref name.Reference, index v1.ImageIndex) error {
+	options := c.getOptions(ref)
+	return c.writeIndex(ref, index, options...)
+}
+
+// ListTags returns the tags of a repository
+func (c client) ListTags(repository string) ([]string, error) {
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	options := c.getRegistryOptions(repo.RegistryStr())
+	tags, err := c.list(repo, options...)
+	if err != nil {
+		if c.isRepositoryNotFound(err) {
+			return nil, fmt.Errorf("error listing tags: %w", oktetoErrors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	return tags, nil
+}
+
+// ListRepositories returns the repositories of a registry
+func (c client) ListRepositories(registry string) ([]string, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	options := c.getRegistryOptions(reg.RegistryStr())
+	repositories, err := c.catalog(context.Background(), reg, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing repositories: %w", err)
+	}
+	return repositories, nil
+}
+
+// Delete deletes the manifest of an image from the registry
+func (c client) Delete(image string) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+
+	options := c.getOptions(ref)
+	if err := c.delete(ref, options...); err != nil {
+		if c.isNotFound(err) {
+			return fmt.Errorf("error deleting image: %w", oktetoErrors.ErrNotFound)
+		}
+		return fmt.Errorf("error deleting image: %w", err)
+	}
+	return nil
+}
+
 // GetDigest returns the digest of an image
 func (c client) GetDigest(image string) (string, error) {
 	descriptor, err := c.GetDescriptor(image)
@@ -157,8 +226,27 @@ func (c client) isNotFound(err error) bool {
 	return false
 }
 
+func (c client) isRepositoryNotFound(err error) bool {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		if transportErr.StatusCode == http.StatusNotFound {
+			return true
+		}
+		for _, err := range transportErr.Errors {
+			if err.Code == transport.NameUnknownErrorCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (c client) getOptions(ref name.Reference) []remote.Option {
-	return []remote.Option{c.getAuthentication(ref), c.getTransportOption()}
+	return c.getRegistryOptions(ref.Context().RegistryStr())
+}
+
+func (c client) getRegistryOptions(registry string) []remote.Option {
+	return []remote.Option{c.getAuthentication(registry), c.getTransportOption()}
 }
 
 func (c client) getAuthHelper(_ name.Reference) authn.Keychain {
@@ -166,8 +254,7 @@ func (c client) getAuthHelper(_ name.Reference) authn.Keychain {
 	return authn.NewKeychainFromHelper(helper)
 }
 
-func (c client) getAuthentication(ref name.Reference) remote.Option {
-	registry := ref.Context().RegistryStr()
+func (c client) getAuthentication(registry string) remote.Option {
 	oktetoLog.Debugf("calling registry %s", registry)
 
 	okRegistry := c.config.GetRegistryURL()
@@ -137,6 +137,22 @@ func (fc fakeClient) Write(_ name.Reference, _ containerv1.Image) error {
 	return fc.MockWrite.Err
 }
 
+func (fakeClient) ListTags(_ string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeClient) ListRepositories(_ string) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeClient) Delete(_ string) error {
+	return nil
+}
+
+func (fc fakeClient) WriteIndex(_ name.Reference, _ containerv1.ImageIndex) error {
+	return fc.MockWrite.Err
+}
+
 type fakeClientConfig struct {
 	cert                        *x509.Certificate
 	externalRegistryCredentials [2]string
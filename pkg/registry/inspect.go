@@ -0,0 +1,252 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// defaultInspectPlatform is the platform inspected when an image is built for several platforms
+const defaultInspectPlatform = "linux/amd64"
+
+// ImageLayer represents a layer of an image
+type ImageLayer struct {
+	Digest    string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	Size      int64  `json:"size"`
+}
+
+// ImageInfo is the configuration, layers and platforms of an image
+type ImageInfo struct {
+	Created    time.Time         `json:"created"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Image      string            `json:"image"`
+	Digest     string            `json:"digest"`
+	MediaType  string            `json:"mediaType"`
+	Platform   string            `json:"platform"`
+	Workdir    string            `json:"workdir,omitempty"`
+	User       string            `json:"user,omitempty"`
+	Entrypoint []string          `json:"entrypoint,omitempty"`
+	Cmd        []string          `json:"cmd,omitempty"`
+	Env        []string          `json:"env,omitempty"`
+	Ports      []string          `json:"ports,omitempty"`
+	Platforms  []string          `json:"platforms"`
+	Layers     []ImageLayer      `json:"layers"`
+	Size       int64             `json:"size"`
+}
+
+// ListRepositories returns the repositories stored under a registry prefix, for example okteto.dev or okteto.global.
+// The repositories are returned using the same notation as the prefix
+func (or OktetoRegistry) ListRepositories(prefix string) ([]string, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	expandedPrefix := or.imageCtrl.expandImageRegistries(prefix)
+	registry, path, _ := strings.Cut(expandedPrefix, "/")
+
+	repositories, err := or.client.ListRepositories(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, repository := range repositories {
+		if path == "" {
+			result = append(result, fmt.Sprintf("%s/%s", prefix, repository))
+			continue
+		}
+		if strings.HasPrefix(repository, path+"/") {
+			result = append(result, fmt.Sprintf("%s/%s", prefix, strings.TrimPrefix(repository, path+"/")))
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// InspectImage returns the configuration, layers and platforms of an image.
+// The configuration and layers of images built for several platforms are the ones of linux/amd64, or the first platform if not available
+func (or OktetoRegistry) InspectImage(image string) (ImageInfo, error) {
+	expandedImage := or.imageCtrl.expandImageRegistries(image)
+	descriptor, err := or.client.GetDescriptor(expandedImage)
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+	}
+
+	info := ImageInfo{
+		Image:     expandedImage,
+		Digest:    descriptor.Digest.String(),
+		MediaType: string(descriptor.MediaType),
+		Platforms: []string{},
+	}
+
+	var img v1.Image
+	if descriptor.MediaType.IsIndex() {
+		img, info.Platforms, err = getIndexPlatformImage(descriptor)
+	} else {
+		img, err = descriptor.Image()
+	}
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+	}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+	}
+	info.Created = cfg.Created.Time
+	info.Platform = getPlatformName(&v1.Platform{OS: cfg.OS, Architecture: cfg.Architecture})
+	if !descriptor.MediaType.IsIndex() {
+		info.Platforms = []string{info.Platform}
+	}
+	info.Labels = cfg.Config.Labels
+	info.Workdir = cfg.Config.WorkingDir
+	info.User = cfg.Config.User
+	info.Entrypoint = cfg.Config.Entrypoint
+	info.Cmd = cfg.Config.Cmd
+	info.Env = cfg.Config.Env
+	for port := range cfg.Config.ExposedPorts {
+		info.Ports = append(info.Ports, port)
+	}
+	sort.Strings(info.Ports)
+
+	layers, err := img.Layers()
+	if err != nil {
+		return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+	}
+	info.Layers = make([]ImageLayer, 0, len(layers))
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+		}
+		size, err := layer.Size()
+		if err != nil {
+			return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+		}
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return ImageInfo{}, fmt.Errorf("error inspecting image: %w", err)
+		}
+		info.Layers = append(info.Layers, ImageLayer{Digest: digest.String(), MediaType: string(mediaType), Size: size})
+		info.Size += size
+	}
+	return info, nil
+}
+
+// CopyImage copies an image, and all its platforms, to another repository and returns the destination image with digest.
+// If the destination has no tag, the tag of the source image is used
+func (or OktetoRegistry) CopyImage(src, dst string) (string, error) {
+	expandedSrc := or.imageCtrl.expandImageRegistries(src)
+	expandedDst := or.imageCtrl.expandImageRegistries(dst)
+
+	srcRef, err := name.ParseReference(expandedSrc)
+	if err != nil {
+		return "", fmt.Errorf("invalid source image '%s': %w", src, err)
+	}
+	if !hasTagOrDigest(expandedDst) {
+		if _, ok := srcRef.(name.Tag); !ok {
+			return "", fmt.Errorf("invalid destination image '%s': the source image has no tag", dst)
+		}
+		expandedDst = fmt.Sprintf("%s:%s", expandedDst, srcRef.Identifier())
+	}
+	dstRef, err := name.ParseReference(expandedDst)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination image '%s': %w", dst, err)
+	}
+
+	descriptor, err := or.client.GetDescriptor(expandedSrc)
+	if err != nil {
+		return "", fmt.Errorf("error copying image: %w", err)
+	}
+
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return "", fmt.Errorf("error copying image: %w", err)
+		}
+		err = or.client.WriteIndex(dstRef, index)
+		if err != nil {
+			return "", fmt.Errorf("error copying image: %w", err)
+		}
+	} else {
+		img, err := descriptor.Image()
+		if err != nil {
+			return "", fmt.Errorf("error copying image: %w", err)
+		}
+		err = or.client.Write(dstRef, img)
+		if err != nil {
+			return "", fmt.Errorf("error copying image: %w", err)
+		}
+	}
+	return fmt.Sprintf("%s@%s", dstRef.Context().Name(), descriptor.Digest.String()), nil
+}
+
+// getIndexPlatformImage returns the image of the platform to inspect and the platforms of an image index.
+// Attestation manifests are not platforms
+func getIndexPlatformImage(descriptor *remote.Descriptor) (v1.Image, []string, error) {
+	index, err := descriptor.ImageIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	platforms := []string{}
+	var selected *v1.Hash
+	for i, m := range manifest.Manifests {
+		if m.Annotations[attestationReferenceTypeAnnotation] == attestationManifestReferenceType || !m.MediaType.IsImage() {
+			continue
+		}
+		platform := getPlatformName(m.Platform)
+		platforms = append(platforms, platform)
+		if selected == nil || platform == defaultInspectPlatform {
+			selected = &manifest.Manifests[i].Digest
+		}
+	}
+	if selected == nil {
+		return nil, nil, fmt.Errorf("image index '%s' has no images", descriptor.Digest.String())
+	}
+
+	img, err := index.Image(*selected)
+	if err != nil {
+		return nil, nil, err
+	}
+	return img, platforms, nil
+}
+
+func getPlatformName(platform *v1.Platform) string {
+	if platform == nil || platform.OS == "" {
+		return "unknown"
+	}
+	result := fmt.Sprintf("%s/%s", platform.OS, platform.Architecture)
+	if platform.Variant != "" {
+		result = fmt.Sprintf("%s/%s", result, platform.Variant)
+	}
+	return result
+}
+
+// hasTagOrDigest returns if an image reference includes a tag or a digest
+func hasTagOrDigest(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	return strings.Contains(image[strings.LastIndex(image, "/")+1:], ":")
+}
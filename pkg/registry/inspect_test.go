@@ -0,0 +1,149 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrRegistry "github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOktetoRegistry(t *testing.T) (OktetoRegistry, string) {
+	t.Helper()
+	server := httptest.NewServer(ggcrRegistry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	or := NewOktetoRegistry(FakeConfig{
+		IsOktetoClusterCfg: true,
+		RegistryURL:        u.Host,
+		Namespace:          "cindy",
+		GlobalNamespace:    "okteto",
+	})
+	return or, u.Host
+}
+
+func pushTestIndex(t *testing.T, image string) v1.ImageIndex {
+	t.Helper()
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+
+	amd64, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	cfg, err := amd64.ConfigFile()
+	require.NoError(t, err)
+	cfg = cfg.DeepCopy()
+	cfg.OS = "linux"
+	cfg.Architecture = "amd64"
+	cfg.Config = v1.Config{
+		Cmd:          []string{"yarn", "start"},
+		WorkingDir:   "/usr/src/app",
+		Env:          []string{"PORT=8080"},
+		ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+	}
+	amd64, err = mutate.ConfigFile(amd64, cfg)
+	require.NoError(t, err)
+	arm64, err := random.Image(1024, 1)
+	require.NoError(t, err)
+
+	index := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+	)
+	require.NoError(t, remote.WriteIndex(ref, index))
+	return index
+}
+
+func TestListRepositories(t *testing.T) {
+	or, registryURL := newTestOktetoRegistry(t)
+	pushTestImage(t, fmt.Sprintf("%s/cindy/api:1.0", registryURL), time.Now())
+	pushTestImage(t, fmt.Sprintf("%s/cindy/frontend:1.0", registryURL), time.Now())
+	pushTestImage(t, fmt.Sprintf("%s/okteto/api:1.0", registryURL), time.Now())
+
+	repositories, err := or.ListRepositories("okteto.dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"okteto.dev/api", "okteto.dev/frontend"}, repositories)
+
+	repositories, err = or.ListRepositories("okteto.global/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"okteto.global/api"}, repositories)
+}
+
+func TestInspectImage(t *testing.T) {
+	or, registryURL := newTestOktetoRegistry(t)
+	index := pushTestIndex(t, fmt.Sprintf("%s/cindy/api:1.0", registryURL))
+	indexDigest, err := index.Digest()
+	require.NoError(t, err)
+
+	info, err := or.InspectImage("okteto.dev/api:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/cindy/api:1.0", registryURL), info.Image)
+	assert.Equal(t, indexDigest.String(), info.Digest)
+	assert.Equal(t, []string{"linux/arm64", "linux/amd64"}, info.Platforms)
+	assert.Equal(t, "linux/amd64", info.Platform)
+	assert.Equal(t, []string{"yarn", "start"}, info.Cmd)
+	assert.Equal(t, "/usr/src/app", info.Workdir)
+	assert.Equal(t, []string{"PORT=8080"}, info.Env)
+	assert.Equal(t, []string{"8080/tcp"}, info.Ports)
+	assert.Len(t, info.Layers, 2)
+	assert.Equal(t, info.Layers[0].Size+info.Layers[1].Size, info.Size)
+
+	pushTestImage(t, fmt.Sprintf("%s/cindy/worker:1.0", registryURL), time.Now())
+	info, err = or.InspectImage("okteto.dev/worker:1.0")
+	require.NoError(t, err)
+	assert.Len(t, info.Layers, 1)
+	assert.Equal(t, []string{info.Platform}, info.Platforms)
+
+	_, err = or.InspectImage("okteto.dev/unknown:1.0")
+	assert.Error(t, err)
+}
+
+func TestCopyImage(t *testing.T) {
+	or, registryURL := newTestOktetoRegistry(t)
+	index := pushTestIndex(t, fmt.Sprintf("%s/okteto/api:1.0", registryURL))
+	indexDigest, err := index.Digest()
+	require.NoError(t, err)
+
+	result, err := or.CopyImage("okteto.global/api:1.0", "okteto.dev/api")
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/cindy/api@%s", registryURL, indexDigest), result)
+	digest, err := or.client.GetDigest(fmt.Sprintf("%s/cindy/api:1.0", registryURL))
+	require.NoError(t, err)
+	assert.Equal(t, indexDigest.String(), digest)
+
+	pushTestImage(t, fmt.Sprintf("%s/cindy/worker:1.0", registryURL), time.Now())
+	result, err = or.CopyImage("okteto.dev/worker:1.0", "okteto.global/worker:stable")
+	require.NoError(t, err)
+	digest, err = or.client.GetDigest(fmt.Sprintf("%s/okteto/worker:stable", registryURL))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/okteto/worker@%s", registryURL, digest), result)
+
+	imageWithDigest, err := or.GetImageTagWithDigest("okteto.dev/worker:1.0")
+	require.NoError(t, err)
+	_, err = or.CopyImage(imageWithDigest, "okteto.dev/other")
+	assert.Error(t, err)
+}
@@ -22,7 +22,13 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
-const globalTestImage = "okteto.global/test"
+const (
+	globalTestImage = "okteto.global/test"
+
+	// attestationReferenceTypeAnnotation is the annotation BuildKit sets on the attestation manifests of an image index
+	attestationReferenceTypeAnnotation = "vnd.docker.reference.type"
+	attestationManifestReferenceType   = "attestation-manifest"
+)
 
 type configInterface interface {
 	IsOktetoCluster() bool
@@ -99,6 +105,36 @@ func (or OktetoRegistry) GetImageMetadata(image string) (ImageMetadata, error) {
 	}, nil
 }
 
+// GetAttestationDigests returns the digests of the attestation manifests attached to an image.
+// BuildKit stores them in the image index next to the platform manifests
+func (or OktetoRegistry) GetAttestationDigests(image string) ([]string, error) {
+	expandedImage := or.imageCtrl.expandImageRegistries(image)
+	descriptor, err := or.client.GetDescriptor(expandedImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image attestations: %w", err)
+	}
+	if !descriptor.MediaType.IsIndex() {
+		return nil, nil
+	}
+
+	index, err := descriptor.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("error getting image attestations: %w", err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("error getting image attestations: %w", err)
+	}
+
+	result := []string{}
+	for _, m := range manifest.Manifests {
+		if m.Annotations[attestationReferenceTypeAnnotation] == attestationManifestReferenceType {
+			result = append(result, m.Digest.String())
+		}
+	}
+	return result, nil
+}
+
 // IsOktetoRegistry returns if an image tag is pointing to the okteto registry
 func (or OktetoRegistry) IsOktetoRegistry(image string) bool {
 	expandedImage := or.imageCtrl.expandImageRegistries(image)
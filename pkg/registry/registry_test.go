@@ -20,6 +20,7 @@ import (
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
 )
@@ -702,3 +703,64 @@ func TestGetImageReference(t *testing.T) {
 		})
 	}
 }
+
+func Test_OktetoRegistry_GetAttestationDigests(t *testing.T) {
+	index := `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.index.v1+json",
+  "manifests": [
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111", "size": 1, "platform": {"architecture": "amd64", "os": "linux"}},
+    {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222", "size": 1, "platform": {"architecture": "unknown", "os": "unknown"}, "annotations": {"vnd.docker.reference.type": "attestation-manifest", "vnd.docker.reference.digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111"}}
+  ]
+}`
+	var tests = []struct {
+		expectedErr error
+		name        string
+		client      fakeClient
+		expected    []string
+	}{
+		{
+			name: "error getting descriptor",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{Err: assert.AnError},
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "single manifest image",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{
+					Result: &remote.Descriptor{
+						Descriptor: v1.Descriptor{MediaType: types.OCIManifestSchema1},
+					},
+				},
+			},
+		},
+		{
+			name: "image index with attestations",
+			client: fakeClient{
+				MockGetDescriptor: mockGetDescriptor{
+					Result: &remote.Descriptor{
+						Descriptor: v1.Descriptor{MediaType: types.OCIImageIndex},
+						Manifest:   []byte(index),
+					},
+				},
+			},
+			expected: []string{"sha256:2222222222222222222222222222222222222222222222222222222222222222"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := FakeConfig{RegistryURL: "okteto.registry"}
+			or := OktetoRegistry{
+				imageCtrl: NewImageCtrl(cfg),
+				config:    cfg,
+				client:    tt.client,
+			}
+
+			result, err := or.GetAttestationDigests("okteto.registry/ns/app:1.0")
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// The signatures follow the cosign format, so they can also be verified with 'cosign verify':
+// they are stored as layers of an OCI image tagged 'sha256-<digest>.sig' in the repository of the signed image
+const (
+	signatureTagSuffix         = ".sig"
+	simpleSigningMediaType     = "application/vnd.dev.cosign.simplesigning.v1+json"
+	signatureAnnotation        = "dev.cosignproject.cosign/signature"
+	simpleSigningPayloadType   = "cosign container image signature"
+	signatureConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	signatureManifestMediaType = types.OCIManifestSchema1
+)
+
+var (
+	// ErrImageNotSigned is returned when an image doesn't have any signature
+	ErrImageNotSigned = errors.New("image is not signed")
+
+	// ErrInvalidSignature is returned when none of the signatures of an image is valid for the given key
+	ErrInvalidSignature = errors.New("no valid signature found for the given key")
+)
+
+type simpleSigningPayload struct {
+	Optional map[string]interface{} `json:"optional"`
+	Critical simpleSigningCritical  `json:"critical"`
+}
+
+type simpleSigningCritical struct {
+	Identity simpleSigningIdentity `json:"identity"`
+	Image    simpleSigningImage    `json:"image"`
+	Type     string                `json:"type"`
+}
+
+type simpleSigningIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type simpleSigningImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// SignImage signs the digest of an image and pushes the signature next to the image.
+// Images already signed with the same key are not signed again
+func (or OktetoRegistry) SignImage(image string, signer crypto.Signer) error {
+	digestRef, err := or.getDigestReference(image)
+	if err != nil {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+
+	sigImage, err := or.getSignatureImage(digestRef)
+	if err != nil && !errors.Is(err, oktetoErrors.ErrNotFound) {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+	if sigImage != nil {
+		if err := verifySignatures(sigImage, digestRef, signer.Public()); err == nil {
+			oktetoLog.Infof("image '%s' is already signed with the given key", digestRef.String())
+			return nil
+		}
+	} else {
+		sigImage = mutate.ConfigMediaType(mutate.MediaType(empty.Image, signatureManifestMediaType), signatureConfigMediaType)
+	}
+
+	payload, err := json.Marshal(simpleSigningPayload{
+		Critical: simpleSigningCritical{
+			Identity: simpleSigningIdentity{DockerReference: digestRef.Context().Name()},
+			Image:    simpleSigningImage{DockerManifestDigest: digestRef.DigestStr()},
+			Type:     simpleSigningPayloadType,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+	hash := sha256.Sum256(payload)
+	signature, err := signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+
+	sigImage, err = mutate.Append(sigImage, mutate.Addendum{
+		Layer: static.NewLayer(payload, simpleSigningMediaType),
+		Annotations: map[string]string{
+			signatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error signing image '%s': %w", image, err)
+	}
+
+	sigRef := getSignatureReference(digestRef)
+	if err := or.client.Write(sigRef, sigImage); err != nil {
+		return fmt.Errorf("error pushing signature of image '%s': %w", image, err)
+	}
+	oktetoLog.Infof("signature of image '%s' pushed to '%s'", digestRef.String(), sigRef.String())
+	return nil
+}
+
+// VerifyImage checks that the image has a signature made with the private key of the given public key
+func (or OktetoRegistry) VerifyImage(image string, publicKey crypto.PublicKey) error {
+	digestRef, err := or.getDigestReference(image)
+	if err != nil {
+		return fmt.Errorf("error verifying image '%s': %w", image, err)
+	}
+
+	sigImage, err := or.getSignatureImage(digestRef)
+	if err != nil {
+		if errors.Is(err, oktetoErrors.ErrNotFound) {
+			return fmt.Errorf("error verifying image '%s': %w", image, ErrImageNotSigned)
+		}
+		return fmt.Errorf("error verifying image '%s': %w", image, err)
+	}
+	if err := verifySignatures(sigImage, digestRef, publicKey); err != nil {
+		return fmt.Errorf("error verifying image '%s': %w", image, err)
+	}
+	return nil
+}
+
+// getDigestReference returns the digest reference of an image, resolving the digest when the image is a tag
+func (or OktetoRegistry) getDigestReference(image string) (name.Digest, error) {
+	expandedImage := or.imageCtrl.expandImageRegistries(image)
+	if !strings.Contains(expandedImage, "@") {
+		digest, err := or.client.GetDigest(expandedImage)
+		if err != nil {
+			return name.Digest{}, err
+		}
+		repo, _ := or.imageCtrl.GetRepoNameAndTag(expandedImage)
+		expandedImage = fmt.Sprintf("%s@%s", repo, digest)
+	}
+	return name.NewDigest(expandedImage)
+}
+
+func (or OktetoRegistry) getSignatureImage(digestRef name.Digest) (v1.Image, error) {
+	descriptor, err := or.client.GetDescriptor(getSignatureReference(digestRef).String())
+	if err != nil {
+		return nil, err
+	}
+	return descriptor.Image()
+}
+
+// getSignatureReference returns the tag where the signatures of an image are stored
+func getSignatureReference(digestRef name.Digest) name.Tag {
+	tag := strings.Replace(digestRef.DigestStr(), ":", "-", 1) + signatureTagSuffix
+	return digestRef.Context().Tag(tag)
+}
+
+// GetSignedDigest returns the digest of the image signed by a signature tag 'sha256-<digest>.sig'
+func GetSignedDigest(tag string) (string, bool) {
+	if !strings.HasSuffix(tag, signatureTagSuffix) {
+		return "", false
+	}
+	algorithm, hex, found := strings.Cut(strings.TrimSuffix(tag, signatureTagSuffix), "-")
+	if !found || algorithm == "" || hex == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%s", algorithm, hex), true
+}
+
+// verifySignatures checks if any of the signature layers is a valid signature of the image digest
+func verifySignatures(sigImage v1.Image, digestRef name.Digest, publicKey crypto.PublicKey) error {
+	ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unsupported public key type %T: only ECDSA keys are supported", publicKey)
+	}
+
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != simpleSigningMediaType {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+		if err != nil {
+			continue
+		}
+		payload, err := getLayerContent(sigImage, layer.Digest)
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(payload)
+		if !ecdsa.VerifyASN1(ecdsaKey, hash[:], signature) {
+			continue
+		}
+
+		var p simpleSigningPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			continue
+		}
+		if p.Critical.Image.DockerManifestDigest == digestRef.DigestStr() {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func getLayerContent(img v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
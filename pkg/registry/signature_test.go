@@ -0,0 +1,116 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrRegistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry starts an in-memory registry compatible with registry:2 and pushes a random image to it
+func newTestRegistry(t *testing.T) (OktetoRegistry, string) {
+	t.Helper()
+	server := httptest.NewServer(ggcrRegistry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	image := fmt.Sprintf("%s/test/app:1.0", u.Host)
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	return NewOktetoRegistry(FakeConfig{RegistryURL: u.Host}), image
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func TestSignAndVerifyImage(t *testing.T) {
+	or, image := newTestRegistry(t)
+	key := newTestKey(t)
+
+	err := or.VerifyImage(image, key.Public())
+	assert.ErrorIs(t, err, ErrImageNotSigned)
+
+	require.NoError(t, or.SignImage(image, key))
+	assert.NoError(t, or.VerifyImage(image, key.Public()))
+
+	// the signature is bound to the digest, so it can be verified using the digest reference too
+	imageWithDigest, err := or.GetImageTagWithDigest(image)
+	require.NoError(t, err)
+	assert.NoError(t, or.VerifyImage(imageWithDigest, key.Public()))
+
+	otherKey := newTestKey(t)
+	err = or.VerifyImage(image, otherKey.Public())
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestSignImageTwice(t *testing.T) {
+	or, image := newTestRegistry(t)
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+
+	require.NoError(t, or.SignImage(image, key))
+	require.NoError(t, or.SignImage(image, key))
+	require.NoError(t, or.SignImage(image, otherKey))
+
+	digestRef, err := or.getDigestReference(image)
+	require.NoError(t, err)
+	sigImage, err := or.getSignatureImage(digestRef)
+	require.NoError(t, err)
+	layers, err := sigImage.Layers()
+	require.NoError(t, err)
+	// signing again with the same key doesn't add a new signature
+	assert.Len(t, layers, 2)
+
+	assert.NoError(t, or.VerifyImage(image, key.Public()))
+	assert.NoError(t, or.VerifyImage(image, otherKey.Public()))
+}
+
+func TestGetSignatureReference(t *testing.T) {
+	digestRef, err := name.NewDigest("registry.com/ns/app@sha256:4b2ef4d2b5a5f4c5e3b0d2e1d9e8a8c6f0e5a1f3c0d2b4a6e8f0a2c4e6b8d0f2")
+	require.NoError(t, err)
+	assert.Equal(t, "registry.com/ns/app:sha256-4b2ef4d2b5a5f4c5e3b0d2e1d9e8a8c6f0e5a1f3c0d2b4a6e8f0a2c4e6b8d0f2.sig", getSignatureReference(digestRef).String())
+}
+
+func TestGetSignedDigest(t *testing.T) {
+	digest, ok := GetSignedDigest("sha256-4b2ef4d2.sig")
+	assert.True(t, ok)
+	assert.Equal(t, "sha256:4b2ef4d2", digest)
+
+	_, ok = GetSignedDigest("okteto")
+	assert.False(t, ok)
+
+	_, ok = GetSignedDigest("latest.sig")
+	assert.False(t, ok)
+}
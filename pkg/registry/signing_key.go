@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	ecPrivateKeyPEMType    = "EC PRIVATE KEY"
+	privateKeyPEMType      = "PRIVATE KEY"
+	publicKeyPEMType       = "PUBLIC KEY"
+	cosignPrivateKeyType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	sigstorePrivateKeyType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+
+	nonceLength = 24
+	keyLength   = 32
+)
+
+var errInvalidKey = errors.New("invalid key: it must be a PEM encoded ECDSA key")
+
+// encryptedKey is the format of the encrypted keys generated by 'cosign generate-key-pair'
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt string `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce string `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// GetSigner returns the signer of the key at keyPath or, if keyPath is empty, of the key defined by OKTETO_SIGN_KEY
+func GetSigner(fs afero.Fs, keyPath string) (crypto.Signer, error) {
+	key, err := readKey(fs, keyPath, constants.OktetoSignKeyEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	password := os.Getenv(constants.OktetoSignKeyPasswordEnvVar)
+	if password == "" {
+		password = os.Getenv(constants.CosignPasswordEnvVar)
+	}
+	return LoadSigner(key, []byte(password))
+}
+
+// GetVerifier returns the public key at keyPath or, if keyPath is empty, the key defined by OKTETO_VERIFY_KEY
+func GetVerifier(fs afero.Fs, keyPath string) (crypto.PublicKey, error) {
+	key, err := readKey(fs, keyPath, constants.OktetoVerifyKeyEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	return LoadPublicKey(key)
+}
+
+func readKey(fs afero.Fs, keyPath, envVar string) ([]byte, error) {
+	if keyPath != "" {
+		key, err := afero.ReadFile(fs, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read key '%s': %w", keyPath, err)
+		}
+		return key, nil
+	}
+	key := os.Getenv(envVar)
+	if key == "" {
+		return nil, fmt.Errorf("no key provided: use a key file or set the %s environment variable", envVar)
+	}
+	return []byte(key), nil
+}
+
+// LoadSigner returns the signer of a PEM encoded ECDSA private key.
+// Keys encrypted by cosign are decrypted with the given password. Other key types are rejected since the signatures are verified with ECDSA
+func LoadSigner(key, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errInvalidKey
+	}
+
+	var der []byte
+	switch block.Type {
+	case ecPrivateKeyPEMType:
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+		}
+		return privateKey, nil
+	case privateKeyPEMType:
+		der = block.Bytes
+	case cosignPrivateKeyType, sigstorePrivateKeyType:
+		decrypted, err := decryptKey(block.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+		der = decrypted
+	default:
+		return nil, fmt.Errorf("%w: unsupported PEM type '%s'", errInvalidKey, block.Type)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+	ecdsaKey, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T, only ECDSA keys are supported", errInvalidKey, privateKey)
+	}
+	return ecdsaKey, nil
+}
+
+// LoadPublicKey returns the public key of a PEM encoded ECDSA public key
+func LoadPublicKey(key []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil || block.Type != publicKeyPEMType {
+		return nil, errInvalidKey
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+	ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T, only ECDSA keys are supported", errInvalidKey, publicKey)
+	}
+	return ecdsaKey, nil
+}
+
+func decryptKey(data, password []byte) ([]byte, error) {
+	var k encryptedKey
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+	if k.KDF.Name != "scrypt" || k.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("%w: unsupported encryption '%s' '%s'", errInvalidKey, k.KDF.Name, k.Cipher.Name)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(k.KDF.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(k.Cipher.Nonce)
+	if err != nil || len(nonce) != nonceLength {
+		return nil, fmt.Errorf("%w: invalid nonce", errInvalidKey)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(k.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+
+	secret, err := scrypt.Key(password, salt, k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidKey, err)
+	}
+	var secretKey [keyLength]byte
+	copy(secretKey[:], secret)
+	var nonceArray [nonceLength]byte
+	copy(nonceArray[:], nonce)
+
+	decrypted, ok := secretbox.Open(nil, ciphertext, &nonceArray, &secretKey)
+	if !ok {
+		return nil, errors.New("could not decrypt the key: the password is not valid")
+	}
+	return decrypted, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptTestKey encrypts a PKCS8 key the same way 'cosign generate-key-pair' does
+func encryptTestKey(t *testing.T, der, password []byte) []byte {
+	t.Helper()
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	require.NoError(t, err)
+	var nonce [nonceLength]byte
+	_, err = rand.Read(nonce[:])
+	require.NoError(t, err)
+
+	secret, err := scrypt.Key(password, salt, 1024, 8, 1, keyLength)
+	require.NoError(t, err)
+	var secretKey [keyLength]byte
+	copy(secretKey[:], secret)
+
+	var k encryptedKey
+	k.KDF.Name = "scrypt"
+	k.KDF.Params.N = 1024
+	k.KDF.Params.R = 8
+	k.KDF.Params.P = 1
+	k.KDF.Salt = base64.StdEncoding.EncodeToString(salt)
+	k.Cipher.Name = "nacl/secretbox"
+	k.Cipher.Nonce = base64.StdEncoding.EncodeToString(nonce[:])
+	k.Ciphertext = base64.StdEncoding.EncodeToString(secretbox.Seal(nil, der, &nonce, &secretKey))
+	b, err := json.Marshal(k)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: cosignPrivateKeyType, Bytes: b})
+}
+
+func TestLoadSigner(t *testing.T) {
+	key := newTestKey(t)
+	sec1, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed25519PKCS8, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		key         []byte
+		password    []byte
+		expectedErr bool
+	}{
+		{
+			name: "ec private key",
+			key:  pem.EncodeToMemory(&pem.Block{Type: ecPrivateKeyPEMType, Bytes: sec1}),
+		},
+		{
+			name: "pkcs8 private key",
+			key:  pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: pkcs8}),
+		},
+		{
+			name:     "cosign encrypted key",
+			key:      encryptTestKey(t, pkcs8, []byte("secret")),
+			password: []byte("secret"),
+		},
+		{
+			name:        "cosign encrypted key with wrong password",
+			key:         encryptTestKey(t, pkcs8, []byte("secret")),
+			password:    []byte("wrong"),
+			expectedErr: true,
+		},
+		{
+			name:        "not a pem",
+			key:         []byte("key"),
+			expectedErr: true,
+		},
+		{
+			name:        "ed25519 private key",
+			key:         pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: ed25519PKCS8}),
+			expectedErr: true,
+		},
+		{
+			name:        "cosign encrypted ed25519 key",
+			key:         encryptTestKey(t, ed25519PKCS8, []byte("secret")),
+			password:    []byte("secret"),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := LoadSigner(tt.key, tt.password)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, key.PublicKey.Equal(signer.Public()))
+		})
+	}
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	key := newTestKey(t)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	publicKey, err := LoadPublicKey(pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	_, err = LoadPublicKey([]byte("key"))
+	assert.Error(t, err)
+
+	ed25519PublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(ed25519PublicKey)
+	require.NoError(t, err)
+	_, err = LoadPublicKey(pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: der}))
+	assert.ErrorIs(t, err, errInvalidKey)
+}
+
+func TestGetSigner(t *testing.T) {
+	key := newTestKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	encrypted := encryptTestKey(t, pkcs8, []byte("secret"))
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "cosign.key", encrypted, 0600))
+
+	t.Setenv(constants.OktetoSignKeyEnvVar, "")
+	t.Setenv(constants.CosignPasswordEnvVar, "secret")
+	signer, err := GetSigner(fs, "cosign.key")
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+
+	_, err = GetSigner(fs, "")
+	assert.Error(t, err)
+
+	t.Setenv(constants.OktetoSignKeyEnvVar, string(encrypted))
+	t.Setenv(constants.OktetoSignKeyPasswordEnvVar, "secret")
+	t.Setenv(constants.CosignPasswordEnvVar, "")
+	signer, err = GetSigner(fs, "")
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+
+	_, err = GetSigner(fs, "missing.key")
+	assert.Error(t, err)
+}
+
+func TestGetVerifier(t *testing.T) {
+	key := newTestKey(t)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	publicKey := pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: der})
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "cosign.pub", publicKey, 0600))
+
+	t.Setenv(constants.OktetoVerifyKeyEnvVar, "")
+	verifier, err := GetVerifier(fs, "cosign.pub")
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(verifier))
+
+	_, err = GetVerifier(fs, "")
+	assert.Error(t, err)
+
+	t.Setenv(constants.OktetoVerifyKeyEnvVar, string(publicKey))
+	verifier, err = GetVerifier(fs, "")
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(verifier))
+}
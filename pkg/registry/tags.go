@@ -0,0 +1,123 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// ImageTag represents a tag of a repository stored in the registry
+type ImageTag struct {
+	Created    time.Time
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// Image returns the reference of the tag
+func (t ImageTag) Image() string {
+	return fmt.Sprintf("%s:%s", t.Repository, t.Tag)
+}
+
+// ImageWithDigest returns the immutable reference of the tag
+func (t ImageTag) ImageWithDigest() string {
+	return fmt.Sprintf("%s@%s", t.Repository, t.Digest)
+}
+
+// ListImageTags returns the tags of the images of a repository, the newest first.
+// Repositories of the okteto registry can use the okteto.dev and okteto.global notation
+func (or OktetoRegistry) ListImageTags(repository string) ([]ImageTag, error) {
+	return or.listTags(repository, func(tag string) bool {
+		// cosign signatures and attestations are stored as tags of the signed digest
+		return !strings.HasPrefix(tag, "sha256-")
+	})
+}
+
+// ListSignatureTags returns the tags where the signatures of the images of a repository are stored, the newest first
+func (or OktetoRegistry) ListSignatureTags(repository string) ([]ImageTag, error) {
+	return or.listTags(repository, func(tag string) bool {
+		_, ok := GetSignedDigest(tag)
+		return ok
+	})
+}
+
+// listTags returns the tags of a repository selected by include, the newest first
+func (or OktetoRegistry) listTags(repository string, include func(tag string) bool) ([]ImageTag, error) {
+	expandedRepository := or.imageCtrl.expandImageRegistries(repository)
+	tags, err := or.client.ListTags(expandedRepository)
+	if err != nil {
+		if errors.Is(err, oktetoErrors.ErrNotFound) {
+			return []ImageTag{}, nil
+		}
+		return nil, err
+	}
+
+	result := make([]ImageTag, 0, len(tags))
+	for _, tag := range tags {
+		if !include(tag) {
+			continue
+		}
+		imageTag := ImageTag{
+			Repository: expandedRepository,
+			Tag:        tag,
+		}
+		descriptor, err := or.client.GetDescriptor(imageTag.Image())
+		if err != nil {
+			if errors.Is(err, oktetoErrors.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		imageTag.Digest = descriptor.Digest.String()
+
+		// the image configuration is only needed for the creation date
+		if img, err := descriptor.Image(); err == nil {
+			if cfg, err := img.ConfigFile(); err == nil {
+				imageTag.Created = cfg.Created.Time
+			}
+		} else {
+			oktetoLog.Infof("could not get the creation date of '%s': %s", imageTag.Image(), err)
+		}
+		result = append(result, imageTag)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Created.Equal(result[j].Created) {
+			return result[i].Tag < result[j].Tag
+		}
+		return result[i].Created.After(result[j].Created)
+	})
+	return result, nil
+}
+
+// DeleteImage deletes an image by digest. Every tag pointing to the digest is deleted too
+func (or OktetoRegistry) DeleteImage(imageWithDigest string) error {
+	expandedImage := or.imageCtrl.expandImageRegistries(imageWithDigest)
+	ref, err := name.ParseReference(expandedImage)
+	if err != nil {
+		return err
+	}
+	if _, ok := ref.(name.Digest); !ok {
+		return fmt.Errorf("image '%s' must be referenced by digest to be deleted", imageWithDigest)
+	}
+	return or.client.Delete(expandedImage)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushTestImage(t *testing.T, image string, created time.Time) {
+	t.Helper()
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+}
+
+func TestListImageTags(t *testing.T) {
+	or, image := newTestRegistry(t)
+	repository := image[:len(image)-len(":1.0")]
+	now := time.Now().UTC().Truncate(time.Second)
+	pushTestImage(t, fmt.Sprintf("%s:2.0", repository), now.Add(-time.Hour))
+	pushTestImage(t, fmt.Sprintf("%s:3.0", repository), now)
+
+	tags, err := or.ListImageTags(repository)
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+	assert.Equal(t, "3.0", tags[0].Tag)
+	assert.Equal(t, "2.0", tags[1].Tag)
+	assert.Equal(t, "1.0", tags[2].Tag)
+	assert.Equal(t, now, tags[0].Created.UTC())
+	for _, tag := range tags {
+		assert.Equal(t, repository, tag.Repository)
+		assert.NotEmpty(t, tag.Digest)
+	}
+
+	tags, err = or.ListImageTags(fmt.Sprintf("%s/test/unknown", or.config.GetRegistryURL()))
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestDeleteImage(t *testing.T) {
+	or, image := newTestRegistry(t)
+	imageWithDigest, err := or.GetImageTagWithDigest(image)
+	require.NoError(t, err)
+
+	assert.Error(t, or.DeleteImage(image))
+
+	require.NoError(t, or.DeleteImage(imageWithDigest))
+	_, err = or.client.GetDescriptor(imageWithDigest)
+	assert.ErrorIs(t, err, oktetoErrors.ErrNotFound)
+}
+
+func TestListSignatureTags(t *testing.T) {
+	or, image := newTestRegistry(t)
+	repository := image[:len(image)-len(":1.0")]
+	require.NoError(t, or.SignImage(image, newTestKey(t)))
+	imageWithDigest, err := or.GetImageTagWithDigest(image)
+	require.NoError(t, err)
+
+	signatures, err := or.ListSignatureTags(repository)
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	signedDigest, ok := GetSignedDigest(signatures[0].Tag)
+	require.True(t, ok)
+	assert.Equal(t, imageWithDigest, fmt.Sprintf("%s@%s", repository, signedDigest))
+
+	// signatures are not listed as images
+	tags, err := or.ListImageTags(repository)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "1.0", tags[0].Tag)
+}
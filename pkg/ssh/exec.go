@@ -32,29 +32,36 @@ import (
 	"golang.org/x/term"
 )
 
-// Exec executes the command over SSH
-func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command []string) error {
+// Dial connects to the SSH server of the development container, retrying for up to 10 seconds
+func Dial(ctx context.Context, iface string, remotePort int) (*ssh.Client, error) {
 	sshConfig, err := getSSHClientConfig()
 	if err != nil {
-		return fmt.Errorf("failed to get SSH configuration: %s", err)
+		return nil, fmt.Errorf("failed to get SSH configuration: %s", err)
 	}
 
-	// dockerterm.StdStreams() configures the terminal on windows
-	dockerterm.StdStreams()
-
 	var connection *ssh.Client
 	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
 	for i := 0; i < 100; i++ {
 		connection, err = dial(ctx, "tcp", net.JoinHostPort(iface, fmt.Sprintf("%d", remotePort)), sshConfig)
 		if err == nil {
-			break
+			return connection, nil
 		}
 
 		<-t.C
 	}
 
+	return nil, fmt.Errorf("failed to connect to SSH server: %s", err)
+}
+
+// Exec executes the command over SSH
+func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command []string) error {
+	// dockerterm.StdStreams() configures the terminal on windows
+	dockerterm.StdStreams()
+
+	connection, err := Dial(ctx, iface, remotePort)
 	if err != nil {
-		return fmt.Errorf("failed to connect to SSH server: %s", err)
+		return err
 	}
 	defer func() {
 		if err := connection.Close(); err != nil {
@@ -207,3 +214,40 @@ func dial(ctx context.Context, network, addr string, config *ssh.ClientConfig) (
 	}
 	return ssh.NewClient(c, chans, reqs), nil
 }
+
+// Run runs a shell command over SSH without a terminal, writing its combined output to out.
+// Unlike Exec, it doesn't read from the local stdin so it can run while a terminal session is open
+func Run(ctx context.Context, iface string, remotePort int, command string, out io.Writer) error {
+	connection, err := Dial(ctx, iface, remotePort)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := connection.Close(); err != nil && !oktetoErrors.IsClosedNetwork(err) {
+			oktetoLog.Debugf("Error closing connection: %s", err)
+		}
+	}()
+
+	session, err := connection.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %s", err)
+	}
+	defer session.Close()
+	session.Stdout = out
+	session.Stderr = out
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := session.Close(); err != nil {
+				oktetoLog.Debugf("failed to close SSH session: %s", err)
+			}
+		case <-done:
+		}
+	}()
+
+	oktetoLog.Infof("running command over ssh: '%s'", command)
+	return session.Run(command)
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+const stignoreFile = ".stignore"
+
+// ignoreMatcher decides which files are not synchronized following the syntax of the '.stignore' files:
+// the first pattern matching a file or one of its parent folders decides if the file is ignored
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	regex *regexp.Regexp
+	// glob is the original pattern, used to prune the remote listing
+	glob     string
+	negate   bool
+	anchored bool
+	foldCase bool
+}
+
+// loadIgnore reads the '.stignore' file of a synchronized folder, if it exists
+func loadIgnore(fileSystem afero.Fs, folder string) (*ignoreMatcher, error) {
+	content, err := afero.ReadFile(fileSystem, filepath.Join(folder, stignoreFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &ignoreMatcher{}, nil
+		}
+		return nil, err
+	}
+	return parseIgnore(content), nil
+}
+
+func parseIgnore(content []byte) *ignoreMatcher {
+	m := &ignoreMatcher{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if strings.HasPrefix(line, "#include") {
+			oktetoLog.Infof("'%s' is not supported by the ssh sync backend", line)
+			continue
+		}
+
+		p := ignorePattern{}
+		for {
+			switch {
+			case strings.HasPrefix(line, "!"):
+				p.negate = true
+				line = line[1:]
+				continue
+			case strings.HasPrefix(line, "(?d)"):
+				line = line[len("(?d)"):]
+				continue
+			case strings.HasPrefix(line, "(?i)"):
+				p.foldCase = true
+				line = line[len("(?i)"):]
+				continue
+			}
+			break
+		}
+		if strings.HasPrefix(line, "/") {
+			p.anchored = true
+			line = strings.TrimLeft(line, "/")
+		}
+		line = strings.TrimRight(line, "/")
+		if line == "" {
+			continue
+		}
+
+		regex, err := globToRegexp(line, p.foldCase)
+		if err != nil {
+			oktetoLog.Infof("ignoring invalid '.stignore' pattern '%s': %s", line, err)
+			continue
+		}
+		p.regex = regex
+		p.glob = line
+		m.patterns = append(m.patterns, p)
+	}
+	return m
+}
+
+// globToRegexp translates a glob pattern where '*' and '?' don't match '/' and '**' matches anything
+func globToRegexp(glob string, foldCase bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	if foldCase {
+		sb.WriteString("(?i)")
+	}
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end == -1 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+				continue
+			}
+			sb.WriteString(`\\`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// match returns if a file, given by its slash separated path relative to the synchronized folder, is ignored
+func (m *ignoreMatcher) match(name string) bool {
+	components := strings.Split(name, "/")
+	for _, p := range m.patterns {
+		if p.matches(components) {
+			return !p.negate
+		}
+	}
+	return false
+}
+
+// matches returns if the pattern matches the path or one of its parent folders
+func (p ignorePattern) matches(components []string) bool {
+	for end := 1; end <= len(components); end++ {
+		if p.anchored {
+			if p.regex.MatchString(path.Join(components[:end]...)) {
+				return true
+			}
+			continue
+		}
+		for start := 0; start < end; start++ {
+			if p.regex.MatchString(path.Join(components[start:end]...)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pruneNames returns the names of the folders and files that can be excluded when listing the remote folder.
+// Nothing is pruned when there are negated patterns, as they could include files inside ignored folders
+func (m *ignoreMatcher) pruneNames() []ignorePattern {
+	result := []ignorePattern{}
+	for _, p := range m.patterns {
+		if p.negate {
+			return nil
+		}
+		if p.anchored || strings.Contains(p.glob, "/") || strings.Contains(p.glob, "**") || strings.Contains(p.glob, "\\") {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
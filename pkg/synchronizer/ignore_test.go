@@ -0,0 +1,69 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	m := parseIgnore([]byte(`// dependencies
+node_modules
+/build
+*.log
+(?i)*.TMP
+docs/**/*.pdf
+!/dist/keep.js
+(?d)dist
+#include other
+`))
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "main.go", expected: false},
+		{path: "node_modules", expected: true},
+		{path: "node_modules/react/index.js", expected: true},
+		{path: "web/node_modules/react/index.js", expected: true},
+		{path: "build/app", expected: true},
+		{path: "src/build/app", expected: false},
+		{path: "server.log", expected: true},
+		{path: "logs/server.log", expected: true},
+		{path: "cache.tmp", expected: true},
+		{path: "docs/guides/v1/manual.pdf", expected: true},
+		{path: "docs/manual.md", expected: false},
+		{path: "dist/keep.js", expected: false},
+		{path: "dist/bundle.js", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, m.match(tt.path))
+		})
+	}
+}
+
+func TestIgnorePruneNames(t *testing.T) {
+	m := parseIgnore([]byte("node_modules\n/build\n*.log\ndocs/*.pdf\n"))
+	globs := []string{}
+	for _, p := range m.pruneNames() {
+		globs = append(globs, p.glob)
+	}
+	assert.Equal(t, []string{"node_modules", "*.log"}, globs)
+
+	m = parseIgnore([]byte("node_modules\n!node_modules/keep\n"))
+	assert.Empty(t, m.pruneNames())
+}
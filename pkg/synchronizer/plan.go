@@ -0,0 +1,170 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+// fileState is the state of a synchronized file. Hash is computed only when size or modification time change
+type fileState struct {
+	Hash    string
+	Size    int64
+	ModTime int64
+}
+
+// plan is the list of operations needed to synchronize a folder. Paths are slash separated and relative to the folder
+type plan struct {
+	upload       []string
+	download     []string
+	removeRemote []string
+	removeLocal  []string
+	conflicts    []string
+}
+
+func (p *plan) isEmpty() bool {
+	return len(p.upload) == 0 && len(p.download) == 0 && len(p.removeRemote) == 0 && len(p.removeLocal) == 0
+}
+
+// scanLocal returns the state of the files of a local folder, reusing the hashes of the previous scan for unmodified files
+func scanLocal(fileSystem afero.Fs, folder string, ignore *ignoreMatcher, previous map[string]fileState) (map[string]fileState, error) {
+	result := map[string]fileState{}
+	err := afero.Walk(fileSystem, folder, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(folder, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			if ignore.match(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || rel == stignoreFile || ignore.match(rel) {
+			return nil
+		}
+
+		state := fileState{Size: info.Size(), ModTime: info.ModTime().Unix()}
+		if prev, ok := previous[rel]; ok && prev.Size == state.Size && prev.ModTime == state.ModTime {
+			state.Hash = prev.Hash
+		} else {
+			state.Hash, err = hashFile(fileSystem, p)
+			if err != nil {
+				return err
+			}
+		}
+		result[rel] = state
+		return nil
+	})
+	return result, err
+}
+
+func hashFile(fileSystem afero.Fs, p string) (string, error) {
+	f, err := fileSystem.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// computePlan compares the local and remote files with the ones of the last synchronization.
+// Changes on both sides are conflicts: the local version wins unless the local file was deleted
+func computePlan(local, remote map[string]fileState, synced map[string]string, mode string) *plan {
+	paths := map[string]bool{}
+	for p := range local {
+		paths[p] = true
+	}
+	for p := range remote {
+		paths[p] = true
+	}
+	for p := range synced {
+		paths[p] = true
+	}
+
+	result := &plan{}
+	for p := range paths {
+		localHash := local[p].Hash
+		remoteHash := remote[p].Hash
+		if localHash == remoteHash {
+			continue
+		}
+		localChanged := localHash != synced[p]
+		remoteChanged := remoteHash != synced[p]
+
+		switch {
+		case mode == model.SyncModeSend:
+			if localChanged {
+				result.push(p, localHash)
+			}
+		case mode == model.SyncModeReceive:
+			if remoteChanged {
+				result.pull(p, remoteHash)
+			}
+		case localChanged && remoteChanged:
+			if synced[p] != "" {
+				result.conflicts = append(result.conflicts, p)
+			}
+			if localHash == "" {
+				result.pull(p, remoteHash)
+			} else {
+				result.push(p, localHash)
+			}
+		case localChanged:
+			result.push(p, localHash)
+		case remoteChanged:
+			result.pull(p, remoteHash)
+		}
+	}
+
+	for _, list := range [][]string{result.upload, result.download, result.removeRemote, result.removeLocal, result.conflicts} {
+		sort.Strings(list)
+	}
+	return result
+}
+
+func (p *plan) push(path, localHash string) {
+	if localHash == "" {
+		p.removeRemote = append(p.removeRemote, path)
+		return
+	}
+	p.upload = append(p.upload, path)
+}
+
+func (p *plan) pull(path, remoteHash string) {
+	if remoteHash == "" {
+		p.removeLocal = append(p.removeLocal, path)
+		return
+	}
+	p.download = append(p.download, path)
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePlan(t *testing.T) {
+	tests := []struct {
+		local    map[string]fileState
+		remote   map[string]fileState
+		synced   map[string]string
+		expected *plan
+		name     string
+		mode     string
+	}{
+		{
+			name:   "initial synchronization",
+			mode:   model.SyncModeBoth,
+			local:  map[string]fileState{"a": {Hash: "1"}, "b": {Hash: "2"}, "same": {Hash: "3"}},
+			remote: map[string]fileState{"b": {Hash: "old"}, "c": {Hash: "4"}, "same": {Hash: "3"}},
+			synced: map[string]string{},
+			expected: &plan{
+				upload:   []string{"a", "b"},
+				download: []string{"c"},
+			},
+		},
+		{
+			name:   "changes on one side",
+			mode:   model.SyncModeBoth,
+			local:  map[string]fileState{"a": {Hash: "1-new"}, "b": {Hash: "2"}, "c": {Hash: "3"}},
+			remote: map[string]fileState{"a": {Hash: "1"}, "b": {Hash: "2-new"}, "d": {Hash: "4"}},
+			synced: map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"},
+			expected: &plan{
+				upload:       []string{"a"},
+				download:     []string{"b"},
+				removeRemote: []string{"d"},
+				removeLocal:  []string{"c"},
+			},
+		},
+		{
+			name:   "conflicts",
+			mode:   model.SyncModeBoth,
+			local:  map[string]fileState{"a": {Hash: "1-local"}},
+			remote: map[string]fileState{"a": {Hash: "1-remote"}, "b": {Hash: "2-remote"}},
+			synced: map[string]string{"a": "1", "b": "2"},
+			expected: &plan{
+				upload:    []string{"a"},
+				download:  []string{"b"},
+				conflicts: []string{"a", "b"},
+			},
+		},
+		{
+			name:   "send",
+			mode:   model.SyncModeSend,
+			local:  map[string]fileState{"a": {Hash: "1-new"}, "b": {Hash: "2"}},
+			remote: map[string]fileState{"a": {Hash: "1"}, "b": {Hash: "2-new"}, "c": {Hash: "3"}, "d": {Hash: "4"}},
+			synced: map[string]string{"a": "1", "b": "2", "d": "4"},
+			expected: &plan{
+				upload:       []string{"a"},
+				removeRemote: []string{"d"},
+			},
+		},
+		{
+			name:   "receive",
+			mode:   model.SyncModeReceive,
+			local:  map[string]fileState{"a": {Hash: "1-new"}, "b": {Hash: "2"}, "c": {Hash: "3"}},
+			remote: map[string]fileState{"a": {Hash: "1"}, "b": {Hash: "2-new"}, "d": {Hash: "4"}},
+			synced: map[string]string{"a": "1", "b": "2", "c": "3"},
+			expected: &plan{
+				download:    []string{"b", "d"},
+				removeLocal: []string{"c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := computePlan(tt.local, tt.remote, tt.synced, tt.mode)
+			assert.ElementsMatch(t, tt.expected.upload, result.upload)
+			assert.ElementsMatch(t, tt.expected.download, result.download)
+			assert.ElementsMatch(t, tt.expected.removeRemote, result.removeRemote)
+			assert.ElementsMatch(t, tt.expected.removeLocal, result.removeLocal)
+			assert.ElementsMatch(t, tt.expected.conflicts, result.conflicts)
+		})
+	}
+}
+
+func TestBatches(t *testing.T) {
+	states := map[string]fileState{
+		"a": {Size: maxBatchBytes - 10},
+		"b": {Size: 20},
+		"c": {Size: 5},
+	}
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}}, batches([]string{"a", "b", "c"}, states))
+
+	names := make([]string, maxBatchFiles+1)
+	for i := range names {
+		names[i] = "file"
+	}
+	result := batches(names, nil)
+	assert.Len(t, result, 2)
+	assert.Len(t, result[1], 1)
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"golang.org/x/crypto/ssh"
+)
+
+// remote runs the file operations in the development container
+type remote interface {
+	// list returns the size and modification time of the files of a folder, excluding the pruned names
+	list(ctx context.Context, dir string, prune []ignorePattern) (map[string]fileState, error)
+	// hash returns the content hash of the given files of a folder
+	hash(ctx context.Context, dir string, paths []string) (map[string]string, error)
+	// upload extracts a tar stream in a folder
+	upload(ctx context.Context, dir string, r io.Reader) error
+	// download writes a tar stream with the given files of a folder
+	download(ctx context.Context, dir string, paths []string, w io.Writer) error
+	// remove deletes the given files of a folder
+	remove(ctx context.Context, dir string, paths []string) error
+	ping(ctx context.Context) bool
+	close() error
+}
+
+// requiredToolsCheck fails with the name of the first tool of the development container that is missing or that doesn't
+// support the options used to transfer file names separated by NUL characters
+const requiredToolsCheck = `for tool in find sha256sum xargs tar rm mkdir; do command -v "$tool" >/dev/null 2>&1 || { echo "$tool"; exit 1; }; done
+find . -maxdepth 0 -printf '' >/dev/null 2>&1 || { echo "find -printf"; exit 1; }
+sha256sum -z /dev/null >/dev/null 2>&1 || { echo "sha256sum -z"; exit 1; }
+printf '' | xargs -0 true >/dev/null 2>&1 || { echo "xargs -0"; exit 1; }
+tar --null -cf /dev/null -T /dev/null >/dev/null 2>&1 || { echo "tar --null"; exit 1; }`
+
+// sshRemote runs the file operations with shell commands over the SSH connection with the remote okteto agent
+type sshRemote struct {
+	client *ssh.Client
+}
+
+// checkTools returns an error if the shell commands used by the file operations are not available in the development container.
+// They are the GNU versions of find, sha256sum, xargs and tar, which are not included in busybox based or distroless images
+func (r *sshRemote) checkTools(ctx context.Context) error {
+	out := &bytes.Buffer{}
+	session, err := r.client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer session.Close()
+	session.Stdout = out
+	session.Stderr = io.Discard
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := session.Close(); err != nil {
+				oktetoLog.Debugf("failed to close SSH session: %s", err)
+			}
+		case <-done:
+		}
+	}()
+
+	if err := session.Run(requiredToolsCheck); err != nil {
+		missing := strings.TrimSpace(out.String())
+		if missing == "" {
+			missing = "sh"
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '%s' sync backend can't run '%s' in your development container", model.SyncBackendSSH, missing),
+			Hint: fmt.Sprintf("Install a shell and the GNU versions of find, sha256sum, xargs and tar in the image of your development container or use the '%s' sync backend", model.SyncBackendSyncthing),
+		}
+	}
+	return nil
+}
+
+func (r *sshRemote) run(ctx context.Context, cmd string, stdin io.Reader, stdout io.Writer) error {
+	session, err := r.client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer session.Close()
+
+	stderr := &bytes.Buffer{}
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := session.Close(); err != nil {
+				oktetoLog.Debugf("failed to close SSH session: %s", err)
+			}
+		case <-done:
+		}
+	}()
+
+	if err := session.Run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("'%s' failed: %w: %s", cmd, err, msg)
+		}
+		return fmt.Errorf("'%s' failed: %w", cmd, err)
+	}
+	return nil
+}
+
+func (r *sshRemote) list(ctx context.Context, dir string, prune []ignorePattern) (map[string]fileState, error) {
+	pruneExpr := ""
+	if len(prune) > 0 {
+		exprs := make([]string, 0, len(prune))
+		for _, p := range prune {
+			nameFlag := "-name"
+			if p.foldCase {
+				nameFlag = "-iname"
+			}
+			exprs = append(exprs, fmt.Sprintf("%s %s", nameFlag, shellescape.Quote(p.glob)))
+		}
+		pruneExpr = fmt.Sprintf(`\( %s \) -prune -o `, strings.Join(exprs, " -o "))
+	}
+	quotedDir := shellescape.Quote(dir)
+	cmd := fmt.Sprintf("if [ -d %s ]; then cd %s && find . %s-type f -printf '%%s %%T@ %%p\\0'; fi", quotedDir, quotedDir, pruneExpr)
+
+	out := &bytes.Buffer{}
+	if err := r.run(ctx, cmd, nil, out); err != nil {
+		return nil, err
+	}
+	return parseListOutput(out)
+}
+
+// parseListOutput parses NUL terminated entries with the format '<size> <modification time> ./<path>'.
+// The modification time has a fractional part that is discarded
+func parseListOutput(r io.Reader) (map[string]fileState, error) {
+	result := map[string]fileState{}
+	scanner := newNulScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), " ", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		size, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote file size '%s': %w", parts[0], err)
+		}
+		seconds, _, _ := strings.Cut(parts[1], ".")
+		modTime, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote modification time '%s': %w", parts[1], err)
+		}
+		result[strings.TrimPrefix(parts[2], "./")] = fileState{Size: size, ModTime: modTime}
+	}
+	return result, scanner.Err()
+}
+
+func (r *sshRemote) hash(ctx context.Context, dir string, paths []string) (map[string]string, error) {
+	out := &bytes.Buffer{}
+	cmd := fmt.Sprintf("cd %s && xargs -0 sha256sum -z --", shellescape.Quote(dir))
+	if err := r.run(ctx, cmd, nulSeparated(paths), out); err != nil {
+		return nil, err
+	}
+	return parseHashOutput(out)
+}
+
+// parseHashOutput parses the output of sha256sum -z: NUL terminated entries with the format '<hash>  <path>'
+func parseHashOutput(r io.Reader) (map[string]string, error) {
+	result := map[string]string{}
+	scanner := newNulScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "  ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		result[strings.TrimPrefix(parts[1], "./")] = parts[0]
+	}
+	return result, scanner.Err()
+}
+
+func (r *sshRemote) upload(ctx context.Context, dir string, tarStream io.Reader) error {
+	quotedDir := shellescape.Quote(dir)
+	cmd := fmt.Sprintf("mkdir -p %s && tar -xf - -C %s", quotedDir, quotedDir)
+	return r.run(ctx, cmd, tarStream, io.Discard)
+}
+
+func (r *sshRemote) download(ctx context.Context, dir string, paths []string, w io.Writer) error {
+	cmd := fmt.Sprintf("cd %s && tar --null -cf - -T -", shellescape.Quote(dir))
+	return r.run(ctx, cmd, nulSeparated(paths), w)
+}
+
+func (r *sshRemote) remove(ctx context.Context, dir string, paths []string) error {
+	cmd := fmt.Sprintf("cd %s && xargs -0 rm -f --", shellescape.Quote(dir))
+	return r.run(ctx, cmd, nulSeparated(paths), io.Discard)
+}
+
+func (r *sshRemote) ping(_ context.Context) bool {
+	if _, _, err := r.client.SendRequest("dev.okteto.com/keepalive", true, nil); err != nil {
+		oktetoLog.Infof("ssh sync ping failed: %s", err)
+		return false
+	}
+	return true
+}
+
+func (r *sshRemote) close() error {
+	return r.client.Close()
+}
+
+// newNulScanner returns a scanner of NUL terminated entries, so file names can contain new lines
+func newNulScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, 0); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	return scanner
+}
+
+func nulSeparated(paths []string) io.Reader {
+	buf := &bytes.Buffer{}
+	for _, p := range paths {
+		buf.WriteString(p)
+		buf.WriteByte(0)
+	}
+	return buf
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListOutput(t *testing.T) {
+	out := "12 1697364000.1234567890 ./main.go\x000 1697364001.0000000000 ./dir/file with spaces.txt\x003 1697364002 ./new\nline.txt\x00"
+	result, err := parseListOutput(strings.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]fileState{
+		"main.go":                  {Size: 12, ModTime: 1697364000},
+		"dir/file with spaces.txt": {Size: 0, ModTime: 1697364001},
+		"new\nline.txt":            {Size: 3, ModTime: 1697364002},
+	}, result)
+
+	_, err = parseListOutput(strings.NewReader("abc 1697364000 ./main.go\x00"))
+	assert.Error(t, err)
+}
+
+func TestParseHashOutput(t *testing.T) {
+	out := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  ./main.go\x00" +
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  dir/a\nb.txt\x00"
+	result, err := parseHashOutput(strings.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"main.go":      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"dir/a\nb.txt": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}, result)
+}
@@ -0,0 +1,575 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/spf13/afero"
+)
+
+const (
+	// sshSyncInterval is the time between two synchronizations once the initial synchronization is completed
+	sshSyncInterval = 2 * time.Second
+
+	// sshPingInterval is the time between two checks of the SSH connection
+	sshPingInterval = 10 * time.Second
+
+	// sshMaxRetries is the number of consecutive failures before sending the disconnect signal
+	sshMaxRetries = 3
+
+	// maxBatchFiles and maxBatchBytes limit the size of each transfer
+	maxBatchFiles = 500
+	maxBatchBytes = 64 * 1024 * 1024
+)
+
+// SSH synchronizes the files over the SSH connection with the remote okteto agent of the development container.
+// It polls the local and remote folders instead of watching them, and only transfers the files whose content hash changed
+type SSH struct {
+	fs                    afero.Fs
+	connect               func(ctx context.Context) (remote, error)
+	remote                remote
+	folders               []*sshFolder
+	inSynchronizationFile string
+	interval              time.Duration
+	sendReceive           bool
+	mu                    sync.Mutex
+}
+
+type sshFolder struct {
+	ignore     *ignoreMatcher
+	local      map[string]fileState
+	remote     map[string]fileState
+	synced     map[string]string
+	localPath  string
+	remotePath string
+	mode       string
+}
+
+// NewSSH constructs the ssh synchronizer of a development container
+func NewSSH(dev *model.Dev) (*SSH, error) {
+	s := &SSH{
+		fs:       afero.NewOsFs(),
+		interval: sshSyncInterval,
+		connect: func(ctx context.Context) (remote, error) {
+			client, err := ssh.Dial(ctx, dev.Interface, dev.RemotePort)
+			if err != nil {
+				return nil, err
+			}
+			r := &sshRemote{client: client}
+			if err := r.checkTools(ctx); err != nil {
+				if closeErr := client.Close(); closeErr != nil {
+					oktetoLog.Debugf("failed to close SSH connection: %s", closeErr)
+				}
+				return nil, err
+			}
+			return r, nil
+		},
+	}
+	for _, folder := range dev.Sync.Folders {
+		isSubPath, err := dev.IsSubPathFolder(folder.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		if isSubPath {
+			continue
+		}
+		ignore, err := loadIgnore(s.fs, folder.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the '%s' file of '%s': %w", stignoreFile, folder.LocalPath, err)
+		}
+		s.folders = append(s.folders, &sshFolder{
+			localPath:  folder.LocalPath,
+			remotePath: folder.RemotePath,
+			mode:       folder.GetMode(),
+			ignore:     ignore,
+			synced:     map[string]string{},
+		})
+	}
+	return s, nil
+}
+
+// Run doesn't start any local process: the files are transferred by okteto itself
+func (*SSH) Run() error {
+	return nil
+}
+
+// WaitForPing connects to the SSH server of the development container
+func (s *SSH) WaitForPing(ctx context.Context, local bool) error {
+	if local {
+		return nil
+	}
+	r, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	s.remote = r
+	return nil
+}
+
+// Ping returns if the SSH connection with the development container is alive
+func (s *SSH) Ping(ctx context.Context, local bool) bool {
+	if local {
+		return true
+	}
+	return s.remote != nil && s.remote.ping(ctx)
+}
+
+// WaitForScanning computes the state of the local or the remote files
+func (s *SSH) WaitForScanning(ctx context.Context, local bool) error {
+	for _, f := range s.folders {
+		if local {
+			if err := s.scanLocal(f); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.scanRemote(ctx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitForConnected returns immediately: the connection is established by WaitForPing
+func (*SSH) WaitForConnected(context.Context) error {
+	return nil
+}
+
+// WaitForCompletion transfers the files that differ between the local and the remote folders.
+// Files modified on both sides keep the local version
+func (s *SSH) WaitForCompletion(ctx context.Context, reporter chan float64) error {
+	defer close(reporter)
+
+	plans := make([]*plan, len(s.folders))
+	var total int64
+	for i, f := range s.folders {
+		plans[i] = computePlan(f.local, f.remote, f.synced, f.mode)
+		total += sumSizes(plans[i].upload, f.local) + sumSizes(plans[i].download, f.remote)
+	}
+
+	var transferred int64
+	progress := func(n int64) {
+		transferred += n
+		if total > 0 {
+			reporter <- float64(transferred) / float64(total) * 100
+		}
+	}
+	for i, f := range s.folders {
+		if err := s.apply(ctx, f, plans[i], progress); err != nil {
+			return err
+		}
+	}
+	reporter <- 100
+	return nil
+}
+
+// GetInSynchronizationFile returns the largest file of the current transfer
+func (s *SSH) GetInSynchronizationFile(context.Context) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inSynchronizationFile
+}
+
+func (s *SSH) setInSynchronizationFile(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inSynchronizationFile = name
+}
+
+// StartSendReceive starts synchronizing the changes periodically
+func (s *SSH) StartSendReceive(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendReceive = true
+	return nil
+}
+
+func (s *SSH) isSendReceive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sendReceive
+}
+
+// Monitor sends a message to disconnect if the SSH connection is lost
+func (s *SSH) Monitor(ctx context.Context, disconnect chan error) {
+	ticker := time.NewTicker(sshPingInterval)
+	defer ticker.Stop()
+	retries := 0
+	for {
+		select {
+		case <-ticker.C:
+			if s.Ping(ctx, false) {
+				retries = 0
+				continue
+			}
+			retries++
+			oktetoLog.Infof("ssh sync ping error %d", retries)
+			if retries >= sshMaxRetries {
+				oktetoLog.Infof("ssh sync ping error, sending disconnect signal")
+				disconnect <- oktetoErrors.ErrLostSyncthing
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// MonitorStatus synchronizes the changes periodically and sends a message to disconnect if the synchronization keeps failing
+func (s *SSH) MonitorStatus(ctx context.Context, disconnect chan error) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	retries := 0
+	for {
+		select {
+		case <-ticker.C:
+			if !s.isSendReceive() {
+				continue
+			}
+			if err := s.syncAll(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				retries++
+				oktetoLog.Infof("ssh sync error %d: %s", retries, err)
+				if retries >= sshMaxRetries {
+					oktetoLog.Infof("ssh sync error, sending disconnect signal")
+					disconnect <- oktetoErrors.ErrLostSyncthing
+					return
+				}
+				continue
+			}
+			retries = 0
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetForwards returns no ports: the files are transferred over the SSH connection
+func (*SSH) GetForwards() []forward.Forward {
+	return nil
+}
+
+// SoftTerminate closes the SSH connection
+func (s *SSH) SoftTerminate() error {
+	if s.remote == nil {
+		return nil
+	}
+	return s.remote.close()
+}
+
+// HardTerminate closes the SSH connection
+func (s *SSH) HardTerminate() error {
+	return s.SoftTerminate()
+}
+
+// syncAll synchronizes the changes of every folder. The remote files of the send only folders are not listed again
+// once they are synchronized, as their remote changes are ignored
+func (s *SSH) syncAll(ctx context.Context) error {
+	for _, f := range s.folders {
+		if err := s.scanLocal(f); err != nil {
+			return err
+		}
+		if f.mode != model.SyncModeSend || f.remote == nil {
+			if err := s.scanRemote(ctx, f); err != nil {
+				return err
+			}
+		}
+		p := computePlan(f.local, f.remote, f.synced, f.mode)
+		if p.isEmpty() {
+			continue
+		}
+		if err := s.apply(ctx, f, p, func(int64) {}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SSH) scanLocal(f *sshFolder) error {
+	local, err := scanLocal(s.fs, f.localPath, f.ignore, f.local)
+	if err != nil {
+		return fmt.Errorf("failed to scan '%s': %w", f.localPath, err)
+	}
+	f.local = local
+	return nil
+}
+
+func (s *SSH) scanRemote(ctx context.Context, f *sshFolder) error {
+	listed, err := s.remote.list(ctx, f.remotePath, f.ignore.pruneNames())
+	if err != nil {
+		return fmt.Errorf("failed to scan '%s' in the development container: %w", f.remotePath, err)
+	}
+
+	result := map[string]fileState{}
+	toHash := []string{}
+	for p, state := range listed {
+		if p == stignoreFile || f.ignore.match(p) {
+			continue
+		}
+		if prev, ok := f.remote[p]; ok && prev.Size == state.Size && prev.ModTime == state.ModTime {
+			state.Hash = prev.Hash
+		} else {
+			toHash = append(toHash, p)
+		}
+		result[p] = state
+	}
+
+	for _, batch := range batches(toHash, nil) {
+		hashes, err := s.remote.hash(ctx, f.remotePath, batch)
+		if err != nil {
+			return fmt.Errorf("failed to hash the files of '%s' in the development container: %w", f.remotePath, err)
+		}
+		for p, h := range hashes {
+			if state, ok := result[p]; ok {
+				state.Hash = h
+				result[p] = state
+			}
+		}
+	}
+
+	// files deleted between listing and hashing
+	for p, state := range result {
+		if state.Hash == "" {
+			delete(result, p)
+		}
+	}
+	f.remote = result
+	return nil
+}
+
+// apply runs the operations of a plan and records the synchronized hashes
+func (s *SSH) apply(ctx context.Context, f *sshFolder, p *plan, progress func(int64)) error {
+	defer s.setInSynchronizationFile("")
+
+	for _, c := range p.conflicts {
+		kept := "local"
+		if f.local[c].Hash == "" {
+			kept = "remote"
+		}
+		oktetoLog.Warning("Sync conflict in '%s': the file was modified locally and in your development container, the %s version was kept", filepath.Join(f.localPath, filepath.FromSlash(c)), kept)
+	}
+
+	for _, batch := range batches(p.upload, f.local) {
+		s.setInSynchronizationFile(largestFile(batch, f.local))
+		if err := s.upload(ctx, f, batch); err != nil {
+			return err
+		}
+		for _, name := range batch {
+			f.synced[name] = f.local[name].Hash
+		}
+		progress(sumSizes(batch, f.local))
+	}
+
+	for _, batch := range batches(p.download, f.remote) {
+		s.setInSynchronizationFile(largestFile(batch, f.remote))
+		if err := s.download(ctx, f, batch); err != nil {
+			return err
+		}
+		for _, name := range batch {
+			f.synced[name] = f.remote[name].Hash
+		}
+		progress(sumSizes(batch, f.remote))
+	}
+
+	for _, batch := range batches(p.removeRemote, nil) {
+		if err := s.remote.remove(ctx, f.remotePath, batch); err != nil {
+			return fmt.Errorf("failed to remove files of '%s' in the development container: %w", f.remotePath, err)
+		}
+		for _, name := range batch {
+			delete(f.synced, name)
+		}
+	}
+
+	for _, name := range p.removeLocal {
+		if err := s.fs.Remove(filepath.Join(f.localPath, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove '%s': %w", name, err)
+		}
+		delete(f.synced, name)
+	}
+
+	for name, state := range f.local {
+		if f.remote[name].Hash == state.Hash {
+			f.synced[name] = state.Hash
+		}
+	}
+	return nil
+}
+
+func (s *SSH) upload(ctx context.Context, f *sshFolder, names []string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(s.fs, f.localPath, names, pw))
+	}()
+	err := s.remote.upload(ctx, f.remotePath, pr)
+	pr.CloseWithError(err)
+	if err != nil {
+		return fmt.Errorf("failed to upload files to '%s' in the development container: %w", f.remotePath, err)
+	}
+	return nil
+}
+
+func (s *SSH) download(ctx context.Context, f *sshFolder, names []string) error {
+	pr, pw := io.Pipe()
+	downloadErr := make(chan error, 1)
+	go func() {
+		err := s.remote.download(ctx, f.remotePath, names, pw)
+		pw.CloseWithError(err)
+		downloadErr <- err
+	}()
+	err := extractTar(s.fs, f.localPath, pr)
+	if err == nil {
+		_, err = io.Copy(io.Discard, pr)
+	}
+	pr.CloseWithError(err)
+	if dErr := <-downloadErr; dErr != nil {
+		return fmt.Errorf("failed to download files from '%s' in the development container: %w", f.remotePath, dErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the files downloaded from the development container: %w", err)
+	}
+	return nil
+}
+
+func writeTar(fileSystem afero.Fs, dir string, names []string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, name := range names {
+		if err := addFileToTar(fileSystem, tw, dir, name); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func addFileToTar(fileSystem afero.Fs, tw *tar.Writer, dir, name string) error {
+	f, err := fileSystem.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// deleted after the scan, it will be synchronized in the next iteration
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, f, info.Size())
+	return err
+}
+
+func extractTar(fileSystem afero.Fs, dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(header.Name, "./")))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid file path '%s'", header.Name)
+		}
+		target := filepath.Join(dir, name)
+		if err := fileSystem.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		f, err := fileSystem.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode).Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if err := fileSystem.Chtimes(target, header.ModTime, header.ModTime); err != nil {
+			oktetoLog.Infof("failed to set the modification time of '%s': %s", target, err)
+		}
+	}
+}
+
+// batches splits a list of files in groups limited by number of files and, when states are given, by size
+func batches(names []string, states map[string]fileState) [][]string {
+	result := [][]string{}
+	current := []string{}
+	var size int64
+	for _, name := range names {
+		fileSize := states[name].Size
+		if len(current) > 0 && (len(current) >= maxBatchFiles || size+fileSize > maxBatchBytes) {
+			result = append(result, current)
+			current = []string{}
+			size = 0
+		}
+		current = append(current, name)
+		size += fileSize
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+func sumSizes(names []string, states map[string]fileState) int64 {
+	var result int64
+	for _, name := range names {
+		result += states[name].Size
+	}
+	return result
+}
+
+func largestFile(names []string, states map[string]fileState) string {
+	result := ""
+	var largest int64 = -1
+	for _, name := range names {
+		if states[name].Size > largest {
+			result = name
+			largest = states[name].Size
+		}
+	}
+	return result
+}
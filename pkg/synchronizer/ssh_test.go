@@ -0,0 +1,243 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemote runs the file operations in an in-memory file system
+type fakeRemote struct {
+	fs afero.Fs
+}
+
+func (r *fakeRemote) list(_ context.Context, dir string, _ []ignorePattern) (map[string]fileState, error) {
+	result := map[string]fileState{}
+	err := afero.Walk(r.fs, dir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		result[filepath.ToSlash(rel)] = fileState{Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+		return nil
+	})
+	return result, err
+}
+
+func (r *fakeRemote) hash(_ context.Context, dir string, paths []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, p := range paths {
+		h, err := hashFile(r.fs, filepath.Join(dir, p))
+		if err != nil {
+			return nil, err
+		}
+		result[p] = h
+	}
+	return result, nil
+}
+
+func (r *fakeRemote) upload(_ context.Context, dir string, tarStream io.Reader) error {
+	return extractTar(r.fs, dir, tarStream)
+}
+
+func (r *fakeRemote) download(_ context.Context, dir string, paths []string, w io.Writer) error {
+	return writeTar(r.fs, dir, paths, w)
+}
+
+func (r *fakeRemote) remove(_ context.Context, dir string, paths []string) error {
+	for _, p := range paths {
+		if err := r.fs.Remove(filepath.Join(dir, p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*fakeRemote) ping(context.Context) bool {
+	return true
+}
+
+func (*fakeRemote) close() error {
+	return nil
+}
+
+func writeFile(t *testing.T, fileSystem afero.Fs, name, content string) {
+	t.Helper()
+	require.NoError(t, afero.WriteFile(fileSystem, name, []byte(content), 0600))
+	// the modification time has a resolution of seconds in the remote listing
+	modTime := time.Now().Add(time.Duration(len(content)) * time.Second)
+	require.NoError(t, fileSystem.Chtimes(name, modTime, modTime))
+}
+
+func readFile(t *testing.T, fileSystem afero.Fs, name string) string {
+	t.Helper()
+	content, err := afero.ReadFile(fileSystem, name)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func newTestSSH(t *testing.T, localFs, remoteFs afero.Fs, mode string) *SSH {
+	t.Helper()
+	ignore, err := loadIgnore(localFs, "/local")
+	require.NoError(t, err)
+	return &SSH{
+		fs:       localFs,
+		interval: time.Millisecond,
+		connect: func(context.Context) (remote, error) {
+			return &fakeRemote{fs: remoteFs}, nil
+		},
+		folders: []*sshFolder{
+			{
+				localPath:  "/local",
+				remotePath: "/app",
+				mode:       mode,
+				ignore:     ignore,
+				synced:     map[string]string{},
+			},
+		},
+	}
+}
+
+func startTestSSH(t *testing.T, s *SSH) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, s.Run())
+	require.NoError(t, s.WaitForPing(ctx, true))
+	require.NoError(t, s.WaitForPing(ctx, false))
+	require.NoError(t, s.WaitForScanning(ctx, true))
+	require.NoError(t, s.WaitForScanning(ctx, false))
+	require.NoError(t, s.WaitForConnected(ctx))
+
+	reporter := make(chan float64)
+	progress := []float64{}
+	done := make(chan struct{})
+	go func() {
+		for p := range reporter {
+			progress = append(progress, p)
+		}
+		close(done)
+	}()
+	require.NoError(t, s.WaitForCompletion(ctx, reporter))
+	<-done
+	require.NotEmpty(t, progress)
+	assert.Equal(t, float64(100), progress[len(progress)-1])
+}
+
+func TestSSHSynchronization(t *testing.T) {
+	localFs := afero.NewMemMapFs()
+	remoteFs := afero.NewMemMapFs()
+	writeFile(t, localFs, "/local/.stignore", "node_modules\n")
+	writeFile(t, localFs, "/local/a.txt", "local a")
+	writeFile(t, localFs, "/local/dir/b.txt", "b")
+	writeFile(t, localFs, "/local/node_modules/x.js", "x")
+	writeFile(t, remoteFs, "/app/a.txt", "remote a")
+	writeFile(t, remoteFs, "/app/c.txt", "c")
+	writeFile(t, remoteFs, "/app/node_modules/y.js", "y")
+
+	s := newTestSSH(t, localFs, remoteFs, model.SyncModeBoth)
+	startTestSSH(t, s)
+
+	// initial synchronization: the local version wins
+	assert.Equal(t, "local a", readFile(t, remoteFs, "/app/a.txt"))
+	assert.Equal(t, "b", readFile(t, remoteFs, "/app/dir/b.txt"))
+	assert.Equal(t, "c", readFile(t, localFs, "/local/c.txt"))
+	exists, err := afero.Exists(remoteFs, "/app/node_modules/x.js")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.Exists(localFs, "/local/node_modules/y.js")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.Exists(remoteFs, "/app/.stignore")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// changes on each side
+	writeFile(t, remoteFs, "/app/c.txt", "remote c")
+	require.NoError(t, localFs.Remove("/local/dir/b.txt"))
+	writeFile(t, localFs, "/local/d.txt", "d")
+	require.NoError(t, s.syncAll(context.Background()))
+
+	assert.Equal(t, "remote c", readFile(t, localFs, "/local/c.txt"))
+	assert.Equal(t, "d", readFile(t, remoteFs, "/app/d.txt"))
+	exists, err = afero.Exists(remoteFs, "/app/dir/b.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// nothing to do once synchronized
+	require.NoError(t, s.scanLocal(s.folders[0]))
+	require.NoError(t, s.scanRemote(context.Background(), s.folders[0]))
+	assert.True(t, computePlan(s.folders[0].local, s.folders[0].remote, s.folders[0].synced, model.SyncModeBoth).isEmpty())
+}
+
+func TestSSHSynchronizationReceiveMode(t *testing.T) {
+	localFs := afero.NewMemMapFs()
+	remoteFs := afero.NewMemMapFs()
+	writeFile(t, localFs, "/local/a.txt", "local a")
+	writeFile(t, localFs, "/local/local-only.txt", "local")
+	writeFile(t, remoteFs, "/app/a.txt", "remote a")
+
+	s := newTestSSH(t, localFs, remoteFs, model.SyncModeReceive)
+	startTestSSH(t, s)
+
+	assert.Equal(t, "remote a", readFile(t, localFs, "/local/a.txt"))
+	exists, err := afero.Exists(remoteFs, "/app/local-only.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	writeFile(t, localFs, "/local/a.txt", "local change")
+	require.NoError(t, s.syncAll(context.Background()))
+	assert.Equal(t, "remote a", readFile(t, remoteFs, "/app/a.txt"))
+}
+
+func TestSSHMonitorStatus(t *testing.T) {
+	localFs := afero.NewMemMapFs()
+	remoteFs := afero.NewMemMapFs()
+	writeFile(t, localFs, "/local/a.txt", "a")
+
+	s := newTestSSH(t, localFs, remoteFs, model.SyncModeBoth)
+	startTestSSH(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	disconnect := make(chan error, 1)
+	require.NoError(t, s.StartSendReceive(ctx))
+	writeFile(t, localFs, "/local/new.txt", "new")
+	go s.MonitorStatus(ctx, disconnect)
+
+	assert.Eventually(t, func() bool {
+		exists, err := afero.Exists(remoteFs, "/app/new.txt")
+		return err == nil && exists
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, disconnect)
+}
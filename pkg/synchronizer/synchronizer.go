@@ -0,0 +1,63 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+// Synchronizer synchronizes the local folders of a development container with its remote folders
+type Synchronizer interface {
+	// Run starts the local side of the synchronization service
+	Run() error
+
+	// WaitForPing waits until the local or the remote side of the synchronization service is reachable
+	WaitForPing(ctx context.Context, local bool) error
+
+	// Ping returns if the local or the remote side of the synchronization service is reachable
+	Ping(ctx context.Context, local bool) bool
+
+	// WaitForScanning waits until the local or the remote folders are scanned
+	WaitForScanning(ctx context.Context, local bool) error
+
+	// WaitForConnected waits until the local and the remote sides are connected
+	WaitForConnected(ctx context.Context) error
+
+	// WaitForCompletion waits until the initial synchronization is completed, reporting its progress.
+	// The reporter channel is closed when it returns
+	WaitForCompletion(ctx context.Context, reporter chan float64) error
+
+	// GetInSynchronizationFile returns the largest file being synchronized, if any
+	GetInSynchronizationFile(ctx context.Context) string
+
+	// StartSendReceive synchronizes the changes in both directions once the initial synchronization is completed
+	StartSendReceive(ctx context.Context) error
+
+	// Monitor sends an error to disconnect when the connection with the remote side is lost
+	Monitor(ctx context.Context, disconnect chan error)
+
+	// MonitorStatus sends an error to disconnect when the synchronization fails
+	MonitorStatus(ctx context.Context, disconnect chan error)
+
+	// GetForwards returns the ports that must be forwarded to the development container
+	GetForwards() []forward.Forward
+
+	// SoftTerminate stops the synchronization service
+	SoftTerminate() error
+
+	// HardTerminate stops the synchronization service, including the leftovers of previous executions
+	HardTerminate() error
+}
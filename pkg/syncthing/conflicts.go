@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+const (
+	// DefaultMaxConflicts is the number of conflict copies kept per file when sync conflicts are preserved
+	DefaultMaxConflicts = 10
+
+	// LocalVersion identifies the version of a conflicted file modified in the local folder
+	LocalVersion = "local"
+
+	// RemoteVersion identifies the version of a conflicted file modified in the development container
+	RemoteVersion = "remote"
+
+	conflictTimeLayout = "20060102-150405"
+)
+
+// conflictRegex matches the names of the conflict copies created by syncthing: <name>.sync-conflict-<date>-<time>-<device>[.<ext>]
+var conflictRegex = regexp.MustCompile(`^(.*)\.sync-conflict-(\d{8}-\d{6})-([A-Z0-9]{7})(\.[^/]*)?$`)
+
+// Conflict represents a file modified at the same time in the local folder and in the development container.
+// Syncthing keeps one version in the original file and the other one in a conflict copy
+type Conflict struct {
+	Date time.Time `json:"date"`
+	// Folder is the local path of the synchronized folder
+	Folder string `json:"folder"`
+	// Path is the path of the conflicted file, relative to the folder
+	Path string `json:"path"`
+	// ConflictPath is the path of the conflict copy, relative to the folder
+	ConflictPath string `json:"conflictPath"`
+	// ModifiedBy is the side that modified the version kept in the conflict copy
+	ModifiedBy string `json:"modifiedBy"`
+}
+
+// DiskEvent represents a LocalChangeDetected or RemoteChangeDetected event in syncthing.
+type DiskEvent struct {
+	Type string        `json:"type"`
+	Data DataDiskEvent `json:"data"`
+	ID   int           `json:"id"`
+}
+
+// DataDiskEvent represents the data of a disk event in syncthing.
+type DataDiskEvent struct {
+	Action string `json:"action"`
+	Folder string `json:"folder"`
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+}
+
+// ParseConflict returns the conflict represented by a conflict copy, or false if the path isn't a conflict copy
+func ParseConflict(folder, conflictPath string) (Conflict, bool) {
+	dir, name := filepath.Split(conflictPath)
+	matches := conflictRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return Conflict{}, false
+	}
+	date, err := time.Parse(conflictTimeLayout, matches[2])
+	if err != nil {
+		return Conflict{}, false
+	}
+
+	modifiedBy := matches[3]
+	switch modifiedBy {
+	case LocalDeviceID[:7]:
+		modifiedBy = LocalVersion
+	case DefaultRemoteDeviceID[:7]:
+		modifiedBy = RemoteVersion
+	}
+
+	return Conflict{
+		Folder:       folder,
+		Path:         filepath.Join(dir, matches[1]+matches[4]),
+		ConflictPath: conflictPath,
+		ModifiedBy:   modifiedBy,
+		Date:         date,
+	}, true
+}
+
+// GetNewConflicts returns the conflict copies detected by the local syncthing since the last call
+func (s *Syncthing) GetNewConflicts(ctx context.Context) ([]Conflict, error) {
+	body, err := s.getEvents(ctx, "rest/events/disk", map[string]string{}, &s.diskEvents, true)
+	if err != nil {
+		oktetoLog.Infof("error getting disk events: %s", err.Error())
+		if strings.Contains(err.Error(), "Client.Timeout") {
+			return nil, oktetoErrors.ErrBusySyncthing
+		}
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+
+	events := []DiskEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		oktetoLog.Infof("error unmarshalling disk events: %s", err.Error())
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+
+	return s.getConflictsFromEvents(events), nil
+}
+
+func (s *Syncthing) getConflictsFromEvents(events []DiskEvent) []Conflict {
+	result := []Conflict{}
+	for _, e := range events {
+		s.diskEvents.update(e.ID)
+		if e.Data.Type != "file" || e.Data.Action == "deleted" {
+			continue
+		}
+		for _, folder := range s.Folders {
+			if GetFolderName(folder) != e.Data.Folder {
+				continue
+			}
+			if conflict, ok := ParseConflict(folder.LocalPath, filepath.FromSlash(e.Data.Path)); ok {
+				result = append(result, conflict)
+			}
+		}
+	}
+	return result
+}
+
+// FindConflicts returns the conflict copies stored in a synchronized folder
+func FindConflicts(fileSystem afero.Fs, folder string) ([]Conflict, error) {
+	result := []Conflict{}
+	err := afero.Walk(fileSystem, folder, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".stversions" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		if conflict, ok := ParseConflict(folder, rel); ok {
+			result = append(result, conflict)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for sync conflicts in '%s': %w", folder, err)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result, nil
+}
+
+// localAndRemotePaths returns the paths of the files with the local and the remote versions of the conflict
+func (c Conflict) localAndRemotePaths() (string, string, error) {
+	original := filepath.Join(c.Folder, c.Path)
+	conflictCopy := filepath.Join(c.Folder, c.ConflictPath)
+	switch c.ModifiedBy {
+	case LocalVersion:
+		return conflictCopy, original, nil
+	case RemoteVersion:
+		return original, conflictCopy, nil
+	default:
+		return "", "", fmt.Errorf("'%s' was created by an unknown device '%s': resolve it manually", c.ConflictPath, c.ModifiedBy)
+	}
+}
+
+// Diff returns the unified diff between the local and the remote versions of the conflicted file
+func (c Conflict) Diff(fileSystem afero.Fs) (string, error) {
+	localPath, remotePath, err := c.localAndRemotePaths()
+	if err != nil {
+		return "", err
+	}
+	local, err := afero.ReadFile(fileSystem, localPath)
+	if err != nil {
+		return "", err
+	}
+	remote, err := afero.ReadFile(fileSystem, remotePath)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(local), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(remote), "\n")),
+		FromFile: fmt.Sprintf("%s (%s)", c.Path, LocalVersion),
+		ToFile:   fmt.Sprintf("%s (%s)", c.Path, RemoteVersion),
+		Context:  3,
+	})
+}
+
+// Resolve keeps the local or the remote version of the conflicted file and removes the conflict copy.
+// Syncthing propagates the result to the other side
+func (c Conflict) Resolve(fileSystem afero.Fs, keep string) error {
+	if keep != LocalVersion && keep != RemoteVersion {
+		return fmt.Errorf("invalid version '%s': must be '%s' or '%s'", keep, LocalVersion, RemoteVersion)
+	}
+	localPath, remotePath, err := c.localAndRemotePaths()
+	if err != nil {
+		return err
+	}
+	original := filepath.Join(c.Folder, c.Path)
+	conflictCopy := filepath.Join(c.Folder, c.ConflictPath)
+	if (keep == LocalVersion && localPath == conflictCopy) || (keep == RemoteVersion && remotePath == conflictCopy) {
+		return fileSystem.Rename(conflictCopy, original)
+	}
+	return fileSystem.Remove(conflictCopy)
+}
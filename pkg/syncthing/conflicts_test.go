@@ -0,0 +1,233 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected Conflict
+		ok       bool
+	}{
+		{
+			name: "remote conflict with extension",
+			path: filepath.Join("src", "main.sync-conflict-20231015-103000-ATOPHFJ.go"),
+			expected: Conflict{
+				Folder:       "/app",
+				Path:         filepath.Join("src", "main.go"),
+				ConflictPath: filepath.Join("src", "main.sync-conflict-20231015-103000-ATOPHFJ.go"),
+				ModifiedBy:   RemoteVersion,
+				Date:         time.Date(2023, 10, 15, 10, 30, 0, 0, time.UTC),
+			},
+			ok: true,
+		},
+		{
+			name: "local conflict without extension",
+			path: "Makefile.sync-conflict-20231015-103000-ABKAVQF",
+			expected: Conflict{
+				Folder:       "/app",
+				Path:         "Makefile",
+				ConflictPath: "Makefile.sync-conflict-20231015-103000-ABKAVQF",
+				ModifiedBy:   LocalVersion,
+				Date:         time.Date(2023, 10, 15, 10, 30, 0, 0, time.UTC),
+			},
+			ok: true,
+		},
+		{
+			name: "unknown device",
+			path: "a.sync-conflict-20231015-103000-XXXXXXX.txt",
+			expected: Conflict{
+				Folder:       "/app",
+				Path:         "a.txt",
+				ConflictPath: "a.sync-conflict-20231015-103000-XXXXXXX.txt",
+				ModifiedBy:   "XXXXXXX",
+				Date:         time.Date(2023, 10, 15, 10, 30, 0, 0, time.UTC),
+			},
+			ok: true,
+		},
+		{
+			name: "not a conflict",
+			path: filepath.Join("src", "main.go"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseConflict("/app", tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := []string{
+		"/app/main.go",
+		"/app/main.sync-conflict-20231015-103000-ATOPHFJ.go",
+		"/app/api/api.go",
+		"/app/api/api.sync-conflict-20231015-090000-ABKAVQF.go",
+		"/app/.stversions/main.sync-conflict-20231014-090000-ABKAVQF.go",
+	}
+	for _, f := range files {
+		require.NoError(t, afero.WriteFile(fs, f, []byte("content"), 0600))
+	}
+
+	result, err := FindConflicts(fs, "/app")
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, filepath.Join("api", "api.go"), result[0].Path)
+	assert.Equal(t, LocalVersion, result[0].ModifiedBy)
+	assert.Equal(t, "main.go", result[1].Path)
+	assert.Equal(t, RemoteVersion, result[1].ModifiedBy)
+}
+
+func TestConflictResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		modifiedBy string
+		keep       string
+		expected   string
+		expectErr  bool
+	}{
+		{
+			name:       "keep remote version stored in the conflict copy",
+			modifiedBy: RemoteVersion,
+			keep:       RemoteVersion,
+			expected:   "copy",
+		},
+		{
+			name:       "keep local version stored in the original",
+			modifiedBy: RemoteVersion,
+			keep:       LocalVersion,
+			expected:   "original",
+		},
+		{
+			name:       "keep local version stored in the conflict copy",
+			modifiedBy: LocalVersion,
+			keep:       LocalVersion,
+			expected:   "copy",
+		},
+		{
+			name:       "unknown device",
+			modifiedBy: "XXXXXXX",
+			keep:       LocalVersion,
+			expectErr:  true,
+		},
+		{
+			name:       "invalid version",
+			modifiedBy: RemoteVersion,
+			keep:       "both",
+			expectErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/app/a.txt", []byte("original"), 0600))
+			require.NoError(t, afero.WriteFile(fs, "/app/a.sync-conflict-20231015-103000-ATOPHFJ.txt", []byte("copy"), 0600))
+			c := Conflict{
+				Folder:       "/app",
+				Path:         "a.txt",
+				ConflictPath: "a.sync-conflict-20231015-103000-ATOPHFJ.txt",
+				ModifiedBy:   tt.modifiedBy,
+			}
+
+			err := c.Resolve(fs, tt.keep)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			content, err := afero.ReadFile(fs, "/app/a.txt")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+			exists, err := afero.Exists(fs, "/app/a.sync-conflict-20231015-103000-ATOPHFJ.txt")
+			require.NoError(t, err)
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestConflictDiff(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/app/a.txt", []byte("hello\nlocal\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/app/a.sync-conflict-20231015-103000-ATOPHFJ.txt", []byte("hello\nremote\n"), 0600))
+	c := Conflict{
+		Folder:       "/app",
+		Path:         "a.txt",
+		ConflictPath: "a.sync-conflict-20231015-103000-ATOPHFJ.txt",
+		ModifiedBy:   RemoteVersion,
+	}
+
+	diff, err := c.Diff(fs)
+	require.NoError(t, err)
+	expected := `--- a.txt (local)
++++ a.txt (remote)
+@@ -1,2 +1,2 @@
+ hello
+-local
++remote
+`
+	assert.Equal(t, expected, diff)
+}
+
+func TestGetConflictsFromEvents(t *testing.T) {
+	s := &Syncthing{
+		Folders: []*Folder{
+			{Name: "1", LocalPath: "/app"},
+		},
+	}
+	events := []DiskEvent{
+		{ID: 3, Data: DataDiskEvent{Action: "added", Type: "file", Folder: GetFolderName(s.Folders[0]), Path: "a.sync-conflict-20231015-103000-ATOPHFJ.txt"}},
+		{ID: 4, Data: DataDiskEvent{Action: "modified", Type: "file", Folder: GetFolderName(s.Folders[0]), Path: "a.txt"}},
+		{ID: 5, Data: DataDiskEvent{Action: "deleted", Type: "file", Folder: GetFolderName(s.Folders[0]), Path: "b.sync-conflict-20231015-103000-ATOPHFJ.txt"}},
+		{ID: 6, Data: DataDiskEvent{Action: "added", Type: "file", Folder: "other", Path: "c.sync-conflict-20231015-103000-ATOPHFJ.txt"}},
+	}
+
+	result := s.getConflictsFromEvents(events)
+	require.Len(t, result, 1)
+	assert.Equal(t, "a.txt", result[0].Path)
+	assert.Equal(t, "/app", result[0].Folder)
+	assert.Equal(t, 6, s.diskEvents.lastID)
+}
+
+func TestNewKeepConflicts(t *testing.T) {
+	dev := &model.Dev{
+		Sync: model.Sync{
+			KeepConflicts: true,
+			Folders: []model.SyncFolder{
+				{LocalPath: "/app", RemotePath: "/app"},
+			},
+		},
+	}
+	s, err := New(dev)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxConflicts, s.MaxConflicts)
+
+	dev.Sync.KeepConflicts = false
+	s, err = New(dev)
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.MaxConflicts)
+}
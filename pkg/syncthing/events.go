@@ -0,0 +1,73 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// eventCursor is the position of the last event read from a syncthing instance
+type eventCursor struct {
+	// startTime identifies the instance: event IDs start again at 1 when syncthing restarts
+	startTime string
+	lastID    int
+	// read is true once the events of the instance were read for the first time
+	read bool
+}
+
+// systemStatus represents the status of a syncthing instance
+type systemStatus struct {
+	StartTime string `json:"startTime"`
+}
+
+// update sets the last event ID read from the instance
+func (c *eventCursor) update(id int) {
+	if id > c.lastID {
+		c.lastID = id
+	}
+}
+
+// syncEventCursor resets the cursor when the syncthing instance restarted since the last call.
+// Otherwise the events of the new instance would be filtered out until their IDs reach the last ID read
+func (s *Syncthing) syncEventCursor(ctx context.Context, cursor *eventCursor, local bool) error {
+	body, err := s.APICall(ctx, "rest/system/status", "GET", http.StatusOK, nil, local, nil, true, maxRetries)
+	if err != nil {
+		return err
+	}
+	status := systemStatus{}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return err
+	}
+	if cursor.startTime != "" && cursor.startTime != status.StartTime {
+		oktetoLog.Infof("syncthing restarted at %s (local=%t), reading its events from the start", status.StartTime, local)
+		cursor.lastID = 0
+	}
+	cursor.startTime = status.StartTime
+	return nil
+}
+
+// getEvents returns the events of a syncthing instance after its cursor
+func (s *Syncthing) getEvents(ctx context.Context, url string, params map[string]string, cursor *eventCursor, local bool) ([]byte, error) {
+	if err := s.syncEventCursor(ctx, cursor, local); err != nil {
+		return nil, err
+	}
+	params["since"] = strconv.Itoa(cursor.lastID)
+	params["timeout"] = "0"
+	return s.APICall(ctx, url, "GET", http.StatusOK, params, local, nil, true, maxRetries)
+}
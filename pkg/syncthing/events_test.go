@@ -0,0 +1,137 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventsServer serves the status and the events of a syncthing instance that can be restarted
+type fakeEventsServer struct {
+	startTime string
+	events    []map[string]interface{}
+	mu        sync.Mutex
+}
+
+func (f *fakeEventsServer) restart(startTime string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.startTime = startTime
+	f.events = nil
+}
+
+func (f *fakeEventsServer) addEvent(data map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, map[string]interface{}{"id": len(f.events) + 1, "data": data})
+}
+
+func (f *fakeEventsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result interface{}
+	switch r.URL.Path {
+	case "/rest/system/status":
+		result = systemStatus{StartTime: f.startTime}
+	case "/rest/events", "/rest/events/disk":
+		since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+		events := []map[string]interface{}{}
+		for _, e := range f.events {
+			if e["id"].(int) > since {
+				events = append(events, e)
+			}
+		}
+		result = events
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func newFakeEventsSyncthing(t *testing.T, server *fakeEventsServer) *Syncthing {
+	t.Helper()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	return &Syncthing{
+		GUIAddress:       u.Host,
+		RemoteGUIAddress: u.Host,
+		Client:           NewAPIClient(),
+		Folders: []*Folder{
+			{Name: "1", LocalPath: "/app", RemotePath: "/app"},
+		},
+	}
+}
+
+func TestGetNewSyncedFilesAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	server := &fakeEventsServer{startTime: "2023-10-15T10:00:00Z"}
+	s := newFakeEventsSyncthing(t, server)
+	item := func(path string) map[string]interface{} {
+		return map[string]interface{}{"folder": "okteto-1", "item": path, "type": "file", "action": "update"}
+	}
+
+	for i := 0; i < 5; i++ {
+		server.addEvent(item("old.go"))
+	}
+	files, err := s.GetNewSyncedFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	server.addEvent(item("main.go"))
+	files, err = s.GetNewSyncedFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []SyncedFile{{Folder: "/app", Path: "main.go"}}, files)
+
+	// the event IDs of the restarted instance start again at 1
+	server.restart("2023-10-15T11:00:00Z")
+	server.addEvent(item("restarted.go"))
+	files, err = s.GetNewSyncedFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []SyncedFile{{Folder: "/app", Path: "restarted.go"}}, files)
+}
+
+func TestGetNewConflictsAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	server := &fakeEventsServer{startTime: "2023-10-15T10:00:00Z"}
+	s := newFakeEventsSyncthing(t, server)
+	disk := func(path string) map[string]interface{} {
+		return map[string]interface{}{"folder": "okteto-1", "path": path, "type": "file", "action": "added"}
+	}
+
+	server.addEvent(disk("a.txt"))
+	server.addEvent(disk("a.sync-conflict-20231015-103000-ATOPHFJ.txt"))
+	conflicts, err := s.GetNewConflicts(ctx)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+
+	server.restart("2023-10-15T11:00:00Z")
+	server.addEvent(disk("b.sync-conflict-20231015-113000-ATOPHFJ.txt"))
+	conflicts, err = s.GetNewConflicts(ctx)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "b.txt", conflicts[0].Path)
+}
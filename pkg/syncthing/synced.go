@@ -0,0 +1,98 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// ItemFinishedEvent represents an ItemFinished event in syncthing: an item was synchronized in a folder
+type ItemFinishedEvent struct {
+	Data DataItemFinishedEvent `json:"data"`
+	ID   int                   `json:"id"`
+}
+
+// DataItemFinishedEvent represents the data of an ItemFinished event in syncthing.
+type DataItemFinishedEvent struct {
+	Error  *string `json:"error"`
+	Folder string  `json:"folder"`
+	Item   string  `json:"item"`
+	Type   string  `json:"type"`
+	Action string  `json:"action"`
+}
+
+// SyncedFile is a file synchronized to the development container
+type SyncedFile struct {
+	// Folder is the remote path of the synchronized folder
+	Folder string
+	// Path is the slash separated path of the file, relative to the folder
+	Path string
+	// Deleted is true if the file was deleted
+	Deleted bool
+}
+
+// GetNewSyncedFiles returns the files synchronized to the development container since the last call.
+// The first call only skips the files synchronized before it
+func (s *Syncthing) GetNewSyncedFiles(ctx context.Context) ([]SyncedFile, error) {
+	params := map[string]string{
+		"events": "ItemFinished",
+	}
+	body, err := s.getEvents(ctx, "rest/events", params, &s.itemEvents, false)
+	if err != nil {
+		oktetoLog.Infof("error getting item events: %s", err.Error())
+		if strings.Contains(err.Error(), "Client.Timeout") {
+			return nil, oktetoErrors.ErrBusySyncthing
+		}
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+
+	events := []ItemFinishedEvent{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		oktetoLog.Infof("error unmarshalling item events: %s", err.Error())
+		return nil, oktetoErrors.ErrLostSyncthing
+	}
+
+	result := s.getSyncedFilesFromEvents(events)
+	if !s.itemEvents.read {
+		s.itemEvents.read = true
+		return []SyncedFile{}, nil
+	}
+	return result, nil
+}
+
+func (s *Syncthing) getSyncedFilesFromEvents(events []ItemFinishedEvent) []SyncedFile {
+	result := []SyncedFile{}
+	for _, e := range events {
+		s.itemEvents.update(e.ID)
+		if e.Data.Error != nil || e.Data.Type == "dir" {
+			continue
+		}
+		for _, folder := range s.Folders {
+			if GetFolderName(folder) != e.Data.Folder {
+				continue
+			}
+			result = append(result, SyncedFile{
+				Folder:  folder.RemotePath,
+				Path:    e.Data.Item,
+				Deleted: e.Data.Action == "delete",
+			})
+		}
+	}
+	return result
+}
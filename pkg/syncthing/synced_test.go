@@ -0,0 +1,45 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncthing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSyncedFilesFromEvents(t *testing.T) {
+	syncErr := "permission denied"
+	s := &Syncthing{
+		Folders: []*Folder{
+			{Name: "1", RemotePath: "/app"},
+			{Name: "2", RemotePath: "/lib"},
+		},
+		itemEvents: eventCursor{lastID: 3},
+	}
+	events := []ItemFinishedEvent{
+		{ID: 4, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "main.go", Type: "file", Action: "update"}},
+		{ID: 5, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "pkg", Type: "dir", Action: "update"}},
+		{ID: 6, Data: DataItemFinishedEvent{Folder: "okteto-2", Item: "util/old.go", Type: "file", Action: "delete"}},
+		{ID: 7, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "locked.go", Type: "file", Action: "update", Error: &syncErr}},
+		{ID: 8, Data: DataItemFinishedEvent{Folder: "okteto-3", Item: "other.go", Type: "file", Action: "update"}},
+	}
+
+	expected := []SyncedFile{
+		{Folder: "/app", Path: "main.go"},
+		{Folder: "/lib", Path: "util/old.go", Deleted: true},
+	}
+	assert.Equal(t, expected, s.getSyncedFilesFromEvents(events))
+	assert.Equal(t, 8, s.itemEvents.lastID)
+}
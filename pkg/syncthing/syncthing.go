@@ -35,6 +35,7 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/shirou/gopsutil/process"
 	"golang.org/x/crypto/bcrypt"
 	yaml "gopkg.in/yaml.v2"
@@ -89,12 +90,15 @@ type Syncthing struct {
 	RescanInterval   string        `yaml:"-"`
 	Compression      string        `yaml:"-"`
 	Folders          []*Folder     `yaml:"folders"`
+	diskEvents       eventCursor   `yaml:"-"`
+	itemEvents       eventCursor   `yaml:"-"`
 	timeout          time.Duration `yaml:"-"`
 	FileWatcherDelay int           `yaml:"-"`
 	RemoteGUIPort    int           `yaml:"-"`
 	RemotePort       int           `yaml:"-"`
 	LocalGUIPort     int           `yaml:"-"`
 	LocalPort        int           `yaml:"-"`
+	MaxConflicts     int           `yaml:"-"`
 	pid              int           `yaml:"-"`
 	ForceSendOnly    bool          `yaml:"-"`
 	ResetDatabase    bool          `yaml:"-"`
@@ -107,9 +111,35 @@ type Folder struct {
 	Name        string `yaml:"name"`
 	LocalPath   string `yaml:"localPath"`
 	RemotePath  string `yaml:"remotePath"`
+	Mode        string `yaml:"mode,omitempty"`
 	Overwritten bool   `yaml:"-"`
 }
 
+// LocalType returns the type of the folder in the local syncthing.
+// Folders synchronized in both directions use the type of the syncthing instance
+func (f *Folder) LocalType(defaultType string) string {
+	switch f.Mode {
+	case model.SyncModeSend:
+		return "sendonly"
+	case model.SyncModeReceive:
+		return "receiveonly"
+	default:
+		return defaultType
+	}
+}
+
+// RemoteType returns the type of the folder in the remote syncthing
+func (f *Folder) RemoteType() string {
+	switch f.Mode {
+	case model.SyncModeSend:
+		return "receiveonly"
+	case model.SyncModeReceive:
+		return "sendonly"
+	default:
+		return "sendreceive"
+	}
+}
+
 // Status represents the status of a syncthing folder.
 type Status struct {
 	State      string `json:"state"`
@@ -238,6 +268,9 @@ func New(dev *model.Dev) (*Syncthing, error) {
 		Compression:      compression,
 		timeout:          dev.Timeout.Default,
 	}
+	if dev.Sync.KeepConflicts {
+		s.MaxConflicts = DefaultMaxConflicts
+	}
 	index := 1
 	for _, sync := range dev.Sync.Folders {
 		result, err := dev.IsSubPathFolder(sync.LocalPath)
@@ -251,6 +284,7 @@ func New(dev *model.Dev) (*Syncthing, error) {
 					Name:       strconv.Itoa(index),
 					LocalPath:  sync.LocalPath,
 					RemotePath: sync.RemotePath,
+					Mode:       sync.GetMode(),
 				},
 			)
 			index++
@@ -374,9 +408,14 @@ func (s *Syncthing) Ping(ctx context.Context, local bool) bool {
 	return false
 }
 
-// Overwrite overwrites local changes to the remote syncthing
+// Overwrite overwrites local changes to the remote syncthing.
+// Folders that only receive changes from the remote syncthing are never overwritten
 func (s *Syncthing) Overwrite(ctx context.Context) error {
 	for _, folder := range s.Folders {
+		if folder.Mode == model.SyncModeReceive {
+			folder.Overwritten = true
+			continue
+		}
 		oktetoLog.Infof("overriding local changes to the remote syncthing path=%s", folder.LocalPath)
 		params := getFolderParameter(folder)
 		_, err := s.APICall(ctx, "rest/db/override", "POST", http.StatusOK, params, true, nil, false, maxRetries)
@@ -787,6 +826,25 @@ func getInSynchronizationLargestFile(e ItemEvent) string {
 	return result
 }
 
+// StartSendReceive configures the local folders to send and receive changes once the initial synchronization is completed
+func (s *Syncthing) StartSendReceive(ctx context.Context) error {
+	s.Type = "sendreceive"
+	s.IgnoreDelete = false
+	if err := s.UpdateConfig(); err != nil {
+		return err
+	}
+	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
+	return s.Restart(ctx)
+}
+
+// GetForwards returns the ports of the remote syncthing that must be forwarded to the development container
+func (s *Syncthing) GetForwards() []forward.Forward {
+	return []forward.Forward{
+		{Local: s.RemotePort, Remote: ClusterPort},
+		{Local: s.RemoteGUIPort, Remote: GUIPort},
+	}
+}
+
 // Restart restarts the syncthing process
 func (s *Syncthing) Restart(ctx context.Context) error {
 	_, err := s.APICall(ctx, "rest/system/restart", "POST", http.StatusOK, nil, true, nil, false, maxRetries)
@@ -14,11 +14,15 @@
 package syncthing
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -41,3 +45,73 @@ func TestGetFiles(t *testing.T) {
 		t.Errorf("got %s, expected %s", info, expected)
 	}
 }
+
+func TestFolderTypes(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           string
+		expectedLocal  string
+		expectedRemote string
+	}{
+		{
+			name:           "both",
+			mode:           model.SyncModeBoth,
+			expectedLocal:  "sendreceive",
+			expectedRemote: "sendreceive",
+		},
+		{
+			name:           "send",
+			mode:           model.SyncModeSend,
+			expectedLocal:  "sendonly",
+			expectedRemote: "receiveonly",
+		},
+		{
+			name:           "receive",
+			mode:           model.SyncModeReceive,
+			expectedLocal:  "receiveonly",
+			expectedRemote: "sendonly",
+		},
+		{
+			name:           "loaded from a previous version",
+			expectedLocal:  "sendreceive",
+			expectedRemote: "sendreceive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Folder{Mode: tt.mode}
+			assert.Equal(t, tt.expectedLocal, f.LocalType("sendreceive"))
+			assert.Equal(t, tt.expectedRemote, f.RemoteType())
+		})
+	}
+}
+
+func TestConfigFolderTypes(t *testing.T) {
+	s := &Syncthing{
+		Type: "sendonly",
+		Folders: []*Folder{
+			{Name: "1", LocalPath: "/src", RemotePath: "/src", Mode: model.SyncModeBoth},
+			{Name: "2", LocalPath: "/dist", RemotePath: "/dist", Mode: model.SyncModeReceive},
+		},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, configTemplate.Execute(buf, s))
+	assert.Contains(t, buf.String(), `<folder id="okteto-1" label="1" path="/src" type="sendonly"`)
+	assert.Contains(t, buf.String(), `<folder id="okteto-2" label="2" path="/dist" type="receiveonly"`)
+}
+
+func TestNewSyncMode(t *testing.T) {
+	dev := &model.Dev{
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: "/src", RemotePath: "/src"},
+				{LocalPath: "/dist", RemotePath: "/dist", Mode: model.SyncModeReceive},
+			},
+		},
+	}
+	s, err := New(dev)
+	require.NoError(t, err)
+	require.Len(t, s.Folders, 2)
+	assert.Equal(t, model.SyncModeBoth, s.Folders[0].Mode)
+	assert.Equal(t, model.SyncModeReceive, s.Folders[1].Mode)
+}
@@ -14,6 +14,9 @@
 package types
 
 import (
+	"io"
+	"time"
+
 	"github.com/okteto/okteto/pkg/model"
 )
 
@@ -32,24 +35,55 @@ type HostMap struct {
 	IP       string
 }
 
+// BuildTiming is the time spent on each phase of a build, computed from the BuildKit vertexes
+type BuildTiming struct {
+	// Queue is the time since the build is requested until BuildKit starts the first step
+	Queue time.Duration
+	// Solve is the time spent running the build steps
+	Solve time.Duration
+	// Push is the time spent exporting the image and pushing it to the registry
+	Push time.Duration
+	// Steps is the number of build steps
+	Steps int
+	// CachedSteps is the number of build steps resolved from the BuildKit cache
+	CachedSteps int
+}
+
 // BuildOptions define the options available for build
 type BuildOptions struct {
-	Manifest    *model.Manifest
-	File        string
-	OutputMode  string
-	Path        string
-	Platform    string
-	Tag         string
-	Target      string
-	Namespace   string
-	K8sContext  string
-	DevTag      string
+	Manifest   *model.Manifest
+	File       string
+	OutputMode string
+	Path       string
+	Platform   string
+	Tag        string
+	Target     string
+	Namespace  string
+	K8sContext string
+	DevTag     string
+	// Parallelism is the maximum number of services built at the same time
+	Parallelism int
+	// Provenance is the provenance attestation mode: 'mode=min' or 'mode=max'
+	Provenance string
+	// SignKey is the path of the private key used to sign the images
+	SignKey     string
 	BuildArgs   []string
 	CacheFrom   []string
 	Secrets     []string
 	ExportCache []string
+	// SecretValues are the values of the secrets sourced from Okteto secrets or from a command, by secret id.
+	// They are kept in memory and never written to disk
+	SecretValues map[string][]byte
+	// Outputs are the exports of the build result with the syntax 'type=local|tar|oci,dest=path'.
+	// When defined, the image is not pushed
+	Outputs []string
 	// CommandArgs comes from the user input on the command
 	CommandArgs []string
+	// Timing is filled with the timing of the build when it is not nil
+	Timing *BuildTiming
+	// ProgressWriter receives the build progress instead of the standard output when it is not nil.
+	// It is used to group the output of the services built at the same time
+	ProgressWriter io.Writer
 
 	SshSessions []BuildSshSession
 	ExtraHosts  []HostMap
@@ -57,4 +91,10 @@ type BuildOptions struct {
 	BuildToGlobal bool
 	NoCache       bool
 	EnableStages  bool
+	// SBOM enables the SBOM attestation of the image
+	SBOM bool
+	// Sign signs the pushed images with a cosign compatible signature
+	Sign bool
+	// TimingReport displays the timing of each service after building the manifest
+	TimingReport bool
 }